@@ -0,0 +1,249 @@
+package main
+
+import (
+	"go/ast"
+	"go/token"
+	"strconv"
+	"strings"
+
+	"github.com/NickyBoy89/java2go/nodeutil"
+	sitter "github.com/smacker/go-tree-sitter"
+)
+
+// Java arrays are translated into Go slices, which have the same semantics
+// when they are passed around, since they both refer to the same elements
+
+// elementType returns the Java type of the elements of an array type, after
+// indexing into the array the given number of times
+func elementType(arrayType string, depth int) string {
+	for ; depth > 0; depth-- {
+		arrayType = strings.TrimSuffix(arrayType, "[]")
+	}
+	return arrayType
+}
+
+// isArrayType tests if a Java type is an array
+func isArrayType(javaType string) bool {
+	return strings.HasSuffix(javaType, "[]")
+}
+
+// ArrayCreation translates all of the forms of creating a new array, which
+// either give the array's elements, such as `new int[] {1, 2, 3}`, or the
+// lengths of some of the array's dimensions, such as `new int[2][3]` or
+// `new int[2][]`
+func ArrayCreation(node *sitter.Node, source []byte, ctx Ctx) ast.Expr {
+	arrayType := ExpressionType(node, source, ctx)
+	if value := node.ChildByFieldName("value"); value != nil {
+		return ArrayInitializer(value, source, ctx, arrayType)
+	}
+
+	ev := ctx.evaluation()
+	dimensionNodes, dimensionPointers := []*sitter.Node{}, []*ast.Expr{}
+	for _, child := range nodeutil.NamedChildrenOf(node) {
+		if child.Type() == "dimensions_expr" {
+			dimensionNodes = append(dimensionNodes, child.NamedChild(0))
+			dimensionPointers = append(dimensionPointers, ev.parse(child, source, identity))
+		}
+	}
+	dimensions := evaluated(dimensionPointers)
+
+	// Go's `make` can only create a single dimension
+	if len(dimensions) == 1 {
+		return &ast.CallExpr{
+			Fun:  &ast.Ident{Name: "make"},
+			Args: []ast.Expr{&ast.Ident{Name: ctx.currentFile.GoType(arrayType)}, dimensions[0]},
+		}
+	}
+
+	// The helpers take their dimensions as Go's `int`, and take an empty array
+	// that has the type of the created array's elements
+	for ind, dimension := range dimensions {
+		if !isUntypedConstant(dimensionNodes[ind], source) {
+			dimensions[ind] = callFunc("int", dimension)
+		}
+	}
+	element := &ast.CompositeLit{Type: &ast.Ident{Name: ctx.currentFile.GoType(elementType(arrayType, 1))}}
+
+	var helper string
+	switch len(dimensions) {
+	case 2:
+		helper = "MultiDimensionArray"
+	case 3:
+		helper = "MultiDimensionArray3"
+	case 4:
+		helper = "MultiDimensionArray4"
+	default:
+		return nestedArray(arrayType, dimensions, ctx)
+	}
+	return callStdjava(helper, append([]ast.Expr{element}, dimensions...)...)
+}
+
+// nestedArray creates an array with any number of specified dimensions, which
+// is a function that makes each dimension in a loop over the one before it,
+// and is called with the lengths of the dimensions
+func nestedArray(arrayType string, dimensions []ast.Expr, ctx Ctx) ast.Expr {
+	array := &ast.Ident{Name: "arr"}
+	lengths := make([]*ast.Ident, len(dimensions))
+	for ind := range lengths {
+		lengths[ind] = &ast.Ident{Name: "dim" + strconv.Itoa(ind)}
+	}
+
+	body := makeDimension(array, token.DEFINE, arrayType, lengths, ctx)
+	body = append(body, &ast.ReturnStmt{Results: []ast.Expr{array}})
+	return &ast.CallExpr{
+		Fun: &ast.FuncLit{
+			Type: &ast.FuncType{
+				Params:  &ast.FieldList{List: []*ast.Field{{Names: lengths, Type: &ast.Ident{Name: "int"}}}},
+				Results: &ast.FieldList{List: []*ast.Field{{Type: &ast.Ident{Name: ctx.currentFile.GoType(arrayType)}}}},
+			},
+			Body: &ast.BlockStmt{List: body},
+		},
+		Args: dimensions,
+	}
+}
+
+// makeDimension makes the outermost of the given dimensions of an array, and
+// then makes the rest of the dimensions for each of its elements
+func makeDimension(target ast.Expr, tok token.Token, arrayType string, lengths []*ast.Ident, ctx Ctx) []ast.Stmt {
+	stmts := []ast.Stmt{&ast.AssignStmt{
+		Lhs: []ast.Expr{target},
+		Tok: tok,
+		Rhs: []ast.Expr{&ast.CallExpr{
+			Fun:  &ast.Ident{Name: "make"},
+			Args: []ast.Expr{&ast.Ident{Name: ctx.currentFile.GoType(arrayType)}, lengths[0]},
+		}},
+	}}
+	if len(lengths) == 1 {
+		return stmts
+	}
+
+	// Each dimension's index is numbered the same as its length
+	index := &ast.Ident{Name: "ind" + strings.TrimPrefix(lengths[0].Name, "dim")}
+	element := &ast.IndexExpr{X: target, Index: index}
+	return append(stmts, &ast.RangeStmt{
+		Key:  index,
+		Tok:  token.DEFINE,
+		X:    target,
+		Body: &ast.BlockStmt{List: makeDimension(element, token.ASSIGN, elementType(arrayType, 1), lengths[1:], ctx)},
+	})
+}
+
+// ArrayInitializer translates an array initializer, such as `{1, 2, 3}`, into
+// a literal of the given Java array type
+func ArrayInitializer(node *sitter.Node, source []byte, ctx Ctx, arrayType string) ast.Expr {
+	literal := arrayLiteral(node, source, ctx, arrayType)
+	if arrayType != "" {
+		literal.Type = &ast.Ident{Name: ctx.currentFile.GoType(arrayType)}
+	}
+	return literal
+}
+
+// arrayLiteral translates the elements of an array initializer, which are
+// converted to the array's element type
+//
+// Nested initializers leave out their types, since Go infers them from the
+// type of the outer literal
+func arrayLiteral(node *sitter.Node, source []byte, ctx Ctx, arrayType string) *ast.CompositeLit {
+	element := elementType(arrayType, 1)
+
+	ev := ctx.evaluation()
+	itemPointers := []*ast.Expr{}
+	for _, child := range nodeutil.NamedChildrenOf(node) {
+		if child.Type() == "array_initializer" {
+			item := new(ast.Expr)
+			*item = arrayLiteral(child, source, ctx, element)
+			ev.add(item)
+			itemPointers = append(itemPointers, item)
+			continue
+		}
+
+		valueNode := child
+		itemPointers = append(itemPointers, ev.parse(child, source, func(value ast.Expr) ast.Expr {
+			if element == "" {
+				return value
+			}
+			return AssignmentConversion(value, valueNode, source, ctx, element)
+		}))
+	}
+	return &ast.CompositeLit{Elts: evaluated(itemPointers)}
+}
+
+// ArrayLength translates the `length` field of an array, which is the `len`
+// of a slice in Go, converted to Java's `int`
+func ArrayLength(array ast.Expr) ast.Expr {
+	return callFunc(primitiveTypes["int"], callFunc("len", array))
+}
+
+// isArrayClone tests if a method call clones an array, such as `arr.clone()`
+func isArrayClone(node *sitter.Node, source []byte, ctx Ctx) bool {
+	object := node.ChildByFieldName("object")
+	return object != nil &&
+		node.ChildByFieldName("name").Content(source) == "clone" &&
+		node.ChildByFieldName("arguments").NamedChildCount() == 0 &&
+		isArrayType(ExpressionType(object, source, ctx))
+}
+
+// ArrayConversion converts an array into an array of another type, which Java
+// allows when the elements of the array are a subtype of the other array's
+// elements, such as assigning a `String[]` to an `Object[]`, or casting it back
+//
+// Go's slices are not covariant, so the array becomes a slice of the other
+// type, which `stdjava` keeps as a view of the same array
+func ArrayConversion(value ast.Expr, node *sitter.Node, source []byte, ctx Ctx, to string) ast.Expr {
+	from := ExpressionType(node, source, ctx)
+	if !isArrayType(from) || ctx.currentFile == nil {
+		return value
+	}
+	fromType, toType := ctx.currentFile.GoType(from), ctx.currentFile.GoType(to)
+	if fromType == toType || node.Type() == "null_literal" {
+		return value
+	}
+	return &ast.CallExpr{
+		Fun: &ast.IndexExpr{
+			X:     &ast.SelectorExpr{X: &ast.Ident{Name: "stdjava"}, Sel: &ast.Ident{Name: "CovariantArray"}},
+			Index: &ast.Ident{Name: strings.TrimPrefix(toType, "[]")},
+		},
+		Args: []ast.Expr{value},
+	}
+}
+
+// storesElement tests if the target of an assignment is an element of an array
+// of references, which may be a view of an array of another type
+func storesElement(target *sitter.Node, source []byte, ctx Ctx) bool {
+	if target.Type() != "array_access" {
+		return false
+	}
+	javaType := ExpressionType(target, source, ctx)
+	return javaType != "" && !isPrimitive(javaType)
+}
+
+// storeElement converts the assignment of an element of an array into a call to
+// `stdjava.Store`, which stores it into every view of the array
+//
+// The type of the elements is given explicitly, since the value may have a
+// different type, such as an `int32` that is stored into an `[]any`
+func storeElement(stmt ast.Stmt, elementType string) ast.Stmt {
+	assign, ok := stmt.(*ast.AssignStmt)
+	if !ok || len(assign.Lhs) != 1 {
+		return stmt
+	}
+	element, ok := assign.Lhs[0].(*ast.IndexExpr)
+	if !ok {
+		return stmt
+	}
+
+	value := assign.Rhs[0]
+	switch assign.Tok {
+	case token.ASSIGN:
+	case token.ADD_ASSIGN:
+		// Strings are the only references that can be added to
+		value = &ast.BinaryExpr{X: element, Op: token.ADD, Y: value}
+	default:
+		return stmt
+	}
+	store := callStdjava("Store", element.X, element.Index, value)
+	if elementType != "" {
+		store.Fun = &ast.IndexExpr{X: store.Fun, Index: &ast.Ident{Name: elementType}}
+	}
+	return &ast.ExprStmt{X: store}
+}
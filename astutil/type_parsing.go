@@ -3,10 +3,52 @@ package astutil
 import (
 	"fmt"
 	"go/ast"
+	"strings"
 
 	sitter "github.com/smacker/go-tree-sitter"
 )
 
+// CharType is the Go type that Java's `char` type is represented as, which is
+// either a `rune`, or a `uint16` to match the size of Java's chars
+var CharType = "rune"
+
+// The Go types of the primitive values that each of Java's boxed types contain,
+// where a `Character` contains a char
+var boxedTypes = map[string]string{
+	"Boolean":   "bool",
+	"Byte":      "int8",
+	"Short":     "int16",
+	"Character": "",
+	"Integer":   "int32",
+	"Long":      "int64",
+	"Float":     "float32",
+	"Double":    "float64",
+}
+
+// JavaExceptions maps the JDK's exceptions to their types in `stdjava`, which
+// mirror the JDK's hierarchy of exceptions
+var JavaExceptions = map[string]string{
+	"Throwable":                       "Throwable",
+	"Exception":                       "Exception",
+	"Error":                           "JavaError",
+	"AssertionError":                  "AssertionError",
+	"RuntimeException":                "RuntimeException",
+	"ArithmeticException":             "ArithmeticException",
+	"ClassCastException":              "ClassCastException",
+	"IllegalArgumentException":        "IllegalArgumentException",
+	"NumberFormatException":           "NumberFormatException",
+	"IllegalStateException":           "IllegalStateException",
+	"IndexOutOfBoundsException":       "IndexOutOfBoundsException",
+	"ArrayIndexOutOfBoundsException":  "ArrayIndexOutOfBoundsException",
+	"StringIndexOutOfBoundsException": "StringIndexOutOfBoundsException",
+	"ArrayStoreException":             "ArrayStoreException",
+	"NegativeArraySizeException":      "NegativeArraySizeException",
+	"NullPointerException":            "NullPointerException",
+	"UnsupportedOperationException":   "UnsupportedOperationException",
+	"ConcurrentModificationException": "ConcurrentModificationException",
+	"NoSuchElementException":          "NoSuchElementException",
+}
+
 func ParseType(node *sitter.Node, source []byte) ast.Expr {
 	switch node.Type() {
 	case "integral_type":
@@ -18,9 +60,10 @@ func ParseType(node *sitter.Node, source []byte) ast.Expr {
 		case "long":
 			return &ast.Ident{Name: "int64"}
 		case "char":
-			return &ast.Ident{Name: "rune"}
+			return &ast.Ident{Name: CharType}
 		case "byte":
-			return &ast.Ident{Name: node.Content(source)}
+			// Java's bytes are signed, unlike Go's
+			return &ast.Ident{Name: "int8"}
 		}
 
 		panic(fmt.Errorf("Unknown integral type: %v", node.Child(0).Type()))
@@ -41,12 +84,37 @@ func ParseType(node *sitter.Node, source []byte) ast.Expr {
 		// A generic type is any type that is of the form GenericType<T>
 		return &ast.Ident{Name: node.NamedChild(0).Content(source)}
 	case "array_type":
-		return &ast.ArrayType{Elt: ParseType(node.NamedChild(0), source)}
+		// Each pair of brackets in the dimensions is another dimension of the array
+		arrayType := ParseType(node.ChildByFieldName("element"), source)
+		for range strings.Split(node.ChildByFieldName("dimensions").Content(source), "[")[1:] {
+			arrayType = &ast.ArrayType{Elt: arrayType}
+		}
+		return arrayType
 	case "type_identifier": // Any reference type
 		switch node.Content(source) {
 		// Special case for strings, because in Go, these are primitive types
 		case "String":
 			return &ast.Ident{Name: "string"}
+		// Any value can be an object
+		case "Object":
+			return &ast.Ident{Name: "any"}
+		// Both of Java's string builders are implemented by stdjava
+		case "StringBuilder", "StringBuffer":
+			return &ast.StarExpr{X: &ast.SelectorExpr{X: &ast.Ident{Name: "stdjava"}, Sel: &ast.Ident{Name: "StringBuilder"}}}
+		}
+
+		// The JDK's exceptions may be any exception, including the program's own
+		// exceptions, so they are all `Thrown`
+		if _, exception := JavaExceptions[node.Content(source)]; exception {
+			return &ast.SelectorExpr{X: &ast.Ident{Name: "stdjava"}, Sel: &ast.Ident{Name: "Thrown"}}
+		}
+
+		// Boxed types are pointers to their primitive values, where `null` is nil
+		if primitive, boxed := boxedTypes[node.Content(source)]; boxed {
+			if primitive == "" {
+				primitive = CharType
+			}
+			return &ast.StarExpr{X: &ast.Ident{Name: primitive}}
 		}
 
 		return &ast.StarExpr{
@@ -0,0 +1,231 @@
+package main
+
+import (
+	"go/ast"
+	"go/token"
+	"strings"
+
+	"github.com/NickyBoy89/java2go/nodeutil"
+	"github.com/NickyBoy89/java2go/symbol"
+	log "github.com/sirupsen/logrus"
+	sitter "github.com/smacker/go-tree-sitter"
+)
+
+// Variables, fields, parameters and methods that are declared with one of
+// Java's boxed types, such as `Integer`, are pointers to their primitive
+// values, where `null` is nil, and values are boxed and unboxed with the
+// functions from `stdjava`
+//
+// This includes the type arguments of generic types, such as the elements of a
+// `List<Integer>`, the elements of arrays, and the types of functional
+// interfaces, so that they can hold `null` the same as in Java. Boxes are only
+// unboxed where they are used as primitive values
+
+// isBoxedType tests if a Java type is one of the boxed types, such as `Integer`
+func isBoxedType(javaType string) bool {
+	return symbol.UnboxedType(javaType) != javaType
+}
+
+// isBoxDefinition tests if a variable or a method is declared as a box
+func isBoxDefinition(def *symbol.Definition) bool {
+	return def != nil && isBoxedType(def.OriginalType) && strings.HasPrefix(def.Type, "*")
+}
+
+// holdsBox tests if an expression evaluates to a box, which is true for the
+// variables and methods that are declared with a boxed type, the elements of
+// arrays and collections of a boxed type, as well as for boxing conversions,
+// such as `Integer.valueOf(1)` or `(Integer) object`
+func holdsBox(node *sitter.Node, source []byte, ctx Ctx) bool {
+	switch node.Type() {
+	case "parenthesized_expression", "assignment_expression", "update_expression":
+		return holdsBox(node.NamedChild(0), source, ctx)
+	case "identifier":
+		return isBoxDefinition(findVariable(node, source, ctx))
+	case "field_access":
+		if class := classOfExpression(node.ChildByFieldName("object"), source, ctx); class != nil {
+			return isBoxDefinition(class.FindFieldByName(node.ChildByFieldName("field").Content(source)))
+		}
+	case "method_invocation":
+		if result := wrapperMethodType(node, source, ctx); result != "" {
+			return isBoxedType(result)
+		}
+		if method := resolveMethod(node, source, ctx); method != nil && isBoxedType(method.OriginalType) {
+			return isBoxDefinition(method)
+		}
+		// The methods of collections, optionals, streams and functional
+		// interfaces, and methods that return type parameters, return boxes for
+		// the type arguments that are boxed
+		return isBoxedType(ExpressionType(node, source, ctx))
+	case "array_access", "ternary_expression":
+		return isBoxedType(ExpressionType(node, source, ctx))
+	case "cast_expression":
+		return isBoxedType(node.ChildByFieldName("type").Content(source))
+	}
+	return false
+}
+
+// BoxingConversion converts a value into a box of the given boxed type, which
+// Java does when a primitive value is assigned to a boxed type
+//
+// Values that are already boxes are left as they are, and any other objects
+// are cast to the box
+func BoxingConversion(value ast.Expr, node *sitter.Node, source []byte, ctx Ctx, to string) ast.Expr {
+	if node.Type() == "null_literal" || holdsBox(node, source, ctx) {
+		return value
+	}
+	from := ExpressionType(node, source, ctx)
+	if from == "" || isPrimitive(symbol.UnboxedType(from)) {
+		value = AssignmentConversion(value, node, source, ctx, symbol.UnboxedType(to))
+	}
+	return boxValue(value, from, to)
+}
+
+// UnboxingConversion converts a box into a value of the given Java type, where
+// primitive types get the box's value, which is a `NullPointerException` if
+// the box is null, and objects get either the value or nil
+func UnboxingConversion(value ast.Expr, to string) ast.Expr {
+	switch to = symbol.UnboxedType(to); {
+	case isPrimitive(to):
+		return callStdjava("Unbox", value)
+	case to == "Object":
+		return callStdjava("BoxedValue", value)
+	}
+	return value
+}
+
+// ObjectConversion converts a value that is stored as an `Object`, such as the
+// argument of `List.contains`, where values keep their types, so constants are
+// given the types that they would have been boxed as, and boxes are stored as
+// their values
+func ObjectConversion(value ast.Expr, node *sitter.Node, source []byte, ctx Ctx) ast.Expr {
+	if holdsBox(node, source, ctx) {
+		return callStdjava("BoxedValue", value)
+	}
+	return DeclarationConversion(value, node, source, ctx, symbol.UnboxedType(ExpressionType(node, source, ctx)))
+}
+
+// stringArguments returns the Java types of the arguments of a call to one of
+// the methods of strings or string builders, which are translated by the types
+// of their arguments
+//
+// Boxes are passed as objects to the methods that format their arguments, such
+// as `append`, which formats null as "null", and are unboxed for the others
+func stringArguments(node *sitter.Node, source []byte, ctx Ctx, arguments []ast.Expr, formatted bool) []string {
+	types := argumentTypes(node.ChildByFieldName("arguments"), source, ctx)
+	for ind, argument := range nodeutil.NamedChildrenOf(node.ChildByFieldName("arguments")) {
+		switch {
+		case !holdsBox(argument, source, ctx):
+		case formatted && types[ind] == "Character":
+			// Boxes of chars would be formatted as numbers
+			arguments[ind] = StringConversion(arguments[ind], types[ind])
+			types[ind] = "String"
+		case formatted:
+			types[ind] = "Object"
+		default:
+			arguments[ind] = callStdjava("Unbox", arguments[ind])
+			types[ind] = symbol.UnboxedType(types[ind])
+		}
+	}
+	return types
+}
+
+// unboxedByParent tests if an expression is used as a primitive value by the
+// expression or statement that contains it, such as the operands of
+// arithmetic, or the condition of an `if` statement, so that a box has to be
+// unboxed
+func unboxedByParent(node *sitter.Node, source []byte, ctx Ctx) bool {
+	parent := node.Parent()
+	if parent == nil {
+		return false
+	}
+
+	switch parent.Type() {
+	case "parenthesized_expression":
+		return unboxedByParent(parent, source, ctx)
+	case "unary_expression", "dimensions_expr":
+		return true
+	case "binary_expression":
+		other := parent.Child(0)
+		if other.Equal(node) {
+			other = parent.Child(2)
+		}
+		switch parent.Child(1).Content(source) {
+		case "+":
+			// Anything concatenated to a string is converted like an object
+			return ExpressionType(other, source, ctx) != "String"
+		case "==", "!=":
+			// Two boxes are compared as references, and a box is only null if it is
+			// compared to null
+			return other.Type() != "null_literal" && !holdsBox(other, source, ctx)
+		}
+		return true
+	case "array_access":
+		return parent.NamedChild(1).Equal(node)
+	case "assignment_expression":
+		// The value of a compound assignment is used in its operation
+		return parent.Child(2).Equal(node) && parent.Child(1).Content(source) != "="
+	case "cast_expression":
+		return isPrimitive(parent.ChildByFieldName("type").Content(source))
+	case "if_statement", "while_statement", "do_statement", "for_statement", "ternary_expression":
+		condition := parent.ChildByFieldName("condition")
+		return condition != nil && condition.Equal(node)
+	case "switch_statement", "switch_expression":
+		return parent.NamedChild(0).Equal(node)
+	}
+	return false
+}
+
+// compareBoxes warns about an `==` or `!=` that compares two boxes, which
+// compares them as references, the same as in Java, where boxes with the same
+// value are only the same box if the value is small enough to be cached
+func compareBoxes(node *sitter.Node, source []byte, ctx Ctx) {
+	switch node.Child(1).Content(source) {
+	case "==", "!=":
+	default:
+		return
+	}
+	if holdsBox(node.Child(0), source, ctx) && holdsBox(node.Child(2), source, ctx) {
+		log.WithFields(log.Fields{
+			"expression": node.Content(source),
+			"className":  ctx.className,
+		}).Info("Boxes are compared as references, which are only equal for the same box, or for small values that share the same box")
+	}
+}
+
+// boxedUpdate translates an update or a compound assignment of a box, such as
+// `count++` or `count += 2`, which puts the result into a new box
+func boxedUpdate(target, result ast.Expr, javaType string) ast.Stmt {
+	return &ast.AssignStmt{
+		Lhs: []ast.Expr{target},
+		Tok: token.ASSIGN,
+		Rhs: []ast.Expr{&ast.CallExpr{
+			Fun:  &ast.IndexExpr{X: stdjavaType("Box"), Index: &ast.Ident{Name: primitiveTypes[symbol.UnboxedType(javaType)]}},
+			Args: []ast.Expr{result},
+		}},
+	}
+}
+
+// boxValue boxes a value of the given Java type that isn't a box, which is cast
+// to the box if it is an object
+func boxValue(value ast.Expr, from, to string) ast.Expr {
+	goType := &ast.Ident{Name: primitiveTypes[symbol.UnboxedType(to)]}
+	if from = symbol.UnboxedType(from); from != "" && !isPrimitive(from) {
+		return &ast.CallExpr{Fun: &ast.IndexExpr{X: stdjavaType("CastBox"), Index: goType}, Args: []ast.Expr{value}}
+	}
+	return &ast.CallExpr{Fun: &ast.IndexExpr{X: stdjavaType("Box"), Index: goType}, Args: []ast.Expr{value}}
+}
+
+// boxedIncrement returns the value that an increment or a decrement of a box,
+// such as `count++`, puts into the box's new box
+func boxedIncrement(target ast.Expr, node *sitter.Node, source []byte) ast.Expr {
+	// The operator is either before or after the variable
+	operator := node.Child(0)
+	if operator.IsNamed() {
+		operator = node.Child(1)
+	}
+	op := token.ADD
+	if operator.Content(source) == "--" {
+		op = token.SUB
+	}
+	return &ast.BinaryExpr{X: callStdjava("Unbox", target), Op: op, Y: &ast.BasicLit{Kind: token.INT, Value: "1"}}
+}
@@ -4,11 +4,18 @@ import (
 	"bytes"
 	"context"
 	"go/ast"
+	"go/format"
+	"go/importer"
+	"go/parser"
 	"go/printer"
 	"go/token"
+	"go/types"
 	"os"
 	"testing"
 
+	"github.com/NickyBoy89/java2go/astutil"
+	"github.com/NickyBoy89/java2go/parsing"
+	"github.com/NickyBoy89/java2go/symbol"
 	sitter "github.com/smacker/go-tree-sitter"
 	"github.com/smacker/go-tree-sitter/java"
 )
@@ -33,8 +40,50 @@ func ParseSourceAst(fileName string) (*sitter.Node, []byte) {
 }
 
 func ParseAst(fileName string) ast.Node {
-	root, source := ParseSourceAst(fileName)
-	return ParseNode(root, source, Ctx{}).(ast.Node)
+	sourceCode, err := os.ReadFile(fileName)
+	if err != nil {
+		panic(err)
+	}
+
+	// Parse, generate, and resolve the symbols the same way that the main program does
+	file := parsing.SourceFile{Name: fileName, Source: sourceCode}
+	if err := file.ParseAST(); err != nil {
+		panic(err)
+	}
+	symbol.AddSymbolsToPackage(file.ParseSymbols())
+	ResolveFile(file)
+	ResolveLocals(file)
+
+	ctx := Ctx{currentFile: file.Symbols, currentClass: file.Symbols.BaseClass}
+	return ParseNode(file.Ast, file.Source, ctx).(ast.Node)
+}
+
+// typeCheck type checks generated code, which catches code that doesn't
+// compile, ignoring the soft errors, such as unused variables, that translated
+// programs may have
+func typeCheck(t *testing.T, generated []byte) {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "generated.go", generated, 0)
+	if err != nil {
+		t.Fatalf("Generated code that can't be parsed: %v\n%s", err, generated)
+	}
+
+	var errs []error
+	conf := types.Config{
+		Importer: importer.ForCompiler(fset, "source", nil),
+		Error: func(err error) {
+			if typeErr, ok := err.(types.Error); !ok || !typeErr.Soft {
+				errs = append(errs, err)
+			}
+		},
+	}
+	conf.Check("main", fset, []*ast.File{file}, nil)
+	for _, err := range errs {
+		t.Error(err)
+	}
+	if len(errs) > 0 {
+		t.Log(string(generated))
+	}
 }
 
 // This tests the increment and decrement handling on increment and decrement
@@ -56,7 +105,7 @@ func TestAssignments(t *testing.T) {
 	if err != nil {
 		t.Fatal(err)
 	}
-	t.Log(generated.String())
+	typeCheck(t, generated.Bytes())
 }
 
 // This tests two alternate ways of calling the new constructor
@@ -97,3 +146,326 @@ func TestConditionOrdering(t *testing.T) {
 	}
 	t.Log(generated.String())
 }
+
+// This tests that local variables are tracked in their scopes, and renamed
+// consistently when they collide with Go's built-in identifiers
+func TestLocalScopes(t *testing.T) {
+	var generated bytes.Buffer
+	err := printer.Fprint(&generated, token.NewFileSet(), ParseAst("testfiles/LocalScopes.java"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Log(generated.String())
+}
+
+// This tests the renaming of identifiers that conflict with Go's keywords and
+// built-in identifiers, as well as the method receivers
+func TestBuiltinCollisions(t *testing.T) {
+	var generated bytes.Buffer
+	err := printer.Fprint(&generated, token.NewFileSet(), ParseAst("testfiles/BuiltinCollisions.java"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Log(generated.String())
+}
+
+// This tests casts between primitive types, as well as reference types
+func TestNumericCasts(t *testing.T) {
+	var generated bytes.Buffer
+	err := printer.Fprint(&generated, token.NewFileSet(), ParseAst("testfiles/NumericCasts.java"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Log(generated.String())
+}
+
+func TestNumericPromotion(t *testing.T) {
+	var generated bytes.Buffer
+	err := printer.Fprint(&generated, token.NewFileSet(), ParseAst("testfiles/NumericPromotion.java"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Log(generated.String())
+}
+
+func TestStringConcatenation(t *testing.T) {
+	var generated bytes.Buffer
+	err := printer.Fprint(&generated, token.NewFileSet(), ParseAst("testfiles/StringConcatenation.java"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Log(generated.String())
+}
+
+func TestCharLiterals(t *testing.T) {
+	var generated bytes.Buffer
+	err := printer.Fprint(&generated, token.NewFileSet(), ParseAst("testfiles/CharLiterals.java"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Log(generated.String())
+}
+
+func TestCharLiteralsAsUint16(t *testing.T) {
+	astutil.CharType, primitiveTypes["char"] = "uint16", "uint16"
+	defer func() {
+		astutil.CharType, primitiveTypes["char"] = "rune", "rune"
+	}()
+
+	var generated bytes.Buffer
+	err := printer.Fprint(&generated, token.NewFileSet(), ParseAst("testfiles/CharLiterals.java"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Log(generated.String())
+}
+
+func TestNumericLiterals(t *testing.T) {
+	var generated bytes.Buffer
+	err := printer.Fprint(&generated, token.NewFileSet(), ParseAst("testfiles/NumericLiterals.java"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	typeCheck(t, generated.Bytes())
+}
+
+func TestConstantExpressions(t *testing.T) {
+	var generated bytes.Buffer
+	err := printer.Fprint(&generated, token.NewFileSet(), ParseAst("testfiles/ConstantExpressions.java"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	typeCheck(t, generated.Bytes())
+}
+
+func TestTextBlocks(t *testing.T) {
+	source, err := os.ReadFile("testfiles/TextBlocks.java")
+	if err != nil {
+		t.Fatal(err)
+	}
+	file := parsing.SourceFile{Name: "TextBlocks.java", Source: source}
+	if err := file.ParseAST(); err != nil {
+		t.Fatal(err)
+	}
+	if file.Ast.HasError() {
+		t.Fatalf("Escaping the text blocks left parse errors: %s", file.Ast.String())
+	}
+
+	var generated bytes.Buffer
+	err = printer.Fprint(&generated, token.NewFileSet(), ParseAst("testfiles/TextBlocks.java"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Log(generated.String())
+}
+
+func TestTypedLambdas(t *testing.T) {
+	var generated bytes.Buffer
+	err := printer.Fprint(&generated, token.NewFileSet(), ParseAst("testfiles/TypedLambdas.java"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Log(generated.String())
+}
+
+func TestMethodReferences(t *testing.T) {
+	var generated bytes.Buffer
+	err := printer.Fprint(&generated, token.NewFileSet(), ParseAst("testfiles/MethodReferences.java"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Log(generated.String())
+}
+
+func TestCaptures(t *testing.T) {
+	var generated bytes.Buffer
+	err := printer.Fprint(&generated, token.NewFileSet(), ParseAst("testfiles/Captures.java"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Log(generated.String())
+}
+
+func TestSimplifiedCaptures(t *testing.T) {
+	simplifyCaptures = true
+	defer func() {
+		simplifyCaptures = false
+	}()
+
+	var generated bytes.Buffer
+	err := printer.Fprint(&generated, token.NewFileSet(), ParseAst("testfiles/Captures.java"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Log(generated.String())
+}
+
+// The order that side effects happen in is easy to break, so this compares the
+// generated code to the expected output, in `testfiles/output`
+func TestSideEffects(t *testing.T) {
+	// The file is translated by itself, without the classes of the other tests
+	defer func(global *symbol.GlobalSymbols) {
+		symbol.GlobalScope = global
+	}(symbol.GlobalScope)
+	symbol.GlobalScope = &symbol.GlobalSymbols{Packages: make(map[string]*symbol.PackageScope)}
+
+	var generated bytes.Buffer
+	err := printer.Fprint(&generated, token.NewFileSet(), ParseAst("testfiles/SideEffects.java"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	formatted, err := format.Source(generated.Bytes())
+	if err != nil {
+		t.Fatalf("Generated code that can't be formatted: %v\n%s", err, generated.String())
+	}
+
+	expected, err := os.ReadFile("testfiles/output/SideEffects.go.golden")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(formatted) != string(expected) {
+		t.Errorf("Expected the output to match testfiles/output/SideEffects.go.golden, got:\n%s", formatted)
+	}
+	typeCheck(t, formatted)
+}
+
+func TestTernaries(t *testing.T) {
+	var generated bytes.Buffer
+	err := printer.Fprint(&generated, token.NewFileSet(), ParseAst("testfiles/Ternaries.java"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	typeCheck(t, generated.Bytes())
+}
+
+func TestShifts(t *testing.T) {
+	var generated bytes.Buffer
+	err := printer.Fprint(&generated, token.NewFileSet(), ParseAst("testfiles/Shifts.java"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Log(generated.String())
+}
+
+func TestArrays(t *testing.T) {
+	var generated bytes.Buffer
+	err := printer.Fprint(&generated, token.NewFileSet(), ParseAst("testfiles/Arrays.java"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Log(generated.String())
+}
+
+func TestExceptions(t *testing.T) {
+	var generated bytes.Buffer
+	err := printer.Fprint(&generated, token.NewFileSet(), ParseAst("testfiles/Exceptions.java"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	typeCheck(t, generated.Bytes())
+}
+
+func TestStrings(t *testing.T) {
+	var generated bytes.Buffer
+	err := printer.Fprint(&generated, token.NewFileSet(), ParseAst("testfiles/Strings.java"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	typeCheck(t, generated.Bytes())
+}
+
+func TestStringsWithUint16Chars(t *testing.T) {
+	astutil.CharType, primitiveTypes["char"] = "uint16", "uint16"
+	defer func() {
+		astutil.CharType, primitiveTypes["char"] = "rune", "rune"
+	}()
+
+	var generated bytes.Buffer
+	err := printer.Fprint(&generated, token.NewFileSet(), ParseAst("testfiles/Strings.java"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	typeCheck(t, generated.Bytes())
+}
+
+func TestStringBuilders(t *testing.T) {
+	var generated bytes.Buffer
+	err := printer.Fprint(&generated, token.NewFileSet(), ParseAst("testfiles/StringBuilders.java"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Log(generated.String())
+}
+
+func TestCollections(t *testing.T) {
+	var generated bytes.Buffer
+	err := printer.Fprint(&generated, token.NewFileSet(), ParseAst("testfiles/Collections.java"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Log(generated.String())
+}
+
+func TestNativeCollections(t *testing.T) {
+	nativeCollections = true
+	defer func() {
+		nativeCollections = false
+	}()
+
+	var generated bytes.Buffer
+	err := printer.Fprint(&generated, token.NewFileSet(), ParseAst("testfiles/NativeCollections.java"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	typeCheck(t, generated.Bytes())
+}
+
+func TestStreams(t *testing.T) {
+	var generated bytes.Buffer
+	err := printer.Fprint(&generated, token.NewFileSet(), ParseAst("testfiles/Streams.java"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Log(generated.String())
+}
+
+func TestLoweredStreams(t *testing.T) {
+	lowerStreams = true
+	defer func() {
+		lowerStreams = false
+	}()
+
+	var generated bytes.Buffer
+	err := printer.Fprint(&generated, token.NewFileSet(), ParseAst("testfiles/Streams.java"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Log(generated.String())
+}
+
+func TestOptionals(t *testing.T) {
+	var generated bytes.Buffer
+	err := printer.Fprint(&generated, token.NewFileSet(), ParseAst("testfiles/Optionals.java"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Log(generated.String())
+}
+
+func TestBoxed(t *testing.T) {
+	var generated bytes.Buffer
+	err := printer.Fprint(&generated, token.NewFileSet(), ParseAst("testfiles/Boxed.java"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Log(generated.String())
+}
+
+func TestMathMethods(t *testing.T) {
+	var generated bytes.Buffer
+	err := printer.Fprint(&generated, token.NewFileSet(), ParseAst("testfiles/MathMethods.java"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Log(generated.String())
+}
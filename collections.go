@@ -0,0 +1,365 @@
+package main
+
+import (
+	"go/ast"
+	"regexp"
+
+	"github.com/NickyBoy89/java2go/nodeutil"
+	"github.com/NickyBoy89/java2go/symbol"
+	sitter "github.com/smacker/go-tree-sitter"
+)
+
+// Java's collections from `java.util` are translated into the generic
+// collections in `stdjava`, which have the same methods, but with Go's names
+
+// collectionMethod is one of the methods of Java's collections
+type collectionMethod struct {
+	// The name of the method in `stdjava`
+	name string
+	// The Java types of the method's parameters, in terms of the collection's
+	// type parameters, where an `Object` can be any value
+	parameters []string
+	// The Java type that the method returns
+	result string
+	// Whether the method's arguments are left out, since Java only uses them
+	// for their type, such as the array that `toArray` takes
+	typeOnly bool
+}
+
+// The methods of each of Java's collection interfaces, and `Object`, which are
+// looked up by their Java names
+var collectionInterfaces = map[string]map[string][]collectionMethod{
+	"Object": {
+		"equals":   {{name: "Equals", parameters: []string{"Object"}, result: "boolean"}},
+		"hashCode": {{name: "HashCode", result: "int"}},
+		"toString": {{name: "String", result: "String"}},
+	},
+	"Iterator": {
+		"hasNext": {{name: "HasNext", result: "boolean"}},
+		"next":    {{name: "Next", result: "E"}},
+		"remove":  {{name: "Remove", result: "void"}},
+	},
+	"Iterable": {
+		"iterator": {{name: "Iterator", result: "Iterator<E>"}},
+		"forEach":  {{name: "ForEach", parameters: []string{"Consumer<E>"}, result: "void"}},
+	},
+	"Collection": {
+		"size":        {{name: "Size", result: "int"}},
+		"isEmpty":     {{name: "IsEmpty", result: "boolean"}},
+		"contains":    {{name: "Contains", parameters: []string{"Object"}, result: "boolean"}},
+		"add":         {{name: "Add", parameters: []string{"E"}, result: "boolean"}},
+		"remove":      {{name: "Remove", parameters: []string{"Object"}, result: "boolean"}},
+		"clear":       {{name: "Clear", result: "void"}},
+		"addAll":      {{name: "AddAll", parameters: []string{"Collection<E>"}, result: "boolean"}},
+		"containsAll": {{name: "ContainsAll", parameters: []string{"Collection<E>"}, result: "boolean"}},
+		"removeAll":   {{name: "RemoveAll", parameters: []string{"Collection<E>"}, result: "boolean"}},
+		"retainAll":   {{name: "RetainAll", parameters: []string{"Collection<E>"}, result: "boolean"}},
+		"removeIf":    {{name: "RemoveIf", parameters: []string{"Predicate<E>"}, result: "boolean"}},
+		"toArray": {
+			{name: "ToArray", result: "E[]"},
+			{name: "ToArray", parameters: []string{"E[]"}, result: "E[]", typeOnly: true},
+		},
+	},
+	"List": {
+		"get":         {{name: "Get", parameters: []string{"int"}, result: "E"}},
+		"set":         {{name: "Set", parameters: []string{"int", "E"}, result: "E"}},
+		"add":         {{name: "AddAt", parameters: []string{"int", "E"}, result: "void"}},
+		"remove":      {{name: "RemoveAt", parameters: []string{"int"}, result: "E"}},
+		"indexOf":     {{name: "IndexOf", parameters: []string{"Object"}, result: "int"}},
+		"lastIndexOf": {{name: "LastIndexOf", parameters: []string{"Object"}, result: "int"}},
+		"sort":        {{name: "Sort", parameters: []string{"Comparator<E>"}, result: "void"}},
+		"replaceAll":  {{name: "ReplaceAll", parameters: []string{"UnaryOperator<E>"}, result: "void"}},
+	},
+	"Queue": {
+		"offer":   {{name: "Offer", parameters: []string{"E"}, result: "boolean"}},
+		"poll":    {{name: "Poll", result: "E"}},
+		"peek":    {{name: "Peek", result: "E"}},
+		"element": {{name: "Element", result: "E"}},
+		"remove":  {{name: "RemoveHead", result: "E"}},
+	},
+	"Deque": {
+		"addFirst":           {{name: "AddFirst", parameters: []string{"E"}, result: "void"}},
+		"addLast":            {{name: "AddLast", parameters: []string{"E"}, result: "void"}},
+		"offerFirst":         {{name: "OfferFirst", parameters: []string{"E"}, result: "boolean"}},
+		"offerLast":          {{name: "OfferLast", parameters: []string{"E"}, result: "boolean"}},
+		"removeFirst":        {{name: "RemoveFirst", result: "E"}},
+		"removeLast":         {{name: "RemoveLast", result: "E"}},
+		"pollFirst":          {{name: "PollFirst", result: "E"}},
+		"pollLast":           {{name: "PollLast", result: "E"}},
+		"getFirst":           {{name: "GetFirst", result: "E"}},
+		"getLast":            {{name: "GetLast", result: "E"}},
+		"peekFirst":          {{name: "PeekFirst", result: "E"}},
+		"peekLast":           {{name: "PeekLast", result: "E"}},
+		"push":               {{name: "Push", parameters: []string{"E"}, result: "void"}},
+		"pop":                {{name: "Pop", result: "E"}},
+		"descendingIterator": {{name: "DescendingIterator", result: "Iterator<E>"}},
+	},
+	"Map": {
+		"size":            {{name: "Size", result: "int"}},
+		"isEmpty":         {{name: "IsEmpty", result: "boolean"}},
+		"get":             {{name: "Get", parameters: []string{"Object"}, result: "V"}},
+		"getOrDefault":    {{name: "GetOrDefault", parameters: []string{"Object", "V"}, result: "V"}},
+		"containsKey":     {{name: "ContainsKey", parameters: []string{"Object"}, result: "boolean"}},
+		"containsValue":   {{name: "ContainsValue", parameters: []string{"Object"}, result: "boolean"}},
+		"put":             {{name: "Put", parameters: []string{"K", "V"}, result: "V"}},
+		"putIfAbsent":     {{name: "PutIfAbsent", parameters: []string{"K", "V"}, result: "V"}},
+		"putAll":          {{name: "PutAll", parameters: []string{"Map<K, V>"}, result: "void"}},
+		"remove":          {{name: "Remove", parameters: []string{"Object"}, result: "V"}},
+		"clear":           {{name: "Clear", result: "void"}},
+		"keySet":          {{name: "KeySet", result: "Set<K>"}},
+		"values":          {{name: "Values", result: "Collection<V>"}},
+		"entrySet":        {{name: "EntrySet", result: "Set<Map.Entry<K, V>>"}},
+		"forEach":         {{name: "ForEach", parameters: []string{"BiConsumer<K, V>"}, result: "void"}},
+		"computeIfAbsent": {{name: "ComputeIfAbsent", parameters: []string{"K", "Function<K, V>"}, result: "V"}},
+		"merge":           {{name: "Merge", parameters: []string{"K", "V", "BiFunction<V, V, V>"}, result: "V"}},
+	},
+	"Entry": {
+		"getKey":   {{name: "GetKey", result: "K"}},
+		"getValue": {{name: "GetValue", result: "V"}},
+		"setValue": {{name: "SetValue", parameters: []string{"V"}, result: "V"}},
+	},
+}
+
+// The interfaces that each of the collections implement, in the order that
+// their methods are looked up in, so that overloads in subinterfaces, such as
+// `List.remove(int)`, are found first
+var collectionHierarchy = map[string][]string{
+	"Iterator":   {"Iterator"},
+	"Iterable":   {"Iterable", "Object"},
+	"Collection": {"Collection", "Iterable", "Object"},
+	"List":       {"List", "Collection", "Iterable", "Object"},
+	"ArrayList":  {"List", "Collection", "Iterable", "Object"},
+	"LinkedList": {"List", "Deque", "Queue", "Collection", "Iterable", "Object"},
+	"Set":        {"Collection", "Iterable", "Object"},
+	"HashSet":    {"Collection", "Iterable", "Object"},
+	"Queue":      {"Queue", "Collection", "Iterable", "Object"},
+	"Deque":      {"Deque", "Queue", "Collection", "Iterable", "Object"},
+	"ArrayDeque": {"Deque", "Queue", "Collection", "Iterable", "Object"},
+	"Map":        {"Map", "Object"},
+	"HashMap":    {"Map", "Object"},
+	"Map.Entry":  {"Entry", "Object"},
+	"Entry":      {"Entry", "Object"},
+}
+
+// The collections that can be created, which are the classes
+var collectionClasses = map[string]bool{
+	"ArrayList":  true,
+	"LinkedList": true,
+	"ArrayDeque": true,
+	"HashSet":    true,
+	"HashMap":    true,
+}
+
+// The collections that can be given an initial capacity
+var collectionCapacities = map[string]bool{
+	"ArrayList":  true,
+	"ArrayDeque": true,
+	"HashSet":    true,
+	"HashMap":    true,
+}
+
+// accepts tests if a value of a Java type can be passed as an argument for a
+// parameter, which only matters for overloads that take an index, since an
+// `Integer` is an element, not an index
+func accepts(parameter, argumentType string) bool {
+	if parameter != "int" {
+		return true
+	}
+	switch argumentType {
+	case "int", "short", "byte", "char":
+		return true
+	}
+	return false
+}
+
+// findCollectionMethod looks up the method of a collection that a call with
+// arguments of the given types calls, or returns nil if it isn't one of the
+// methods that are translated
+func findCollectionMethod(collection, name string, argumentTypes []string) *collectionMethod {
+	for _, kind := range collectionHierarchy[collection] {
+		for _, method := range collectionInterfaces[kind][name] {
+			if len(method.parameters) != len(argumentTypes) {
+				continue
+			}
+			matches := true
+			for ind, parameter := range method.parameters {
+				matches = matches && accepts(parameter, argumentTypes[ind])
+			}
+			if matches {
+				return &method
+			}
+		}
+	}
+	return nil
+}
+
+// instantiateCollection substitutes the type arguments of a collection's type
+// into a type from one of its methods, where missing arguments are `Object`s
+func instantiateCollection(collection string, arguments []string, javaType string) string {
+	for ind, parameter := range symbol.CollectionTypeParameters(collection) {
+		argument := "Object"
+		if ind < len(arguments) {
+			argument = arguments[ind]
+		}
+		javaType = regexp.MustCompile(`\b`+parameter+`\b`).ReplaceAllLiteralString(javaType, argument)
+	}
+	return javaType
+}
+
+// collectionCall finds the collection that a method invocation calls a method
+// of, and the method that it calls, or returns nil if it doesn't call one of
+// the collections' methods
+func collectionCall(node *sitter.Node, source []byte, ctx Ctx) (string, []string, *collectionMethod) {
+	object := node.ChildByFieldName("object")
+	if object == nil || ctx.currentFile == nil {
+		return "", nil, nil
+	}
+	objectType := ExpressionType(object, source, ctx)
+	collection := ctx.currentFile.JavaCollection(objectType)
+	if collection == "" {
+		return "", nil, nil
+	}
+	_, typeArguments := symbol.SplitTypeArguments(objectType)
+	method := findCollectionMethod(collection, node.ChildByFieldName("name").Content(source), argumentTypes(node.ChildByFieldName("arguments"), source, ctx))
+	return collection, typeArguments, method
+}
+
+// collectionMethodType returns the Java type that a call to a method of a
+// collection returns, or an empty string if it doesn't call one of them
+func collectionMethodType(node *sitter.Node, source []byte, ctx Ctx) string {
+	collection, typeArguments, method := collectionCall(node, source, ctx)
+	if method == nil {
+		return ""
+	}
+	return instantiateCollection(collection, typeArguments, method.result)
+}
+
+// CollectionMethod translates a call to one of the methods of Java's
+// collections, where each argument is converted to the type of its parameter
+//
+// Returns nil if the method is not one of the methods that can be translated
+func CollectionMethod(node *sitter.Node, source []byte, ctx Ctx, object ast.Expr, arguments []ast.Expr) ast.Expr {
+	collection, typeArguments, method := collectionCall(node, source, ctx)
+	if method == nil {
+		return nil
+	}
+	if method.typeOnly {
+		return &ast.CallExpr{Fun: &ast.SelectorExpr{X: object, Sel: &ast.Ident{Name: method.name}}}
+	}
+
+	return &ast.CallExpr{
+		Fun:  &ast.SelectorExpr{X: object, Sel: &ast.Ident{Name: method.name}},
+		Args: convertCollectionArguments(node, source, ctx, collection, typeArguments, method, arguments),
+	}
+}
+
+// convertCollectionArguments converts the arguments of a call to a method of
+// a collection to the types of the method's parameters
+func convertCollectionArguments(node *sitter.Node, source []byte, ctx Ctx, collection string, typeArguments []string, method *collectionMethod, arguments []ast.Expr) []ast.Expr {
+	argumentNodes := nodeutil.NamedChildrenOf(node.ChildByFieldName("arguments"))
+	for ind, parameter := range method.parameters {
+		if parameter == "Object" {
+			// Values are compared with their types, so constants are given the
+			// types that they would have been boxed as
+			arguments[ind] = ObjectConversion(arguments[ind], argumentNodes[ind], source, ctx)
+			continue
+		}
+		arguments[ind] = AssignmentConversion(arguments[ind], argumentNodes[ind], source, ctx, instantiateCollection(collection, typeArguments, parameter))
+	}
+	return arguments
+}
+
+// NewCollection creates one of Java's collections, such as
+// `new ArrayList<>()`, which is either empty, or starts with the elements of
+// another collection, or is given a capacity
+//
+// Returns nil if the collection can't be created with the given arguments
+func NewCollection(node *sitter.Node, source []byte, ctx Ctx, arguments []ast.Expr) ast.Expr {
+	objectType := node.ChildByFieldName("type")
+	collection := ctx.currentFile.JavaCollection(objectType.Content(source))
+	if !collectionClasses[collection] {
+		return nil
+	}
+
+	_, typeArguments := symbol.SplitTypeArguments(createdType(node, source, ctx))
+	goArguments := make([]ast.Expr, len(symbol.CollectionTypeParameters(collection)))
+	for ind := range goArguments {
+		goArguments[ind] = &ast.Ident{Name: "any"}
+		if ind < len(typeArguments) {
+			goArguments[ind] = &ast.Ident{Name: ctx.currentFile.GoType(typeArguments[ind])}
+		}
+	}
+
+	constructor := "New" + collection
+	switch len(arguments) {
+	case 0:
+	case 1:
+		argumentNode := node.ChildByFieldName("arguments").NamedChild(0)
+		if accepts("int", ExpressionType(argumentNode, source, ctx)) {
+			if !collectionCapacities[collection] {
+				return nil
+			}
+			constructor += "Capacity"
+			arguments[0] = AssignmentConversion(arguments[0], argumentNode, source, ctx, "int")
+		} else {
+			constructor += "From"
+		}
+	default:
+		return nil
+	}
+
+	var function ast.Expr = &ast.IndexExpr{X: stdjavaType(constructor), Index: goArguments[0]}
+	if len(goArguments) > 1 {
+		function = &ast.IndexListExpr{X: stdjavaType(constructor), Indices: goArguments}
+	}
+	return &ast.CallExpr{Fun: function, Args: arguments}
+}
+
+// createdType returns the Java type of a created object, where the type
+// arguments that were left out with the diamond operator, such as
+// `new ArrayList<>()`, are inferred from the type that the object is assigned
+// to, or returned as, including by a lambda
+func createdType(node *sitter.Node, source []byte, ctx Ctx) string {
+	objectType := node.ChildByFieldName("type")
+	if objectType.Type() != "generic_type" || objectType.NamedChild(1).NamedChildCount() > 0 {
+		return objectType.Content(source)
+	}
+
+	_, typeArguments := symbol.SplitTypeArguments(targetType(node, source, ctx))
+	className := objectType.NamedChild(0).Content(source)
+	if len(typeArguments) == 0 {
+		return className
+	}
+	return className + "<" + joinTypes(typeArguments) + ">"
+}
+
+// targetType returns the Java type that an expression is assigned to, or
+// returned as, or an empty string if it is used in any other way
+func targetType(node *sitter.Node, source []byte, ctx Ctx) string {
+	switch parent := node.Parent(); parent.Type() {
+	case "variable_declarator":
+		return parent.Parent().ChildByFieldName("type").Content(source)
+	case "assignment_expression":
+		return ExpressionType(parent.ChildByFieldName("left"), source, ctx)
+	case "return_statement":
+		return ctx.returnType
+	case "lambda_expression":
+		// The body of a lambda is returned as the result of its interface
+		if parent.ChildByFieldName("body").Equal(node) {
+			return ctx.returnType
+		}
+	}
+	return ""
+}
+
+// joinTypes joins a list of Java types, such as the arguments of a generic type
+func joinTypes(javaTypes []string) string {
+	joined := ""
+	for ind, javaType := range javaTypes {
+		if ind > 0 {
+			joined += ", "
+		}
+		joined += javaType
+	}
+	return joined
+}
@@ -0,0 +1,634 @@
+package main
+
+import (
+	"go/ast"
+	"go/constant"
+	"go/token"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/NickyBoy89/java2go/symbol"
+	sitter "github.com/smacker/go-tree-sitter"
+)
+
+// The Go types that each of Java's primitive types are represented as
+var primitiveTypes = symbol.PrimitiveTypes
+
+// isPrimitive tests if a Java type is one of the primitive types
+func isPrimitive(javaType string) bool {
+	_, primitive := primitiveTypes[javaType]
+	return primitive
+}
+
+// isIntegral tests if a Java type is one of the integer types, including `char`
+func isIntegral(javaType string) bool {
+	switch javaType {
+	case "byte", "short", "char", "int", "long":
+		return true
+	}
+	return false
+}
+
+// isFloating tests if a Java type is either a `float` or a `double`
+func isFloating(javaType string) bool {
+	return javaType == "float" || javaType == "double"
+}
+
+// isNumeric tests if a Java type is any of the numeric primitive types
+func isNumeric(javaType string) bool {
+	return isIntegral(javaType) || isFloating(javaType)
+}
+
+// callFunc generates a call to a function with the given name
+func callFunc(name string, args ...ast.Expr) *ast.CallExpr {
+	return &ast.CallExpr{Fun: &ast.Ident{Name: name}, Args: args}
+}
+
+// callStdjava generates a call to a function in the `stdjava` package
+func callStdjava(name string, args ...ast.Expr) *ast.CallExpr {
+	return &ast.CallExpr{
+		Fun:  &ast.SelectorExpr{X: &ast.Ident{Name: "stdjava"}, Sel: &ast.Ident{Name: name}},
+		Args: args,
+	}
+}
+
+// ConvertPrimitive converts a value from one of Java's primitive types into
+// another, following Java's rules for widening and narrowing conversions
+//
+// If the original type of the value is not known, it is left empty, and the
+// value is converted directly
+func ConvertPrimitive(value ast.Expr, from, to string) ast.Expr {
+	if from == to {
+		return value
+	}
+
+	switch {
+	case isFloating(from) && isIntegral(to):
+		// Floating point numbers are truncated into either an int or a long, and
+		// then narrowed into the smaller types
+		if to == "long" {
+			return callStdjava("FloatToLong", value)
+		}
+		return ConvertPrimitive(callStdjava("FloatToInt", value), "int", to)
+	case to == "char" && from != "" && primitiveTypes["char"] != "uint16":
+		// A char is an unsigned 16-bit value, so any higher bits are discarded
+		return callFunc(primitiveTypes["char"], callFunc("uint16", value))
+	}
+
+	return callFunc(primitiveTypes[to], value)
+}
+
+// CastExpr generates a Java cast of a value to the given Java type
+// Primitive types are converted, and reference types are checked at runtime
+func CastExpr(value ast.Expr, from, to string, toType ast.Expr) ast.Expr {
+	if isPrimitive(to) && (from == "" || isPrimitive(from)) {
+		return ConvertPrimitive(value, from, to)
+	}
+
+	// Reference types, as well as unboxing a reference into a primitive, are
+	// type assertions that fail with a `ClassCastException`
+	return &ast.CallExpr{
+		Fun: &ast.IndexExpr{
+			X:     &ast.SelectorExpr{X: &ast.Ident{Name: "stdjava"}, Sel: &ast.Ident{Name: "Cast"}},
+			Index: toType,
+		},
+		Args: []ast.Expr{value},
+	}
+}
+
+// narrowConstant evaluates the cast of a constant number into one of Java's
+// numeric types, since Go does not allow constants to overflow when they are
+// converted, such as in `(byte) 200` or `(float) 1e40`, or to be divided by
+// zero, such as in `(int) (0.0 / 0.0)`
+//
+// Returns nil if the value is not a numeric constant
+func narrowConstant(node *sitter.Node, source []byte, to string) ast.Expr {
+	if !isNumeric(to) {
+		return nil
+	}
+
+	value, ok := integerConstant(node, source)
+	if floating, _, isFloating := floatingConstant(node, source); isFloating {
+		if !isIntegral(to) {
+			return floatingValue(floating, to)
+		}
+		value = truncateFloat(floating, to)
+	} else if !ok || !isIntegral(to) {
+		return nil
+	}
+
+	var narrowed int64
+	switch to {
+	case "byte":
+		narrowed = int64(int8(value))
+	case "short":
+		narrowed = int64(int16(value))
+	case "char":
+		narrowed = int64(uint16(value))
+	case "int":
+		narrowed = int64(int32(value))
+	default:
+		narrowed = value
+	}
+
+	return callFunc(primitiveTypes[to], &ast.BasicLit{Kind: token.INT, Value: strconv.FormatInt(narrowed, 10)})
+}
+
+// truncateFloat converts a floating point number into a long, or into an int
+// for the smaller integral types, which is rounded towards zero, saturated at
+// the limits of the type, and is zero for NaN
+func truncateFloat(value float64, to string) int64 {
+	low, high := float64(math.MinInt32), float64(math.MaxInt32)
+	if to == "long" {
+		low, high = math.MinInt64, math.MaxInt64
+	}
+	switch {
+	case math.IsNaN(value):
+		return 0
+	case value <= low:
+		return int64(low)
+	case value >= high:
+		// The largest long can't be represented exactly as a float64
+		if to == "long" {
+			return math.MaxInt64
+		}
+		return int64(high)
+	}
+	return int64(value)
+}
+
+// floatingValue generates a floating point constant of one of the floating
+// point types, where values that are too large for a float become infinite,
+// and infinities, NaN, and negative zero are generated with the `math` package
+func floatingValue(value float64, to string) ast.Expr {
+	if to == "float" {
+		value = float64(float32(value))
+	}
+
+	var result ast.Expr
+	switch {
+	case math.IsNaN(value):
+		result = &ast.CallExpr{Fun: &ast.SelectorExpr{X: &ast.Ident{Name: "math"}, Sel: &ast.Ident{Name: "NaN"}}}
+	case math.IsInf(value, 0):
+		sign := "1"
+		if value < 0 {
+			sign = "-1"
+		}
+		result = &ast.CallExpr{
+			Fun:  &ast.SelectorExpr{X: &ast.Ident{Name: "math"}, Sel: &ast.Ident{Name: "Inf"}},
+			Args: []ast.Expr{&ast.BasicLit{Kind: token.INT, Value: sign}},
+		}
+	case value == 0 && math.Signbit(value):
+		// Go's constants don't have a negative zero, so `-0.0` would be zero
+		result = &ast.CallExpr{
+			Fun: &ast.SelectorExpr{X: &ast.Ident{Name: "math"}, Sel: &ast.Ident{Name: "Copysign"}},
+			Args: []ast.Expr{
+				&ast.BasicLit{Kind: token.INT, Value: "0"},
+				&ast.BasicLit{Kind: token.INT, Value: "-1"},
+			},
+		}
+	default:
+		result = &ast.BasicLit{Kind: token.FLOAT, Value: strconv.FormatFloat(value, 'g', -1, 64)}
+	}
+	return callFunc(primitiveTypes[to], result)
+}
+
+// floatingConstant evaluates a constant expression that has a floating point
+// type, such as `1e40` or `1.0 / 0`, and returns its value along with its Java
+// type, following Java's rules for the arithmetic of floats and doubles
+//
+// Returns false if the value is not a floating point constant
+func floatingConstant(node *sitter.Node, source []byte) (float64, string, bool) {
+	switch node.Type() {
+	case "parenthesized_expression":
+		return floatingConstant(node.NamedChild(0), source)
+	case "unary_expression":
+		value, javaType, ok := floatingConstant(node.Child(1), source)
+		switch node.Child(0).Content(source) {
+		case "-":
+			return -value, javaType, ok
+		case "+":
+			return value, javaType, ok
+		}
+	case "binary_expression":
+		left, leftType, leftOk := numericConstant(node.Child(0), source)
+		right, rightType, rightOk := numericConstant(node.Child(2), source)
+		if !leftOk || !rightOk || (!isFloating(leftType) && !isFloating(rightType)) {
+			return 0, "", false
+		}
+		javaType := binaryPromotion(leftType, rightType)
+
+		var result float64
+		switch node.Child(1).Content(source) {
+		case "+":
+			result = left + right
+		case "-":
+			result = left - right
+		case "*":
+			result = left * right
+		case "/":
+			result = left / right
+		case "%":
+			result = math.Mod(left, right)
+		default:
+			return 0, "", false
+		}
+		if javaType == "float" {
+			result = float64(float32(result))
+		}
+		return result, javaType, true
+	case "decimal_floating_point_literal", "hex_floating_point_literal":
+		digits := strings.ReplaceAll(node.Content(source), "_", "")
+		javaType := "double"
+		switch digits[len(digits)-1] {
+		case 'F', 'f':
+			javaType, digits = "float", digits[:len(digits)-1]
+		case 'D', 'd':
+			digits = digits[:len(digits)-1]
+		}
+		value, err := strconv.ParseFloat(digits, 64)
+		if err != nil {
+			return 0, "", false
+		}
+		if javaType == "float" {
+			value = float64(float32(value))
+		}
+		return value, javaType, true
+	}
+	return 0, "", false
+}
+
+// numericConstant evaluates either an integer or a floating point constant,
+// and returns its value as a float64 along with its Java type
+func numericConstant(node *sitter.Node, source []byte) (float64, string, bool) {
+	if value, javaType, ok := floatingConstant(node, source); ok {
+		return value, javaType, true
+	}
+	value, ok := integerConstant(node, source)
+	if !ok {
+		return 0, "", false
+	}
+	if isLongLiteral(node, source) {
+		return float64(value), "long", true
+	}
+	return float64(value), "int", true
+}
+
+// isIntegerLiteral tests if a node is an integer literal in any base
+func isIntegerLiteral(node *sitter.Node) bool {
+	switch node.Type() {
+	case "decimal_integer_literal", "hex_integer_literal", "octal_integer_literal", "binary_integer_literal":
+		return true
+	}
+	return false
+}
+
+// isLongLiteral tests if an integer constant is a long, such as `-1L`, or an
+// operation where either of the operands is a long
+func isLongLiteral(node *sitter.Node, source []byte) bool {
+	switch node.Type() {
+	case "parenthesized_expression":
+		return isLongLiteral(node.NamedChild(0), source)
+	case "unary_expression":
+		return isLongLiteral(node.Child(1), source)
+	case "binary_expression":
+		return isLongLiteral(node.Child(0), source) || isLongLiteral(node.Child(2), source)
+	}
+	return strings.HasSuffix(node.Content(source), "L") || strings.HasSuffix(node.Content(source), "l")
+}
+
+// integerConstant evaluates a constant expression of integer literals, such as
+// `-1` or `2147483647 * 2`, which wraps around in the same way as Java's ints
+// and longs
+func integerConstant(node *sitter.Node, source []byte) (int64, bool) {
+	value, ok := integerArithmetic(node, source, true)
+	if !ok {
+		return 0, false
+	}
+	result, _ := constant.Int64Val(value)
+	return result, true
+}
+
+// The operators of Java's integer operations, where division truncates the
+// result, in the same way as Go's integer division
+var integerOperators = map[string]token.Token{
+	"+": token.ADD,
+	"-": token.SUB,
+	"*": token.MUL,
+	"/": token.QUO_ASSIGN,
+	"%": token.REM,
+	"&": token.AND,
+	"|": token.OR,
+	"^": token.XOR,
+}
+
+// integerArithmetic evaluates a constant expression of integer literals,
+// either with Java's arithmetic, where every operation wraps around to the
+// width of an int or a long, or exactly, which is how Go evaluates constants
+//
+// Returns false if the expression is not an integer constant, or divides by zero
+func integerArithmetic(node *sitter.Node, source []byte, wrap bool) (constant.Value, bool) {
+	var result constant.Value
+	switch node.Type() {
+	case "parenthesized_expression":
+		return integerArithmetic(node.NamedChild(0), source, wrap)
+	case "unary_expression":
+		value, ok := integerArithmetic(node.Child(1), source, wrap)
+		if !ok {
+			return nil, false
+		}
+		switch node.Child(0).Content(source) {
+		case "-":
+			result = constant.UnaryOp(token.SUB, value, 0)
+		case "+":
+			result = value
+		case "~":
+			result = constant.UnaryOp(token.XOR, value, 0)
+		default:
+			return nil, false
+		}
+	case "binary_expression":
+		operator, integral := integerOperators[node.Child(1).Content(source)]
+		if !integral {
+			return nil, false
+		}
+		left, leftOk := integerArithmetic(node.Child(0), source, wrap)
+		right, rightOk := integerArithmetic(node.Child(2), source, wrap)
+		if !leftOk || !rightOk {
+			return nil, false
+		}
+		// Dividing by a constant zero throws an exception at runtime in Java
+		if (operator == token.QUO_ASSIGN || operator == token.REM) && constant.Sign(right) == 0 {
+			return nil, false
+		}
+		result = constant.BinaryOp(left, operator, right)
+	case "decimal_integer_literal", "hex_integer_literal", "octal_integer_literal", "binary_integer_literal":
+		literal := strings.ReplaceAll(node.Content(source), "_", "")
+		long := strings.HasSuffix(literal, "L") || strings.HasSuffix(literal, "l")
+		value, err := strconv.ParseUint(strings.TrimRight(literal, "lL"), 0, 64)
+		if err != nil {
+			return nil, false
+		}
+		// Hex literals can represent negative numbers, such as `0xFFFFFFFF`
+		if !long && node.Type() != "decimal_integer_literal" {
+			return constant.MakeInt64(int64(int32(uint32(value)))), true
+		}
+		return constant.MakeInt64(int64(value)), true
+	default:
+		return nil, false
+	}
+
+	if !wrap {
+		return result, true
+	}
+	// Only the lowest bits of the result are kept, which makes the numbers that
+	// overflow wrap around
+	bits := 32
+	if isLongLiteral(node, source) {
+		bits = 64
+	}
+	mask := constant.Shift(constant.MakeInt64(1), token.SHL, uint(bits))
+	result = constant.BinaryOp(result, token.AND, constant.BinaryOp(mask, token.SUB, constant.MakeInt64(1)))
+	if constant.Compare(result, token.GEQ, constant.Shift(mask, token.SHR, 1)) {
+		result = constant.BinaryOp(result, token.SUB, mask)
+	}
+	return result, true
+}
+
+// foldConstant evaluates a numeric constant expression with Java's arithmetic,
+// since Go evaluates constant expressions exactly, so `0.1 + 0.2 == 0.3` and
+// ints never overflow, while Java rounds floating point numbers, and wraps
+// ints around after every operation
+//
+// Returns nil if the expression is not a numeric constant, or if Go evaluates
+// it the same as Java
+func foldConstant(node *sitter.Node, source []byte) ast.Expr {
+	if value, javaType, ok := floatingConstant(node, source); ok {
+		return floatingValue(value, javaType)
+	}
+
+	value, ok := integerArithmetic(node, source, true)
+	if exact, exactOk := integerArithmetic(node, source, false); !ok || !exactOk || constant.Compare(value, token.EQL, exact) {
+		return nil
+	}
+	folded := &ast.BasicLit{Kind: token.INT, Value: value.ExactString()}
+	if !isUntypedConstant(node, source) {
+		return callFunc("int64", folded)
+	}
+	return folded
+}
+
+// binaryPromotion returns the type that both operands of a numeric operation
+// are converted to, according to Java's rules for binary numeric promotion,
+// or an empty string if either of the types are not numeric
+func binaryPromotion(left, right string) string {
+	left, right = symbol.UnboxedType(left), symbol.UnboxedType(right)
+	switch {
+	case !isNumeric(left) || !isNumeric(right):
+		return ""
+	case left == "double" || right == "double":
+		return "double"
+	case left == "float" || right == "float":
+		return "float"
+	case left == "long" || right == "long":
+		return "long"
+	}
+	// Everything else, including bytes, shorts, and chars are promoted to ints
+	return "int"
+}
+
+// unaryPromotion returns the type that a single numeric operand is converted to
+// in an operation, such as a negation, or the value being shifted
+func unaryPromotion(operand string) string {
+	switch operand = symbol.UnboxedType(operand); operand {
+	case "byte", "short", "char":
+		return "int"
+	case "int", "long", "float", "double":
+		return operand
+	}
+	return ""
+}
+
+// isUntypedConstant tests if an expression is translated into an untyped Go
+// constant, such as `1` or `2.0`, which are converted to the type that they
+// are used with automatically
+func isUntypedConstant(node *sitter.Node, source []byte) bool {
+	switch node.Type() {
+	case "parenthesized_expression":
+		return isUntypedConstant(node.NamedChild(0), source)
+	case "unary_expression":
+		return isUntypedConstant(node.Child(1), source)
+	case "binary_expression":
+		return isUntypedConstant(node.Child(0), source) && isUntypedConstant(node.Child(2), source)
+	case "character_literal":
+		return true
+	case "decimal_integer_literal", "hex_integer_literal", "octal_integer_literal", "binary_integer_literal",
+		"decimal_floating_point_literal", "hex_floating_point_literal":
+		// Literals with a suffix, such as `1L` or `1.0F` are converted to their types
+		// Only floating point literals can have the `F` or `D` suffixes, since
+		// these are valid hex digits
+		suffix := node.Content(source)[len(node.Content(source))-1]
+		if suffix == 'L' || suffix == 'l' {
+			return false
+		}
+		return !strings.HasSuffix(node.Type(), "floating_point_literal") || !strings.ContainsRune("FfDd", rune(suffix))
+	}
+	return false
+}
+
+// constantDefaultType returns the Go type that an untyped constant is given
+// when it is declared without a type
+func constantDefaultType(node *sitter.Node, source []byte) string {
+	switch node.Type() {
+	case "parenthesized_expression":
+		return constantDefaultType(node.NamedChild(0), source)
+	case "unary_expression":
+		return constantDefaultType(node.Child(1), source)
+	case "binary_expression":
+		// Go gives mixed constants the type that appears latest in this list
+		left, right := constantDefaultType(node.Child(0), source), constantDefaultType(node.Child(2), source)
+		for _, constantType := range []string{"float64", "rune"} {
+			if left == constantType || right == constantType {
+				return constantType
+			}
+		}
+		return left
+	case "decimal_floating_point_literal", "hex_floating_point_literal":
+		return "float64"
+	case "character_literal":
+		// Chars that can't be represented as runes are written as integers
+		if CharLiteral(node.Content(source)).Kind == token.INT {
+			return "int"
+		}
+		return "rune"
+	}
+	return "int"
+}
+
+// PromoteOperand converts an operand of a numeric operation into the type that
+// it is promoted to
+func PromoteOperand(value ast.Expr, node *sitter.Node, source []byte, from, to string) ast.Expr {
+	from = symbol.UnboxedType(from)
+	if to == "" || from == "" || primitiveTypes[from] == primitiveTypes[to] || isUntypedConstant(node, source) {
+		return value
+	}
+	return ConvertPrimitive(value, from, to)
+}
+
+// AssignmentConversion converts a value so that it can be assigned to a variable
+// of a given Java type, which is the same as the implicit widening conversions
+// that Java does when assigning a value, passing it as an argument, or
+// returning it from a method
+func AssignmentConversion(value ast.Expr, node *sitter.Node, source []byte, ctx Ctx, to string) ast.Expr {
+	// Lambdas take their types from the functional interface that they implement
+	if function := FunctionalConversion(node, source, ctx, to); function != nil {
+		return function
+	}
+
+	if isArrayType(to) {
+		return ArrayConversion(value, node, source, ctx, to)
+	}
+
+	// Boxed types are pointers, which primitive values are boxed into, and
+	// boxes are unboxed when they are assigned to anything else
+	if isBoxedType(to) {
+		return BoxingConversion(value, node, source, ctx, to)
+	}
+	// Objects keep the types of the values that they hold, so constants are
+	// given their Java types
+	if to == "Object" && ExpressionType(node, source, ctx) != "Object" {
+		return ObjectConversion(value, node, source, ctx)
+	}
+	if holdsBox(node, source, ctx) {
+		return UnboxingConversion(value, to)
+	}
+
+	if !isPrimitive(to) || isUntypedConstant(node, source) {
+		return value
+	}
+	from := symbol.UnboxedType(ExpressionType(node, source, ctx))
+	if !isPrimitive(from) || primitiveTypes[from] == primitiveTypes[to] {
+		return value
+	}
+	return ConvertPrimitive(value, from, to)
+}
+
+// DeclarationConversion converts the initial value of a declared variable to
+// the variable's Java type, since Go infers the types of declarations from
+// their values, and would give constants the wrong type, such as `int` for `0`
+func DeclarationConversion(value ast.Expr, node *sitter.Node, source []byte, ctx Ctx, to string) ast.Expr {
+	if isPrimitive(to) && isUntypedConstant(node, source) {
+		if constantDefaultType(node, source) == primitiveTypes[to] {
+			return value
+		}
+		return callFunc(primitiveTypes[to], value)
+	}
+
+	// Lambdas that implement one of the package's functional interfaces are
+	// given the interface's named function type, instead of a function literal's
+	if function := FunctionalConversion(node, source, ctx, to); function != nil {
+		if goType := ctx.currentFile.GoType(to); !strings.HasPrefix(goType, "func(") {
+			return callFunc(goType, function)
+		}
+		return function
+	}
+	return AssignmentConversion(value, node, source, ctx, to)
+}
+
+// Concatenation concatenates two values, where at least one of them is a
+// string, after converting both of them into strings
+//
+// Chars are concatenated with `stdjava.Concat`, which joins the two halves of
+// a surrogate pair back into a single character
+func Concatenation(x ast.Expr, leftType string, y ast.Expr, rightType string) ast.Expr {
+	x, y = StringConversion(x, leftType), StringConversion(y, rightType)
+	if isCharType(leftType) || isCharType(rightType) {
+		return callStdjava("Concat", x, y)
+	}
+	return &ast.BinaryExpr{X: x, Op: token.ADD, Y: y}
+}
+
+// isCharType tests if a Java type is a char, or a box of a char
+func isCharType(javaType string) bool {
+	return javaType == "char" || javaType == "Character"
+}
+
+// StringConversion converts a value of any Java type into a string, which is
+// done implicitly when a value is concatenated to a string
+func StringConversion(value ast.Expr, from string) ast.Expr {
+	switch from {
+	case "String":
+		return value
+	case "char":
+		// Half of a surrogate pair can't be converted with `string`
+		return &ast.CallExpr{
+			Fun:  &ast.IndexExpr{X: stdjavaType("CharString"), Index: &ast.Ident{Name: primitiveTypes["char"]}},
+			Args: []ast.Expr{value},
+		}
+	case "boolean":
+		return &ast.CallExpr{
+			Fun:  &ast.SelectorExpr{X: &ast.Ident{Name: "strconv"}, Sel: &ast.Ident{Name: "FormatBool"}},
+			Args: []ast.Expr{value},
+		}
+	case "byte", "short", "int", "long":
+		if from != "long" {
+			value = callFunc("int64", value)
+		}
+		return &ast.CallExpr{
+			Fun:  &ast.SelectorExpr{X: &ast.Ident{Name: "strconv"}, Sel: &ast.Ident{Name: "FormatInt"}},
+			Args: []ast.Expr{value, &ast.BasicLit{Kind: token.INT, Value: "10"}},
+		}
+	case "float":
+		return callStdjava("FormatFloat", value)
+	case "double":
+		return callStdjava("FormatDouble", value)
+	case "Character":
+		return &ast.CallExpr{
+			Fun:  &ast.IndexExpr{X: stdjavaType("CharValueOf"), Index: &ast.Ident{Name: primitiveTypes["char"]}},
+			Args: []ast.Expr{value},
+		}
+	}
+	// Objects, as well as values with unknown types are converted at runtime
+	return callStdjava("ValueOf", value)
+}
@@ -28,6 +28,15 @@ func ParseDecls(node *sitter.Node, source []byte, ctx Ctx) []ast.Decl {
 
 		ctx.className = ctx.currentFile.FindClass(node.ChildByFieldName("name").Content(source)).Name
 
+		// Exceptions embed the exception that they extend as their first field
+		if embedded := embeddedException(ctx); embedded != "" {
+			var embeddedType ast.Expr = &ast.Ident{Name: embedded}
+			if ctx.currentFile.SuperclassScope(ctx.currentClass) == nil {
+				embeddedType = stdjavaType(embedded)
+			}
+			fields.List = append(fields.List, &ast.Field{Type: embeddedType})
+		}
+
 		// First, look through the class's body for field declarations
 		for _, child := range nodeutil.NamedChildrenOf(node.ChildByFieldName("body")) {
 			if child.Type() == "field_declaration" {
@@ -101,7 +110,7 @@ func ParseDecls(node *sitter.Node, source []byte, ctx Ctx) []ast.Decl {
 		for _, child := range nodeutil.NamedChildrenOf(node) {
 			switch child.Type() {
 			// Skip fields and comments
-			case "field_declaration", "comment":
+			case "field_declaration", "comment", "line_comment", "block_comment":
 			case "constructor_declaration", "method_declaration", "static_initializer":
 				d := ParseDecl(child, source, ctx)
 				// If the declaration is bad, skip it
@@ -138,6 +147,25 @@ func ParseDecls(node *sitter.Node, source []byte, ctx Ctx) []ast.Decl {
 	case "interface_declaration":
 		ctx.className = ctx.currentFile.FindClass(node.ChildByFieldName("name").Content(source)).Name
 
+		// Functional interfaces are translated into function types
+		if ctx.currentClass.Functional {
+			signature := ctx.currentClass.FunctionalSignature()
+			method := ctx.currentClass.Methods[0]
+
+			funcType := &ast.FuncType{Params: &ast.FieldList{}}
+			for ind, paramType := range signature.ParameterTypes(ctx.currentFile) {
+				funcType.Params.List = append(funcType.Params.List, &ast.Field{
+					Names: []*ast.Ident{&ast.Ident{Name: method.Parameters[ind].Name}},
+					Type:  &ast.Ident{Name: paramType},
+				})
+			}
+			if result := signature.ResultType(ctx.currentFile); result != "" {
+				funcType.Results = &ast.FieldList{List: []*ast.Field{&ast.Field{Type: &ast.Ident{Name: result}}}}
+			}
+
+			return []ast.Decl{GenFuncType(ctx.className, ctx.currentClass.TypeParameters, funcType)}
+		}
+
 		return ParseDecls(node.ChildByFieldName("body"), source, ctx)
 	case "enum_declaration":
 		// An enum is treated as both a struct, and a list of values that define
@@ -202,6 +230,8 @@ func ParseDecl(node *sitter.Node, source []byte, ctx Ctx) ast.Decl {
 
 		// Search through the current class for the constructor, which is simply labeled as a method
 		ctx.localScope = ctx.currentClass.FindMethod().By(comparison)[0]
+		ctx.temporaries = new(int)
+		ctx.exit = nil
 
 		body := ParseStmt(node.ChildByFieldName("body"), source, ctx).(*ast.BlockStmt)
 
@@ -265,7 +295,7 @@ func ParseDecl(node *sitter.Node, source []byte, ctx Ctx) ast.Decl {
 			}
 		}
 
-		methodName := ParseExpr(node.ChildByFieldName("name"), source, ctx).(*ast.Ident)
+		methodName := &ast.Ident{Name: node.ChildByFieldName("name").Content(source)}
 
 		methodParameters := node.ChildByFieldName("parameters")
 
@@ -313,6 +343,9 @@ func ParseDecl(node *sitter.Node, source []byte, ctx Ctx) ast.Decl {
 		}
 
 		ctx.localScope = methodDefinition[0]
+		ctx.returnType = ctx.localScope.OriginalType
+		ctx.temporaries = new(int)
+		ctx.exit = nil
 
 		body := ParseStmt(node.ChildByFieldName("body"), source, ctx).(*ast.BlockStmt)
 
@@ -353,6 +386,8 @@ func ParseDecl(node *sitter.Node, source []byte, ctx Ctx) ast.Decl {
 	case "static_initializer":
 
 		ctx.localScope = &symbol.Definition{}
+		ctx.temporaries = new(int)
+		ctx.exit = nil
 
 		// A block of `static`, which is run before the main function
 		return &ast.FuncDecl{
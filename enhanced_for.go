@@ -0,0 +1,117 @@
+package main
+
+import (
+	"go/ast"
+	"go/token"
+	"strings"
+
+	"github.com/NickyBoy89/java2go/symbol"
+	sitter "github.com/smacker/go-tree-sitter"
+)
+
+// EnhancedFor translates an enhanced for statement, such as
+// `for (String word : words)`, which iterates over either an array, or
+// anything that is `Iterable`
+//
+// Arrays are ranged over, and anything else is iterated over with its
+// iterator, the same way that Java does
+func EnhancedFor(node *sitter.Node, source []byte, ctx Ctx) ast.Stmt {
+	nameNode := node.ChildByFieldName("name")
+	iterable := node.ChildByFieldName("value")
+	variable := ParseExpr(nameNode, source, ctx)
+
+	iterableType := ExpressionType(iterable, source, ctx)
+	variableType := node.ChildByFieldName("type").Content(source)
+	element, iterator := iteratedElement(iterableType, ctx)
+	if variableType == "var" {
+		variableType = element
+	}
+
+	// Lists and maps that are Go slices and maps are ranged over, where the keys
+	// of a map are the keys of the range
+	values, keys := nativeRange(iterable, source, ctx)
+	if values != nil {
+		iterator = ""
+	} else {
+		values = ParseExpr(iterable, source, ctx)
+	}
+	if ctx.temporaries == nil {
+		ctx.temporaries = new(int)
+	}
+
+	// The variable is declared with its own type, which the elements may have
+	// to be converted to, such as when an `int[]` is iterated over as `long`s
+	declare := func(value ast.Expr) ast.Stmt {
+		return &ast.AssignStmt{
+			Lhs: []ast.Expr{variable},
+			Tok: token.DEFINE,
+			Rhs: []ast.Expr{convertValue(value, element, variableType)},
+		}
+	}
+
+	if iterator != "" {
+		it := ctx.temporaryName()
+		body := parseBody(node.ChildByFieldName("body"), source, ctx)
+		body.List = append([]ast.Stmt{declare(callMethod(it, "Next"))}, body.List...)
+		return &ast.ForStmt{
+			Init: &ast.AssignStmt{Lhs: []ast.Expr{it}, Tok: token.DEFINE, Rhs: []ast.Expr{callMethod(values, iterator)}},
+			Cond: callMethod(it, "HasNext"),
+			Body: body,
+		}
+	}
+
+	var value ast.Expr = variable
+	var first ast.Stmt
+	if convertValue(variable, element, variableType) != variable {
+		// Each element is converted into a variable of its own
+		value = ctx.temporaryName()
+		first = declare(value)
+	} else if def := ctx.localScope.FindVariableAt(nameNode.Content(source), nameNode.StartByte()); def != nil && def.Captured {
+		// Java creates a new variable for each iteration, but Go reuses the same
+		// one, so lambdas that capture the variable need their own copy of it
+		first = &ast.AssignStmt{Lhs: []ast.Expr{variable}, Tok: token.DEFINE, Rhs: []ast.Expr{variable}}
+	}
+
+	body := parseBody(node.ChildByFieldName("body"), source, ctx)
+	if first != nil {
+		body.List = append([]ast.Stmt{first}, body.List...)
+	}
+	if keys {
+		return &ast.RangeStmt{Key: value, Tok: token.DEFINE, X: values, Body: body}
+	}
+	return &ast.RangeStmt{Key: &ast.Ident{Name: "_"}, Value: value, Tok: token.DEFINE, X: values, Body: body}
+}
+
+// iteratedElement returns the Java type of the elements of something that is
+// iterated over with an enhanced for statement, and the name of the Go method
+// that returns its iterator, which is empty for arrays
+//
+// Both are empty if the type of the elements isn't known
+func iteratedElement(iterableType string, ctx Ctx) (string, string) {
+	if strings.HasSuffix(iterableType, "[]") {
+		return strings.TrimSuffix(iterableType, "[]"), ""
+	}
+
+	// Java's collections are part of stdjava
+	if collection := ctx.currentFile.JavaCollection(iterableType); collection != "" {
+		for _, kind := range collectionHierarchy[collection] {
+			if kind == "Iterable" {
+				_, typeArguments := symbol.SplitTypeArguments(iterableType)
+				return instantiateCollection(collection, typeArguments, "E"), "Iterator"
+			}
+		}
+		return "", ""
+	}
+
+	// Any other class is iterable if it has an `iterator` method
+	if class := findClassScope(iterableType, ctx); class != nil {
+		if method := class.FindMethodByArguments("iterator", []string{}); method != nil {
+			_, typeArguments := symbol.SplitTypeArguments(method.OriginalType)
+			if len(typeArguments) == 1 {
+				return typeArguments[0], method.Name
+			}
+			return "Object", method.Name
+		}
+	}
+	return "", ""
+}
@@ -0,0 +1,537 @@
+package main
+
+import (
+	"go/ast"
+	"go/token"
+	"strconv"
+	"strings"
+
+	"github.com/NickyBoy89/java2go/astutil"
+	"github.com/NickyBoy89/java2go/nodeutil"
+	"github.com/NickyBoy89/java2go/symbol"
+	sitter "github.com/smacker/go-tree-sitter"
+)
+
+// exceptionMethods maps the methods of Java's exceptions to the methods of the
+// exceptions in `stdjava`
+var exceptionMethods = map[string]string{
+	"getMessage":          "GetMessage",
+	"getLocalizedMessage": "GetMessage",
+	"getCause":            "GetCause",
+	"toString":            "Error",
+}
+
+// exceptionMethodTypes are the Java types that the methods of exceptions return
+var exceptionMethodTypes = map[string]string{
+	"getMessage":          "String",
+	"getLocalizedMessage": "String",
+	"getCause":            "Throwable",
+	"toString":            "String",
+}
+
+// isExceptionMethod tests if a method call calls a method of one of the JDK's
+// exceptions, such as `e.getMessage()`
+func isExceptionMethod(node *sitter.Node, source []byte, ctx Ctx) bool {
+	object := node.ChildByFieldName("object")
+	if object == nil || node.ChildByFieldName("arguments").NamedChildCount() > 0 {
+		return false
+	}
+	name := node.ChildByFieldName("name").Content(source)
+	if _, ok := exceptionMethods[name]; !ok {
+		return false
+	}
+	// Exceptions that are caught as several types are caught as `stdjava.Thrown`
+	objectType := ExpressionType(object, source, ctx)
+	if isJavaException(objectType) || strings.Contains(objectType, "|") && isException(objectType, ctx) {
+		return true
+	}
+	// The program's own exceptions inherit the methods that they don't declare
+	class := ctx.currentFile.FindClassScope(objectType)
+	return class != nil && exceptionSuperclass(objectType, ctx) != "" && class.FindMethodByName(name, nil) == nil
+}
+
+// exceptionSuperclass returns the JDK's exception that a class in the package
+// extends, either directly or through its other superclasses, or an empty
+// string if the class is not an exception
+func exceptionSuperclass(className string, ctx Ctx) string {
+	superclasses := ctx.currentFile.Superclasses(className)
+	if len(superclasses) == 0 || !isJavaException(superclasses[len(superclasses)-1]) {
+		return ""
+	}
+	return superclasses[len(superclasses)-1]
+}
+
+// embeddedException returns the name of the type that the struct of an
+// exception that the program declares embeds as its first field, which is the
+// exception that it extends, or an empty string if the class isn't an exception
+//
+// Embedding its superclass makes the exception an instance of it, and inherits
+// its methods, such as `getMessage`
+func embeddedException(ctx Ctx) string {
+	if exceptionSuperclass(ctx.currentClass.Class.OriginalName, ctx) == "" {
+		return ""
+	}
+	if superclass := ctx.currentFile.SuperclassScope(ctx.currentClass); superclass != nil {
+		return superclass.Class.Name
+	}
+	name, _ := symbol.SplitTypeArguments(ctx.currentClass.Superclass)
+	return astutil.JavaExceptions[name]
+}
+
+// SuperExceptionStmt translates a constructor of one of the program's own
+// exceptions calling the constructor of its superclass, such as `super(message)`,
+// which initializes the exception that it embeds
+//
+// Returns nil if the class is not an exception
+func SuperExceptionStmt(node *sitter.Node, source []byte, ctx Ctx) ast.Stmt {
+	field := embeddedException(ctx)
+	if field == "" {
+		return nil
+	}
+	argumentNodes := node.ChildByFieldName("arguments")
+
+	superclass := ctx.currentFile.SuperclassScope(ctx.currentClass)
+	if superclass == nil {
+		name, _ := symbol.SplitTypeArguments(ctx.currentClass.Superclass)
+		arguments := parseArguments(argumentNodes, source, ctx.evaluation())
+		return embedException(field, NewException(name, arguments, nodeutil.NamedChildrenOf(argumentNodes), source, ctx), ctx)
+	}
+
+	// The program's own exceptions are created by their constructors, which
+	// return pointers to them
+	constructor := superclass.FindMethodByArguments(superclass.Class.OriginalName, argumentTypes(argumentNodes, source, ctx))
+	if constructor == nil {
+		return nil
+	}
+	arguments := parseArguments(argumentNodes, source, ctx.evaluation())
+	return embedException(field, &ast.StarExpr{X: &ast.CallExpr{Fun: &ast.Ident{Name: constructor.Name}, Args: arguments}}, ctx)
+}
+
+// embedException initializes the exception that the class of the current
+// constructor embeds
+func embedException(field string, value ast.Expr, ctx Ctx) ast.Stmt {
+	return &ast.AssignStmt{
+		Lhs: []ast.Expr{&ast.SelectorExpr{X: &ast.Ident{Name: ShortName(ctx.className)}, Sel: &ast.Ident{Name: field}}},
+		Tok: token.ASSIGN,
+		Rhs: []ast.Expr{value},
+	}
+}
+
+// isException tests if a Java type is one of the JDK's exceptions, one of the
+// program's own exceptions, or a union of them
+func isException(javaType string, ctx Ctx) bool {
+	for _, alternative := range strings.Split(javaType, "|") {
+		alternative = strings.TrimSpace(alternative)
+		if !isJavaException(alternative) && exceptionSuperclass(alternative, ctx) == "" {
+			return false
+		}
+	}
+	return true
+}
+
+// isJavaException tests if a Java type is one of the JDK's exceptions, or a
+// union of them, such as the type of `e` in `catch (A | B e)`
+func isJavaException(javaType string) bool {
+	for _, alternative := range strings.Split(javaType, "|") {
+		if _, ok := astutil.JavaExceptions[strings.TrimSpace(alternative)]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// stdjavaType references a type in the `stdjava` package
+func stdjavaType(name string) ast.Expr {
+	return &ast.SelectorExpr{X: &ast.Ident{Name: "stdjava"}, Sel: &ast.Ident{Name: name}}
+}
+
+// NewException creates one of the JDK's exceptions, which take a message, a
+// cause, or both
+func NewException(className string, arguments []ast.Expr, argumentNodes []*sitter.Node, source []byte, ctx Ctx) ast.Expr {
+	var message, cause ast.Expr = &ast.BasicLit{Kind: token.STRING, Value: `""`}, &ast.Ident{Name: "nil"}
+	switch len(arguments) {
+	case 1:
+		argumentType := ExpressionType(argumentNodes[0], source, ctx)
+		if isException(argumentType, ctx) {
+			cause = arguments[0]
+		} else {
+			message = StringConversion(arguments[0], argumentType)
+		}
+	case 2:
+		message, cause = arguments[0], arguments[1]
+	}
+	return &ast.CallExpr{
+		Fun:  &ast.IndexExpr{X: stdjavaType("New"), Index: stdjavaType(astutil.JavaExceptions[className])},
+		Args: []ast.Expr{message, cause},
+	}
+}
+
+// A tryExit is how a `return`, `break`, or `continue` leaves the closure that
+// a `try` statement is translated into, which sets the closure's results, and
+// then returns from the closure, so that the method can return, or the loop
+// can be broken out of, after the closure is called
+type tryExit struct {
+	// The `try` statement that the closure is translated from
+	statement *sitter.Node
+	// Whether the closure returned from the method, which is nil if the `try`
+	// statement doesn't contain a `return`
+	done *ast.Ident
+	// The value that was returned, which is nil for methods without results
+	result *ast.Ident
+	// The `break` and `continue` statements that jump out of the `try`
+	// statement, where `jump` is set to the position of the one that ran,
+	// starting at one, and is nil if there aren't any
+	jumps []*sitter.Node
+	jump  *ast.Ident
+}
+
+// returnStmts translates returning a value from the current method, which has
+// already been converted to the method's result type, and is nil if the method
+// does not return anything
+func (ctx Ctx) returnStmts(value ast.Expr) []ast.Stmt {
+	var results []ast.Expr
+	if value != nil {
+		results = []ast.Expr{value}
+	}
+	if ctx.exit == nil {
+		return []ast.Stmt{&ast.ReturnStmt{Results: results}}
+	}
+
+	assign := &ast.AssignStmt{
+		Lhs: []ast.Expr{ctx.exit.done},
+		Tok: token.ASSIGN,
+		Rhs: []ast.Expr{&ast.Ident{Name: "true"}},
+	}
+	if value != nil {
+		assign.Lhs = append(assign.Lhs, ctx.exit.result)
+		assign.Rhs = append(assign.Rhs, value)
+	}
+	return []ast.Stmt{assign, &ast.ReturnStmt{}}
+}
+
+// branchStmts translates a `break` or `continue` statement, which returns from
+// the closure of a `try` statement if it jumps out of the `try` statement
+func (ctx Ctx) branchStmts(node *sitter.Node, source []byte) []ast.Stmt {
+	branch := &ast.BranchStmt{Tok: token.BREAK}
+	if node.Type() == "continue_statement" {
+		branch.Tok = token.CONTINUE
+	}
+	if node.NamedChildCount() > 0 {
+		branch.Label = &ast.Ident{Name: node.NamedChild(0).Content(source)}
+	}
+	if ctx.exit == nil {
+		return []ast.Stmt{branch}
+	}
+
+	for ind, jump := range ctx.exit.jumps {
+		if jump.Equal(node) {
+			return []ast.Stmt{
+				&ast.AssignStmt{
+					Lhs: []ast.Expr{ctx.exit.jump},
+					Tok: token.ASSIGN,
+					Rhs: []ast.Expr{&ast.BasicLit{Kind: token.INT, Value: strconv.Itoa(ind + 1)}},
+				},
+				&ast.ReturnStmt{},
+			}
+		}
+	}
+	return []ast.Stmt{branch}
+}
+
+// TryStatement translates a `try` statement into a closure that is called
+// immediately, since Go can only recover from a panic in a deferred function
+//
+// The `catch` clauses run in a deferred function, which classifies any runtime
+// errors as Java's exceptions, and panics again with anything that was not
+// caught. The `finally` clause is deferred before it, so that it runs last
+func TryStatement(node *sitter.Node, source []byte, ctx Ctx) []ast.Stmt {
+	if ctx.temporaries == nil {
+		ctx.temporaries = new(int)
+	}
+
+	// A closure that returns from the method, or jumps out of a loop, needs
+	// results to say so
+	outer := ctx
+	results := &ast.FieldList{}
+	returns, jumps := containsReturn(node), escapingJumps(node, node, source)
+	if returns || len(jumps) > 0 {
+		ctx.exit = &tryExit{statement: node, jumps: jumps}
+	}
+	if returns {
+		ctx.exit.done = ctx.temporaryName()
+		results.List = []*ast.Field{&ast.Field{Names: []*ast.Ident{ctx.exit.done}, Type: &ast.Ident{Name: "bool"}}}
+		if resultType := ctx.currentFile.GoType(ctx.returnType); ctx.returnType != "void" && resultType != "" {
+			ctx.exit.result = ctx.temporaryName()
+			results.List = append(results.List, &ast.Field{Names: []*ast.Ident{ctx.exit.result}, Type: &ast.Ident{Name: resultType}})
+		}
+	}
+	if len(jumps) > 0 {
+		ctx.exit.jump = ctx.temporaryName()
+		results.List = append(results.List, &ast.Field{Names: []*ast.Ident{ctx.exit.jump}, Type: &ast.Ident{Name: "int"}})
+	}
+
+	var body []ast.Stmt
+	var catches []*sitter.Node
+	for _, child := range nodeutil.NamedChildrenOf(node) {
+		switch child.Type() {
+		case "catch_clause":
+			catches = append(catches, child)
+		case "finally_clause":
+			body = append(body, deferred(ParseStmt(child.NamedChild(0), source, ctx).(*ast.BlockStmt).List))
+		}
+	}
+	if len(catches) > 0 {
+		body = append(body, catchClauses(catches, source, ctx))
+	}
+	body = append(body, ParseStmt(node.ChildByFieldName("body"), source, ctx).(*ast.BlockStmt).List...)
+	if _, returned := body[len(body)-1].(*ast.ReturnStmt); ctx.exit != nil && !returned {
+		body = append(body, &ast.ReturnStmt{})
+	}
+
+	closure := &ast.CallExpr{Fun: &ast.FuncLit{
+		Type: &ast.FuncType{Params: &ast.FieldList{}, Results: results},
+		Body: &ast.BlockStmt{List: body},
+	}}
+	if ctx.exit == nil {
+		return []ast.Stmt{&ast.ExprStmt{X: closure}}
+	}
+
+	// Return from the method if the closure did
+	call := &ast.AssignStmt{Tok: token.DEFINE, Rhs: []ast.Expr{closure}}
+	var result ast.Expr
+	for _, name := range []*ast.Ident{ctx.exit.done, ctx.exit.result, ctx.exit.jump} {
+		if name != nil {
+			call.Lhs = append(call.Lhs, name)
+		}
+	}
+	if ctx.exit.result != nil {
+		result = ctx.exit.result
+	}
+
+	// Go requires methods with results to end with a return, so a `try`
+	// statement that always returns returns its result directly, after any of
+	// the jumps
+	stmts := []ast.Stmt{call}
+	var returned []ast.Stmt
+	switch {
+	case !returns:
+	case result != nil && !completesNormally(node):
+		call.Lhs[0] = &ast.Ident{Name: "_"}
+		returned = outer.returnStmts(result)
+	case len(jumps) == 0:
+		return []ast.Stmt{&ast.IfStmt{
+			Init: call,
+			Cond: ctx.exit.done,
+			Body: &ast.BlockStmt{List: outer.returnStmts(result)},
+		}}
+	default:
+		stmts = append(stmts, &ast.IfStmt{Cond: ctx.exit.done, Body: &ast.BlockStmt{List: outer.returnStmts(result)}})
+	}
+
+	// Then jump out of the loop if the closure did, which may also jump out of
+	// the closure of another `try` statement
+	for ind, jump := range jumps {
+		stmts = append(stmts, &ast.IfStmt{
+			Cond: &ast.BinaryExpr{X: ctx.exit.jump, Op: token.EQL, Y: &ast.BasicLit{Kind: token.INT, Value: strconv.Itoa(ind + 1)}},
+			Body: &ast.BlockStmt{List: outer.branchStmts(jump, source)},
+		})
+	}
+	return append(stmts, returned...)
+}
+
+// escapingJumps finds the `break` and `continue` statements inside of a node
+// that jump out of a `try` statement, to a loop, a `switch`, or a label outside
+// of it, which excludes the bodies of any lambdas or classes
+func escapingJumps(node, statement *sitter.Node, source []byte) []*sitter.Node {
+	switch node.Type() {
+	case "break_statement", "continue_statement":
+		if jumpsOutOf(node, statement, source) {
+			return []*sitter.Node{node}
+		}
+		return nil
+	case "lambda_expression", "class_body":
+		return nil
+	}
+	var jumps []*sitter.Node
+	for _, child := range nodeutil.NamedChildrenOf(node) {
+		jumps = append(jumps, escapingJumps(child, statement, source)...)
+	}
+	return jumps
+}
+
+// jumpsOutOf tests if a `break` or `continue` statement jumps to a statement
+// outside of another statement
+func jumpsOutOf(jump, statement *sitter.Node, source []byte) bool {
+	var label string
+	if jump.NamedChildCount() > 0 {
+		label = jump.NamedChild(0).Content(source)
+	}
+	for parent := jump.Parent(); parent != nil && !parent.Equal(statement); parent = parent.Parent() {
+		switch parent.Type() {
+		case "labeled_statement":
+			if parent.NamedChild(0).Content(source) == label {
+				return false
+			}
+		case "for_statement", "enhanced_for_statement", "while_statement", "do_statement":
+			if label == "" {
+				return false
+			}
+		case "switch_statement", "switch_expression":
+			if label == "" && jump.Type() == "break_statement" {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// completesNormally tests if the statements after a `try` statement can run,
+// which is not the case when its body and all of its `catch` clauses end by
+// returning or throwing, or its `finally` clause does
+func completesNormally(node *sitter.Node) bool {
+	ends := func(block *sitter.Node) bool {
+		if block.NamedChildCount() == 0 {
+			return false
+		}
+		switch block.NamedChild(int(block.NamedChildCount()) - 1).Type() {
+		case "return_statement", "throw_statement":
+			return true
+		}
+		return false
+	}
+
+	completes := !ends(node.ChildByFieldName("body"))
+	for _, child := range nodeutil.NamedChildrenOf(node) {
+		switch child.Type() {
+		case "catch_clause":
+			completes = completes || !ends(child.ChildByFieldName("body"))
+		case "finally_clause":
+			if ends(child.NamedChild(0)) {
+				return false
+			}
+		}
+	}
+	return completes
+}
+
+// catchClauses recovers from a panic, and runs the first `catch` clause that
+// catches the exception
+func catchClauses(catches []*sitter.Node, source []byte, ctx Ctx) ast.Stmt {
+	thrown := ctx.temporaryName()
+
+	// Anything that is not caught is thrown again
+	var chain ast.Stmt = &ast.BlockStmt{List: []ast.Stmt{
+		&ast.ExprStmt{X: callFunc("panic", thrown)},
+	}}
+	for ind := len(catches) - 1; ind >= 0; ind-- {
+		param := catches[ind].NamedChild(0)
+		var catchTypes []*sitter.Node
+		for _, child := range nodeutil.NamedChildrenOf(param) {
+			if child.Type() == "catch_type" {
+				catchTypes = nodeutil.NamedChildrenOf(child)
+			}
+		}
+
+		var cond ast.Expr
+		for _, catchType := range catchTypes {
+			test := catchTest(catchType.Content(source), thrown, ctx)
+			if cond == nil {
+				cond = test
+			} else {
+				cond = &ast.BinaryExpr{X: cond, Op: token.LOR, Y: test}
+			}
+		}
+
+		body := ParseStmt(catches[ind].ChildByFieldName("body"), source, ctx).(*ast.BlockStmt).List
+		// The exception is only declared if it is used, since Go does not allow
+		// unused variables
+		name := param.ChildByFieldName("name")
+		if usesIdentifier(catches[ind].ChildByFieldName("body"), name.Content(source), source) {
+			// The JDK's exceptions may be any of their subclasses, and other
+			// exceptions are their own types, unless there are several of them
+			var value ast.Expr = thrown
+			if caughtType := catchTypes[0].Content(source); isJavaException(catchTypes[0].Parent().Content(source)) {
+				value = &ast.TypeAssertExpr{X: thrown, Type: stdjavaType("Thrown")}
+			} else if len(catchTypes) == 1 && exceptionSuperclass(caughtType, ctx) != "" {
+				// The program's own exceptions may also be any of their subclasses,
+				// which embed them
+				value = &ast.CallExpr{
+					Fun:  &ast.IndexExpr{X: stdjavaType("As"), Index: &ast.Ident{Name: ctx.currentFile.GoType(caughtType)}},
+					Args: []ast.Expr{thrown},
+				}
+			} else if len(catchTypes) == 1 {
+				value = &ast.TypeAssertExpr{X: thrown, Type: &ast.Ident{Name: ctx.currentFile.GoType(caughtType)}}
+			} else if isException(catchTypes[0].Parent().Content(source), ctx) {
+				value = &ast.TypeAssertExpr{X: thrown, Type: stdjavaType("Thrown")}
+			}
+			body = append([]ast.Stmt{&ast.AssignStmt{
+				Lhs: []ast.Expr{ParseExpr(name, source, ctx)},
+				Tok: token.DEFINE,
+				Rhs: []ast.Expr{value},
+			}}, body...)
+		}
+
+		chain = &ast.IfStmt{Cond: cond, Body: &ast.BlockStmt{List: body}, Else: chain}
+	}
+
+	return deferred([]ast.Stmt{&ast.IfStmt{
+		Init: &ast.AssignStmt{Lhs: []ast.Expr{thrown}, Tok: token.DEFINE, Rhs: []ast.Expr{callFunc("recover")}},
+		Cond: &ast.BinaryExpr{X: thrown, Op: token.NEQ, Y: &ast.Ident{Name: "nil"}},
+		Body: &ast.BlockStmt{List: []ast.Stmt{
+			&ast.AssignStmt{Lhs: []ast.Expr{thrown}, Tok: token.ASSIGN, Rhs: []ast.Expr{callStdjava("Classify", thrown)}},
+			chain,
+		}},
+	}})
+}
+
+// catchTest tests if a value that was thrown is an instance of the class that
+// a `catch` clause catches
+func catchTest(className string, thrown ast.Expr, ctx Ctx) ast.Expr {
+	var caughtType ast.Expr = &ast.Ident{Name: ctx.currentFile.GoType(className)}
+	if isJavaException(className) {
+		caughtType = stdjavaType(astutil.JavaExceptions[className])
+	}
+	return &ast.CallExpr{
+		Fun:  &ast.IndexExpr{X: stdjavaType("InstanceOf"), Index: caughtType},
+		Args: []ast.Expr{thrown},
+	}
+}
+
+// deferred defers a function with the given body
+func deferred(body []ast.Stmt) ast.Stmt {
+	return &ast.DeferStmt{Call: &ast.CallExpr{Fun: &ast.FuncLit{
+		Type: &ast.FuncType{Params: &ast.FieldList{}},
+		Body: &ast.BlockStmt{List: body},
+	}}}
+}
+
+// containsReturn tests if a statement returns from the current method, which
+// excludes the bodies of any lambdas or classes that are declared inside of it
+func containsReturn(node *sitter.Node) bool {
+	switch node.Type() {
+	case "return_statement":
+		return true
+	case "lambda_expression", "class_body":
+		return false
+	}
+	for _, child := range nodeutil.NamedChildrenOf(node) {
+		if containsReturn(child) {
+			return true
+		}
+	}
+	return false
+}
+
+// usesIdentifier tests if an identifier with the given name appears anywhere
+// inside of a node
+func usesIdentifier(node *sitter.Node, name string, source []byte) bool {
+	if node.Type() == "identifier" {
+		return node.Content(source) == name
+	}
+	for _, child := range nodeutil.NamedChildrenOf(node) {
+		if usesIdentifier(child, name, source) {
+			return true
+		}
+	}
+	return false
+}
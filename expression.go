@@ -1,9 +1,8 @@
 package main
 
 import (
-	"fmt"
 	"go/ast"
-	"go/token"
+	"math"
 
 	"github.com/NickyBoy89/java2go/astutil"
 	"github.com/NickyBoy89/java2go/nodeutil"
@@ -14,6 +13,16 @@ import (
 
 // ParseExpr parses an expression type
 func ParseExpr(node *sitter.Node, source []byte, ctx Ctx) ast.Expr {
+	value := parseExpression(node, source, ctx)
+	// Boxes are unboxed where their values are used, such as in arithmetic, and
+	// parentheses are left to the expressions inside of them
+	if node.Type() != "parenthesized_expression" && unboxedByParent(node, source, ctx) && holdsBox(node, source, ctx) {
+		return callStdjava("Unbox", value)
+	}
+	return value
+}
+
+func parseExpression(node *sitter.Node, source []byte, ctx Ctx) ast.Expr {
 	switch node.Type() {
 	case "ERROR":
 		log.WithFields(log.Fields{
@@ -21,142 +30,174 @@ func ParseExpr(node *sitter.Node, source []byte, ctx Ctx) ast.Expr {
 			"className": ctx.className,
 		}).Warn("Expression parse error")
 		return &ast.BadExpr{}
-	case "comment":
+	case "comment", "line_comment", "block_comment":
 		return &ast.BadExpr{}
 	case "update_expression":
-		// This can either be a pre or post expression
-		// a pre expression has the identifier second, while the post expression
-		// has the identifier first
-
-		// Post-update expression, e.g. `i++`
-		if node.Child(0).IsNamed() {
-			return &ast.CallExpr{
-				Fun:  &ast.Ident{Name: "PostUpdate"},
-				Args: []ast.Expr{ParseExpr(node.Child(0), source, ctx)},
-			}
-		}
-
-		// Otherwise, pre-update expression
-		return &ast.CallExpr{
-			Fun:  &ast.Ident{Name: "PreUpdate"},
-			Args: []ast.Expr{ParseExpr(node.Child(1), source, ctx)},
-		}
+		// Increments and decrements are statements in Go, so they are run before
+		// the expression, such as `i++` in `array[i++]`
+		return updateExpression(node, source, ctx)
 	case "class_literal":
 		// Class literals refer to the class directly, such as
 		// Object.class
 		return &ast.BadExpr{}
 	case "assignment_expression":
-		return &ast.CallExpr{
-			Fun: &ast.Ident{Name: "AssignmentExpression"},
-			Args: []ast.Expr{
-				ParseExpr(node.Child(0), source, ctx),
-				&ast.BasicLit{Kind: token.STRING, Value: fmt.Sprintf("\"%s\"", node.Child(1).Content(source))},
-				ParseExpr(node.Child(2), source, ctx),
-			},
-		}
+		// Assignments are statements in Go, so they are run before the expression
+		return assignmentExpression(node, source, ctx)
 	case "super":
 		return &ast.BadExpr{}
 	case "lambda_expression":
-		// Lambdas can either be called with a list of expressions
-		// (ex: (n1, n1) -> {}), or with a single expression
-		// (ex: n1 -> {})
-
-		var lambdaBody *ast.BlockStmt
+		// The types of the lambda are only known from the context it is used in
+		return ParseLambda(node, source, ctx, nil)
+	case "method_reference":
+		// This refers to a method that is passed as a function, such as
+		// `ClassName::methodName`, or a constructor, such as `ClassName::new`
+		return ParseMethodReference(node, source, ctx, nil)
+	case "array_initializer":
+		// A literal that initializes an array, such as `{1, 2, 3}`, which takes
+		// its type from the variable that it is declared as
+		return ArrayInitializer(node, source, ctx, ctx.lastType)
+	case "method_invocation":
+		// Lists and maps may be Go's slices and maps
+		if call := NativeCollectionMethod(node, source, ctx); call != nil {
+			return call
+		}
+		// Simple stream pipelines may be loops, and other streams are part of
+		// stdjava's `stream` package
+		if result := LowerStream(node, source, ctx); result != nil {
+			return result
+		}
+		if call := StreamMethod(node, source, ctx); call != nil {
+			return call
+		}
+		// Optionals are part of stdjava
+		if call := OptionalMethod(node, source, ctx); call != nil {
+			return call
+		}
+		// So are the methods of the boxed types, such as `Integer.parseInt`
+		if call := WrapperMethod(node, source, ctx); call != nil {
+			return call
+		}
+		if call := MathMethod(node, source, ctx); call != nil {
+			return call
+		}
+		// Printing to the standard streams is done with `fmt`
+		if call := PrintMethod(node, source, ctx); call != nil {
+			return call
+		}
 
-		var lambdaParameters *ast.FieldList
+		methodName := &ast.Ident{Name: node.ChildByFieldName("name").Content(source)}
 
-		bodyNode := node.ChildByFieldName("body")
+		// The object is evaluated before the arguments
+		ev := ctx.evaluation()
+		object := new(ast.Expr)
+		if node.ChildByFieldName("object") != nil {
+			object = ev.parse(node.ChildByFieldName("object"), source, identity)
+		}
+		arguments := parseArguments(node.ChildByFieldName("arguments"), source, ev)
 
-		switch bodyNode.Type() {
-		case "block":
-			lambdaBody = ParseStmt(bodyNode, source, ctx).(*ast.BlockStmt)
-		default:
-			// Lambdas can be called inline without a block expression
-			lambdaBody = &ast.BlockStmt{
-				List: []ast.Stmt{
-					&ast.ExprStmt{
-						X: ParseExpr(bodyNode, source, ctx),
-					},
-				},
+		// Functional interfaces are functions, so they are called directly
+		if signature := functionalCall(node, source, ctx); signature != nil {
+			argumentNodes := nodeutil.NamedChildrenOf(node.ChildByFieldName("arguments"))
+			for ind := range arguments {
+				if ind < len(signature.Parameters) {
+					arguments[ind] = AssignmentConversion(arguments[ind], argumentNodes[ind], source, ctx, signature.Parameters[ind])
+				}
 			}
+			return &ast.CallExpr{Fun: *object, Args: arguments}
 		}
 
-		paramNode := node.ChildByFieldName("parameters")
-
-		switch paramNode.Type() {
-		case "inferred_parameters", "formal_parameters":
-			lambdaParameters = ParseNode(paramNode, source, ctx).(*ast.FieldList)
-		default:
-			// If we can't identify the types of the parameters, then just set their
-			// types to any
-			lambdaParameters = &ast.FieldList{
-				List: []*ast.Field{
-					&ast.Field{
-						Names: []*ast.Ident{ParseExpr(paramNode, source, ctx).(*ast.Ident)},
-						Type:  &ast.Ident{Name: "any"},
-					},
-				},
+		if isStringMethod(node, source, ctx) {
+			if call := StringMethod(*object, methodName.Name, arguments, stringArguments(node, source, ctx, arguments, false)); call != nil {
+				return call
 			}
 		}
-
-		return &ast.FuncLit{
-			Type: &ast.FuncType{
-				Params: lambdaParameters,
-			},
-			Body: lambdaBody,
+		// Go's strings don't have any methods to fall back on
+		if object := node.ChildByFieldName("object"); object != nil && ExpressionType(object, source, ctx) == "String" {
+			log.WithFields(log.Fields{
+				"method":    node.Content(source),
+				"className": ctx.className,
+			}).Warn("String method is not translated")
+			return &ast.BadExpr{}
+		}
+		if isStringStaticMethod(node, source, ctx) {
+			if call := StringStaticMethod(methodName.Name, arguments, stringArguments(node, source, ctx, arguments, true), ctx); call != nil {
+				return call
+			}
 		}
-	case "method_reference":
-		// This refers to manually selecting a function from a specific class and
-		// passing it in as an argument in the `func(className::methodName)` style
-
-		// For class constructors such as `Class::new`, you only get one node
-		if node.NamedChildCount() < 2 {
-			return &ast.SelectorExpr{
-				X:   ParseExpr(node.NamedChild(0), source, ctx),
-				Sel: &ast.Ident{Name: "new"},
+
+		if isStringBuilderMethod(node, source, ctx) {
+			formatted := methodName.Name == "append" || methodName.Name == "insert"
+			if call := StringBuilderMethod(*object, methodName.Name, arguments, stringArguments(node, source, ctx, arguments, formatted)); call != nil {
+				return call
 			}
 		}
 
-		return &ast.SelectorExpr{
-			X:   ParseExpr(node.NamedChild(0), source, ctx),
-			Sel: ParseExpr(node.NamedChild(1), source, ctx).(*ast.Ident),
+		// Java's collections are part of stdjava
+		if call := CollectionMethod(node, source, ctx, *object, arguments); call != nil {
+			return call
 		}
-	case "array_initializer":
-		// A literal that initilzes an array, such as `{1, 2, 3}`
-		items := []ast.Expr{}
-		for _, c := range nodeutil.NamedChildrenOf(node) {
-			items = append(items, ParseExpr(c, source, ctx))
+
+		if isArrayClone(node, source, ctx) {
+			return callStdjava("CloneArray", *object)
 		}
 
-		// If there wasn't a type for the array specified, then use the one that has been defined
-		if _, ok := ctx.lastType.(*ast.ArrayType); ctx.lastType != nil && ok {
-			return &ast.CompositeLit{
-				Type: ctx.lastType.(*ast.ArrayType),
-				Elts: items,
-			}
+		if isExceptionMethod(node, source, ctx) {
+			return &ast.CallExpr{Fun: &ast.SelectorExpr{X: *object, Sel: &ast.Ident{Name: exceptionMethods[methodName.Name]}}}
 		}
-		return &ast.CompositeLit{
-			Elts: items,
+
+		// The method may have been renamed, so look up its definition
+		method := resolveMethod(node, source, ctx)
+		if method != nil {
+			methodName.Name = method.Name
+
+			// Arguments are converted to the types of the method's parameters
+			if len(method.Parameters) == len(arguments) {
+				argumentNodes := nodeutil.NamedChildrenOf(node.ChildByFieldName("arguments"))
+				for ind, param := range method.Parameters {
+					arguments[ind] = AssignmentConversion(arguments[ind], argumentNodes[ind], source, ctx, param.OriginalType)
+				}
+			}
+
+			// Static methods are declared as functions, so they are called directly
+			if method.Static {
+				return &ast.CallExpr{Fun: methodName, Args: arguments}
+			}
 		}
-	case "method_invocation":
+
 		// Methods with a selector are called as X.Sel(Args)
 		// Otherwise, they are called as Fun(Args)
 		if node.ChildByFieldName("object") != nil {
 			return &ast.CallExpr{
 				Fun: &ast.SelectorExpr{
-					X:   ParseExpr(node.ChildByFieldName("object"), source, ctx),
-					Sel: ParseExpr(node.ChildByFieldName("name"), source, ctx).(*ast.Ident),
+					X:   *object,
+					Sel: methodName,
 				},
-				Args: ParseNode(node.ChildByFieldName("arguments"), source, ctx).([]ast.Expr),
+				Args: arguments,
 			}
 		}
+
+		// Methods of the current class can be called without `this`
+		if method != nil {
+			return &ast.CallExpr{
+				Fun: &ast.SelectorExpr{
+					X:   &ast.Ident{Name: ShortName(ctx.className)},
+					Sel: methodName,
+				},
+				Args: arguments,
+			}
+		}
+
 		return &ast.CallExpr{
-			Fun:  ParseExpr(node.ChildByFieldName("name"), source, ctx),
-			Args: ParseNode(node.ChildByFieldName("arguments"), source, ctx).([]ast.Expr),
+			Fun:  methodName,
+			Args: arguments,
 		}
 	case "object_creation_expression":
 		// This is called when anything is created with a constructor
 
+		if collection := NativeCollectionCreation(node, source, ctx); collection != nil {
+			return collection
+		}
+
 		objectType := node.ChildByFieldName("type")
 
 		// A object can also be created with this format:
@@ -166,30 +207,21 @@ func ParseExpr(node *sitter.Node, source []byte, ctx Ctx) ast.Expr {
 
 		// Get all the arguments, and look up their types
 		objectArguments := node.ChildByFieldName("arguments")
-		arguments := make([]ast.Expr, objectArguments.NamedChildCount())
-		argumentTypes := make([]string, objectArguments.NamedChildCount())
-		for ind, argument := range nodeutil.NamedChildrenOf(objectArguments) {
-			arguments[ind] = ParseExpr(argument, source, ctx)
-
-			// Look up each argument and find its type
-			if argument.Type() != "identifier" {
-				argumentTypes[ind] = symbol.TypeOfLiteral(argument, source)
-			} else {
-				if localDef := ctx.localScope.FindVariable(argument.Content(source)); localDef != nil {
-					argumentTypes[ind] = localDef.OriginalType
-					// Otherwise, a variable may exist as a global variable
-				} else if def := ctx.currentFile.FindField().ByOriginalName(argument.Content(source)); len(def) > 0 {
-					argumentTypes[ind] = def[0].OriginalType
-				}
-			}
-		}
+		arguments := parseArguments(objectArguments, source, ctx.evaluation())
+		argumentTypes := argumentTypes(objectArguments, source, ctx)
 
-		var constructor *symbol.Definition
-		// Find the respective constructor, and call it
+		className := objectType.Content(source)
 		if objectType.Type() == "generic_type" {
-			constructor = ctx.currentClass.FindMethodByName(objectType.NamedChild(0).Content(source), argumentTypes)
-		} else {
-			constructor = ctx.currentClass.FindMethodByName(objectType.Content(source), argumentTypes)
+			className = objectType.NamedChild(0).Content(source)
+		}
+		// Find the respective constructor in the class, which may be nested in
+		// another class, and call it
+		var constructor *symbol.Definition
+		if class := ctx.currentFile.FindClassScope(className); class != nil {
+			constructor = class.FindMethodByArguments(className, argumentTypes)
+		}
+		if constructor == nil {
+			constructor = ctx.currentClass.FindMethodByName(className, argumentTypes)
 		}
 
 		if constructor != nil {
@@ -199,6 +231,21 @@ func ParseExpr(node *sitter.Node, source []byte, ctx Ctx) ast.Expr {
 			}
 		}
 
+		// The JDK's exceptions are part of stdjava
+		if isJavaException(objectType.Content(source)) {
+			return NewException(objectType.Content(source), arguments, nodeutil.NamedChildrenOf(objectArguments), source, ctx)
+		}
+
+		// Java's string builders are part of stdjava
+		if isStringBuilderType(objectType.Content(source)) {
+			return NewStringBuilder(arguments, argumentTypes)
+		}
+
+		// So are Java's collections
+		if collection := NewCollection(node, source, ctx, arguments); collection != nil {
+			return collection
+		}
+
 		// It is also possible that a constructor could be unresolved, so we handle
 		// this by calling the type of the type + "Construct" at the beginning
 		return &ast.CallExpr{
@@ -206,98 +253,215 @@ func ParseExpr(node *sitter.Node, source []byte, ctx Ctx) ast.Expr {
 			Args: arguments,
 		}
 	case "array_creation_expression":
-		dimensions := []ast.Expr{}
-		arrayType := astutil.ParseType(node.ChildByFieldName("type"), source)
-
-		for _, child := range nodeutil.NamedChildrenOf(node) {
-			if child.Type() == "dimensions_expr" {
-				dimensions = append(dimensions, ParseExpr(child, source, ctx))
-			}
-		}
-
-		// TODO: Fix this to handle arrays that are declared with types,
-		// i.e `new int[] {1, 2, 3};`
-		if len(dimensions) == 0 {
-			panic("Array had zero dimensions")
-		}
-
-		return GenMultiDimArray(symbol.NodeToStr(arrayType), dimensions)
+		return ArrayCreation(node, source, ctx)
 	case "instanceof_expression":
 		return &ast.BadExpr{}
 	case "dimensions_expr":
 		return ParseExpr(node.NamedChild(0), source, ctx)
 	case "binary_expression":
-		if node.Child(1).Content(source) == ">>>" {
-			return &ast.CallExpr{
-				Fun:  &ast.Ident{Name: "UnsignedRightShift"},
-				Args: []ast.Expr{ParseExpr(node.Child(0), source, ctx), ParseExpr(node.Child(2), source, ctx)},
+		left, right := node.Child(0), node.Child(2)
+		operator := node.Child(1).Content(source)
+
+		if folded := foldConstant(node, source); folded != nil {
+			return folded
+		}
+
+		leftType := ExpressionType(left, source, ctx)
+		rightType := ExpressionType(right, source, ctx)
+		compareBoxes(node, source, ctx)
+
+		// The right operand of a conditional operation isn't always evaluated, so
+		// anything that it hoists has to be run conditionally
+		if operator == "&&" || operator == "||" {
+			if conditional := logicalExpression(node, source, ctx); conditional != nil {
+				return conditional
 			}
 		}
+
+		ev := ctx.evaluation()
+		xp, yp := ev.parse(left, source, identity), ev.parse(right, source, identity)
+		x, y := *xp, *yp
+
+		switch {
+		case operator == "+" && (leftType == "String" || rightType == "String"):
+			// Anything that is added to a string is converted into a string first
+			return Concatenation(x, leftType, y, rightType)
+		case operator == "&&" || operator == "||":
+		case operator == "<<" || operator == ">>" || operator == ">>>":
+			// Only the value being shifted is promoted, the shift distance keeps its type
+			promoted := unaryPromotion(leftType)
+			if constant := shiftConstant(left, right, source, operator, promoted); constant != nil {
+				return constant
+			}
+			x = PromoteOperand(x, left, source, leftType, promoted)
+			// A constant that is shifted by a variable would be given Go's int type
+			if isUntypedConstant(left, source) && promoted != "" {
+				x = callFunc(primitiveTypes[promoted], x)
+			}
+			return ShiftExpr(x, ShiftDistance(y, right, source, promoted), operator, promoted)
+		default:
+			// Both operands of a numeric operation are converted to the same type
+			promoted := binaryPromotion(leftType, rightType)
+			x = PromoteOperand(x, left, source, leftType, promoted)
+			y = PromoteOperand(y, right, source, rightType, promoted)
+		}
+
 		return &ast.BinaryExpr{
-			X:  ParseExpr(node.Child(0), source, ctx),
-			Op: StrToToken(node.Child(1).Content(source)),
-			Y:  ParseExpr(node.Child(2), source, ctx),
+			X:  x,
+			Op: StrToToken(operator),
+			Y:  y,
 		}
 	case "unary_expression":
+		operand := node.Child(1)
+		operator := node.Child(0).Content(source)
+
+		// Negative literals are written directly, since some values, such as
+		// `-2147483648L`, can't be written as positive numbers, and negating
+		// `0x80000000` overflows back into itself
+		if operator == "-" && isIntegerLiteral(operand) {
+			return IntegerLiteral(operand.Content(source), true)
+		}
+		// Negating a zero gives negative zero, which isn't a Go constant
+		if value, javaType, ok := floatingConstant(node, source); ok && value == 0 && math.Signbit(value) {
+			return floatingValue(value, javaType)
+		}
+
+		x := ParseExpr(operand, source, ctx)
+		if operator != "!" {
+			operandType := ExpressionType(operand, source, ctx)
+			x = PromoteOperand(x, operand, source, operandType, unaryPromotion(operandType))
+		}
+
 		return &ast.UnaryExpr{
-			Op: StrToToken(node.Child(0).Content(source)),
-			X:  ParseExpr(node.Child(1), source, ctx),
+			Op: StrToToken(operator),
+			X:  x,
 		}
 	case "parenthesized_expression":
+		inner := ParseExpr(node.NamedChild(0), source, ctx)
+		// An assignment, such as `(line = reader.readLine())`, is replaced with
+		// its variable, which doesn't need parentheses
+		if _, ok := inner.(*ast.Ident); ok && hoistsStatements(node.NamedChild(0)) {
+			return inner
+		}
 		return &ast.ParenExpr{
-			X: ParseExpr(node.NamedChild(0), source, ctx),
+			X: inner,
 		}
 	case "ternary_expression":
-		// Ternary expressions are replaced with a function that takes in the
-		// condition, and returns one of the two values, depending on the condition
+		// Only the branch that is chosen is evaluated, so the ternary is lowered
+		// into an `if` statement that runs before the expression
+		return ternaryExpression(node, source, ctx)
+	case "cast_expression":
+		castType := node.ChildByFieldName("type")
+		value := node.ChildByFieldName("value")
 
-		args := []ast.Expr{}
-		for _, c := range nodeutil.NamedChildrenOf(node) {
-			args = append(args, ParseExpr(c, source, ctx))
+		// Casting a lambda gives it the type of a functional interface
+		if function := FunctionalConversion(value, source, ctx, castType.Content(source)); function != nil {
+			return function
 		}
-		return &ast.CallExpr{
-			Fun:  &ast.Ident{Name: "ternary"},
-			Args: args,
+
+		// Constants can't overflow when they are converted in Go, so evaluate them
+		if narrowed := narrowConstant(value, source, castType.Content(source)); narrowed != nil {
+			return narrowed
 		}
-	case "cast_expression":
-		// TODO: This probably should be a cast function, instead of an assertion
-		return &ast.TypeAssertExpr{
-			X:    ParseExpr(node.NamedChild(1), source, ctx),
-			Type: astutil.ParseType(node.NamedChild(0), source),
+
+		// Casting to a boxed type boxes the value, and casting a box to a primitive
+		// type unboxes it first
+		if isBoxedType(castType.Content(source)) {
+			return BoxingConversion(ParseExpr(value, source, ctx), value, source, ctx, castType.Content(source))
+		}
+
+		// Casting an array into an array of a subtype gives a view of the same array
+		if isArrayType(castType.Content(source)) && isArrayType(ExpressionType(value, source, ctx)) {
+			return ArrayConversion(ParseExpr(value, source, ctx), value, source, ctx, castType.Content(source))
+		}
+
+		// The type is resolved like a declaration's, since classes may be renamed
+		var goType ast.Expr = astutil.ParseType(castType, source)
+		if resolved := ctx.currentFile.GoType(castType.Content(source)); resolved != "" {
+			goType = &ast.Ident{Name: resolved}
 		}
+
+		return CastExpr(
+			ParseExpr(value, source, ctx),
+			symbol.UnboxedType(ExpressionType(value, source, ctx)),
+			castType.Content(source),
+			goType,
+		)
 	case "field_access":
 		// X.Sel
 		obj := node.ChildByFieldName("object")
+		fieldName := node.ChildByFieldName("field").Content(source)
 
-		if obj.Type() == "this" {
-			def := ctx.currentClass.FindField().ByOriginalName(node.ChildByFieldName("field").Content(source))
-			if len(def) == 0 {
-				// TODO: This field could not be found in the current class, because it exists in the superclass
-				// definition for the class
-				def = []*symbol.Definition{&symbol.Definition{
-					Name: node.ChildByFieldName("field").Content(source),
-				}}
-			}
+		// The length of an array is the length of its slice
+		if fieldName == "length" && isArrayType(ExpressionType(obj, source, ctx)) {
+			return ArrayLength(ParseExpr(obj, source, ctx))
+		}
+
+		// The limits of the boxed types, such as `Integer.MAX_VALUE`, and the
+		// constants of `Math`
+		if constant := WrapperField(node, source, ctx); constant != nil {
+			return constant
+		}
+		if constant := MathField(node, source, ctx); constant != nil {
+			return constant
+		}
 
-			return &ast.SelectorExpr{
-				X:   ParseExpr(node.ChildByFieldName("object"), source, ctx),
-				Sel: &ast.Ident{Name: def[0].Name},
+		// Look up the field in the class of the object, since it may have been renamed
+		// TODO: The field might not be found in the class, because it exists in the
+		// superclass definition for the class
+		if class := classOfExpression(obj, source, ctx); class != nil {
+			if def := class.FindFieldByName(fieldName); def != nil {
+				// Static fields are global variables
+				if def.Static {
+					return &ast.Ident{Name: def.Name}
+				}
+				fieldName = def.Name
 			}
 		}
+
 		return &ast.SelectorExpr{
 			X:   ParseExpr(obj, source, ctx),
-			Sel: ParseExpr(node.ChildByFieldName("field"), source, ctx).(*ast.Ident),
+			Sel: &ast.Ident{Name: fieldName},
 		}
 	case "array_access":
-		return &ast.IndexExpr{
-			X:     ParseExpr(node.NamedChild(0), source, ctx),
-			Index: ParseExpr(node.NamedChild(1), source, ctx),
+		// A single-element array that is used to modify a captured variable may
+		// have been replaced with its element
+		if simplifyCaptures && node.NamedChild(0).Type() == "identifier" && ctx.localScope != nil {
+			array := node.NamedChild(0)
+			if def := ctx.localScope.FindVariableAt(array.Content(source), array.StartByte()); def != nil && def.CaptureBox {
+				return ParseExpr(array, source, ctx)
+			}
 		}
+		ev := ctx.evaluation()
+		array, index := ev.parse(node.NamedChild(0), source, identity), ev.parse(node.NamedChild(1), source, identity)
+		return &ast.IndexExpr{X: *array, Index: *index}
 	case "scoped_identifier":
 		return ParseExpr(node.NamedChild(0), source, ctx)
 	case "this":
 		return &ast.Ident{Name: ShortName(ctx.className)}
 	case "identifier":
+		// Local variables and parameters may have been renamed, so look them up
+		// in the scope that they are referenced in
+		if ctx.localScope != nil {
+			if def := ctx.localScope.FindVariableAt(node.Content(source), node.StartByte()); def != nil {
+				return &ast.Ident{Name: def.Name}
+			}
+		}
+
+		// Otherwise, the identifier can refer to one of the current class's fields
+		// without using `this`
+		if ctx.currentClass != nil {
+			if def := ctx.currentClass.FindFieldByName(node.Content(source)); def != nil {
+				if def.Static {
+					return &ast.Ident{Name: def.Name}
+				}
+				return &ast.SelectorExpr{
+					X:   &ast.Ident{Name: ShortName(ctx.className)},
+					Sel: &ast.Ident{Name: def.Name},
+				}
+			}
+		}
+
 		return &ast.Ident{Name: node.Content(source)}
 	case "type_identifier": // Any reference type
 		switch node.Content(source) {
@@ -320,29 +484,17 @@ func ParseExpr(node *sitter.Node, source []byte, ctx Ctx) ast.Expr {
 		}
 	case "null_literal":
 		return &ast.Ident{Name: "nil"}
-	case "decimal_integer_literal":
-		literal := node.Content(source)
-		switch literal[len(literal)-1] {
-		case 'L':
-			return &ast.CallExpr{Fun: &ast.Ident{Name: "int64"}, Args: []ast.Expr{&ast.BasicLit{Kind: token.INT, Value: literal[:len(literal)-1]}}}
-		}
-		return &ast.Ident{Name: literal}
-	case "hex_integer_literal":
-		return &ast.Ident{Name: node.Content(source)}
-	case "decimal_floating_point_literal":
-		// This is something like 1.3D or 1.3F
-		literal := node.Content(source)
-		switch literal[len(literal)-1] {
-		case 'D':
-			return &ast.CallExpr{Fun: &ast.Ident{Name: "float64"}, Args: []ast.Expr{&ast.BasicLit{Kind: token.FLOAT, Value: literal[:len(literal)-1]}}}
-		case 'F':
-			return &ast.CallExpr{Fun: &ast.Ident{Name: "float32"}, Args: []ast.Expr{&ast.BasicLit{Kind: token.FLOAT, Value: literal[:len(literal)-1]}}}
-		}
-		return &ast.Ident{Name: literal}
+	case "decimal_integer_literal", "hex_integer_literal", "octal_integer_literal", "binary_integer_literal":
+		return IntegerLiteral(node.Content(source), false)
+	case "decimal_floating_point_literal", "hex_floating_point_literal":
+		return FloatLiteral(node.Content(source))
 	case "string_literal":
-		return &ast.Ident{Name: node.Content(source)}
+		literal := node.Content(source)
+		return StringLiteral(literal[1 : len(literal)-1])
+	case "text_block":
+		return TextBlock(node.Content(source))
 	case "character_literal":
-		return &ast.Ident{Name: node.Content(source)}
+		return CharLiteral(node.Content(source))
 	case "true", "false":
 		return &ast.Ident{Name: node.Content(source)}
 	}
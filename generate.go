@@ -4,8 +4,14 @@ import (
 	"fmt"
 	"go/ast"
 	"go/token"
+	"regexp"
+	"sort"
 	"strconv"
+	"strings"
 	"unicode"
+
+	"github.com/NickyBoy89/java2go/symbol"
+	"golang.org/x/exp/slices"
 )
 
 var tokens = map[string]token.Token{
@@ -73,6 +79,60 @@ func ShortName(longName string) string {
 	return string(unicode.ToLower(rune(longName[0]))) + string(unicode.ToLower(rune(longName[len(longName)-1])))
 }
 
+// qualifiedName matches the package of a qualified name that is part of a type
+var qualifiedName = regexp.MustCompile(`([A-Za-z_]\w*)\.`)
+
+// GenImports generates the import declaration for every package that the
+// generated code references, or nil if it does not reference any packages
+func GenImports(file *ast.File) ast.Decl {
+	paths := []string{}
+	addPackage := func(name string) {
+		if path, known := symbol.PackagePath(name); known && !slices.Contains(paths, path) {
+			paths = append(paths, path)
+		}
+	}
+	ast.Inspect(file, func(node ast.Node) bool {
+		switch node := node.(type) {
+		case *ast.SelectorExpr:
+			if pkg, ok := node.X.(*ast.Ident); ok {
+				addPackage(pkg.Name)
+			}
+		case *ast.Ident:
+			// Types that are converted from Java are written out as identifiers,
+			// such as `[]*stdjava.StringBuilder`
+			for _, qualified := range qualifiedName.FindAllStringSubmatch(node.Name, -1) {
+				addPackage(qualified[1])
+			}
+		}
+		return true
+	})
+
+	if len(paths) == 0 {
+		return nil
+	}
+
+	// Standard library packages are listed before any others
+	sort.Slice(paths, func(i, j int) bool {
+		iStandard, jStandard := !strings.Contains(paths[i], "."), !strings.Contains(paths[j], ".")
+		if iStandard != jStandard {
+			return iStandard
+		}
+		return paths[i] < paths[j]
+	})
+
+	imports := &ast.GenDecl{Tok: token.IMPORT}
+	// Multiple imports have to be grouped together in parentheses
+	if len(paths) > 1 {
+		imports.Lparen = 1
+	}
+	for _, path := range paths {
+		imports.Specs = append(imports.Specs, &ast.ImportSpec{
+			Path: &ast.BasicLit{Kind: token.STRING, Value: strconv.Quote(path)},
+		})
+	}
+	return imports
+}
+
 // GenStruct is a utility method for generating the ast representation of
 // a struct, given its name and fields
 func GenStruct(structName string, structFields *ast.FieldList) ast.Decl {
@@ -139,99 +199,26 @@ func GenInterface(name string, methods *ast.FieldList) ast.Decl {
 	}
 }
 
-func GenMultiDimArray(arrayType string, dimensions []ast.Expr) ast.Expr {
-	if len(dimensions) == 1 {
-		return &ast.CallExpr{
-			Fun:  &ast.Ident{Name: "make"},
-			Args: append([]ast.Expr{&ast.Ident{Name: arrayType}}, dimensions...),
-		}
-	}
-
-	// arr := make([][][]int, 2)
-	base := &ast.AssignStmt{
-		Tok: token.DEFINE,
-		Lhs: []ast.Expr{&ast.Ident{Name: "arr"}},
-		Rhs: []ast.Expr{
-			makeExpression(genArrayType(arrayType, len(dimensions)), dimensions[0]),
-		},
+// GenFuncType generates a named function type, which is what a functional
+// interface is translated as, along with any type parameters that it has
+func GenFuncType(name string, typeParameters []string, funcType *ast.FuncType) ast.Decl {
+	spec := &ast.TypeSpec{
+		Name: &ast.Ident{Name: name},
+		Type: funcType,
 	}
 
-	indexes := []string{"ind"}
-
-	var body, currentDimension *ast.RangeStmt
-
-	for offset := range dimensions[1:] {
-		nextDim := &ast.RangeStmt{
-			Key: &ast.Ident{Name: indexes[len(indexes)-1]},
-			Tok: token.DEFINE,
-			X:   multiArrayAccess("arr", indexes[:len(indexes)-1]),
-			Body: &ast.BlockStmt{
-				List: []ast.Stmt{
-					&ast.AssignStmt{
-						Tok: token.ASSIGN,
-						Lhs: []ast.Expr{multiArrayAccess("arr", indexes)},
-						Rhs: []ast.Expr{makeExpression(genArrayType(arrayType, len(dimensions)-(offset+1)), dimensions[offset+1])},
-					},
-				},
-			},
-		}
-
-		if body == nil {
-			body = nextDim
-			currentDimension = body
-		} else {
-			currentDimension.Body.List = append(currentDimension.Body.List, nextDim)
-			currentDimension = currentDimension.Body.List[len(currentDimension.Body.List)-1].(*ast.RangeStmt)
+	if len(typeParameters) > 0 {
+		names := make([]*ast.Ident, len(typeParameters))
+		for ind, param := range typeParameters {
+			names[ind] = &ast.Ident{Name: param}
 		}
-
-		indexes = append(indexes, indexes[len(indexes)-1]+strconv.Itoa(offset))
+		spec.TypeParams = &ast.FieldList{List: []*ast.Field{
+			&ast.Field{Names: names, Type: &ast.Ident{Name: "any"}},
+		}}
 	}
 
-	return &ast.CallExpr{
-		Fun: &ast.FuncLit{
-			Type: &ast.FuncType{
-				Results: &ast.FieldList{
-					List: []*ast.Field{
-						&ast.Field{
-							Type: genArrayType(arrayType, len(dimensions)),
-						},
-					},
-				},
-			},
-			Body: &ast.BlockStmt{
-				List: []ast.Stmt{
-					base,
-					body,
-					&ast.ReturnStmt{Results: []ast.Expr{&ast.Ident{Name: "arr"}}},
-				},
-			},
-		},
-	}
-}
-
-func multiArrayAccess(arrName string, dims []string) ast.Expr {
-	var arr ast.Expr = &ast.Ident{Name: arrName}
-	for _, dim := range dims {
-		arr = &ast.IndexExpr{X: arr, Index: &ast.Ident{Name: dim}}
-	}
-	return arr
-}
-
-func genArrayType(arrayType string, depth int) ast.Expr {
-	var arrayDims ast.Expr = &ast.Ident{Name: arrayType}
-	for i := 0; i < depth; i++ {
-		arrayDims = &ast.ArrayType{Elt: arrayDims}
-	}
-	return arrayDims
-}
-
-// makeExpression constructs an array with the `make` keyword
-func makeExpression(dims, expr ast.Expr) *ast.CallExpr {
-	return &ast.CallExpr{
-		Fun: &ast.Ident{Name: "make"},
-		Args: []ast.Expr{
-			dims,
-			expr,
-		},
+	return &ast.GenDecl{
+		Tok:   token.TYPE,
+		Specs: []ast.Spec{spec},
 	}
 }
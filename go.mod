@@ -1,6 +1,6 @@
 module github.com/NickyBoy89/java2go
 
-go 1.18
+go 1.24
 
 require (
 	github.com/sirupsen/logrus v1.9.0
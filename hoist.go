@@ -0,0 +1,463 @@
+package main
+
+import (
+	"go/ast"
+	"go/token"
+	"strconv"
+
+	"github.com/NickyBoy89/java2go/nodeutil"
+	sitter "github.com/smacker/go-tree-sitter"
+)
+
+// Go only allows assignments and increments as statements, but Java allows
+// them anywhere in an expression, such as `array[i++] = value`, so they are
+// hoisted out of their expression, into statements that run before the
+// statement that contains the expression
+
+// ParseBlockStmts parses a statement in a block, and returns it along with
+// the statements that were hoisted out of its expressions
+func ParseBlockStmts(node *sitter.Node, source []byte, ctx Ctx) []ast.Stmt {
+	hoisted := []ast.Stmt{}
+	ctx.hoisted = &hoisted
+
+	if stmt := TryParseStmt(node, source, ctx); stmt != nil {
+		return append(hoisted, stmt)
+	}
+	// Try statements are ignored, so they return a list of statements
+	return append(hoisted, ParseNode(node, source, ctx).([]ast.Stmt)...)
+}
+
+// parseBody parses the body of a statement, such as an `if` statement, which
+// may not be a block, but has to be one in Go
+func parseBody(node *sitter.Node, source []byte, ctx Ctx) *ast.BlockStmt {
+	stmts := ParseBlockStmts(node, source, ctx)
+	if block, ok := stmts[0].(*ast.BlockStmt); ok && len(stmts) == 1 {
+		return block
+	}
+	return &ast.BlockStmt{List: stmts}
+}
+
+// parseCondition parses an expression that is evaluated more than once, such
+// as the condition of a loop, and returns the statements that have to be run
+// before each time that it is evaluated
+func parseCondition(node *sitter.Node, source []byte, ctx Ctx) (ast.Expr, []ast.Stmt) {
+	hoisted := []ast.Stmt{}
+	ctx.hoisted = &hoisted
+	return ParseExpr(node, source, ctx), hoisted
+}
+
+// hoist adds statements that run before the statement that is being parsed
+func (ctx Ctx) hoist(stmts ...ast.Stmt) {
+	*ctx.hoisted = append(*ctx.hoisted, stmts...)
+}
+
+// temporary hoists the declaration of a new temporary variable, which is set
+// to the given value, and returns its name
+func (ctx Ctx) temporary(value ast.Expr) *ast.Ident {
+	name := ctx.temporaryName()
+	ctx.hoist(&ast.AssignStmt{Lhs: []ast.Expr{name}, Tok: token.DEFINE, Rhs: []ast.Expr{value}})
+	return name
+}
+
+// temporaryName returns a name for a temporary variable that is not used by
+// any of the local variables of the current method
+func (ctx Ctx) temporaryName() *ast.Ident {
+	for {
+		name := "temp" + strconv.Itoa(*ctx.temporaries)
+		*ctx.temporaries++
+
+		var used bool
+		if ctx.localScope != nil {
+			for _, local := range append(ctx.localScope.Locals(), ctx.localScope.Parameters...) {
+				if local.Name == name {
+					used = true
+				}
+			}
+		}
+		if !used {
+			return &ast.Ident{Name: name}
+		}
+	}
+}
+
+// withHoisting parses an expression that hoists statements, even when it is
+// not part of a statement, such as in the initial value of a field
+//
+// In that case, the hoisted statements are run in a function, which returns
+// the value of the expression
+func withHoisting(node *sitter.Node, source []byte, ctx Ctx, parse func(ctx Ctx) ast.Expr) ast.Expr {
+	if ctx.hoisted != nil {
+		return parse(ctx)
+	}
+
+	hoisted := []ast.Stmt{}
+	ctx.hoisted = &hoisted
+	if ctx.temporaries == nil {
+		ctx.temporaries = new(int)
+	}
+	value := parse(ctx)
+
+	resultType := ctx.currentFile.GoType(ExpressionType(node, source, ctx))
+	if resultType == "" {
+		resultType = "any"
+	}
+	return &ast.CallExpr{Fun: &ast.FuncLit{
+		Type: &ast.FuncType{
+			Params:  &ast.FieldList{},
+			Results: &ast.FieldList{List: []*ast.Field{&ast.Field{Type: &ast.Ident{Name: resultType}}}},
+		},
+		Body: &ast.BlockStmt{List: append(hoisted, &ast.ReturnStmt{Results: []ast.Expr{value}})},
+	}}
+}
+
+// An evaluation keeps Java's left-to-right evaluation order for the operands
+// of an expression, such as the arguments of a method call
+//
+// If an operand hoists statements, those statements would run before any of
+// the operands that come before it, so any of the earlier operands that could
+// be changed by them are stored in temporary variables first
+type evaluation struct {
+	ctx      Ctx
+	operands []*ast.Expr
+	// The number of hoisted statements after each operand was evaluated
+	ends  []int
+	start int
+}
+
+func (ctx Ctx) evaluation() *evaluation {
+	ev := &evaluation{ctx: ctx}
+	if ctx.hoisted != nil {
+		ev.start = len(*ctx.hoisted)
+	}
+	return ev
+}
+
+// parse parses the next operand, which the result of the conversion is
+// stored in, so that it can be replaced with a temporary variable later
+func (ev *evaluation) parse(node *sitter.Node, source []byte, convert func(ast.Expr) ast.Expr) *ast.Expr {
+	operand := new(ast.Expr)
+	*operand = convert(ParseExpr(node, source, ev.ctx))
+	ev.add(operand)
+	return operand
+}
+
+// identity is used to parse an operand without converting it
+func identity(expr ast.Expr) ast.Expr {
+	return expr
+}
+
+// evaluated returns the final values of operands that have been parsed
+func evaluated(operands []*ast.Expr) []ast.Expr {
+	values := make([]ast.Expr, len(operands))
+	for ind, operand := range operands {
+		values[ind] = *operand
+	}
+	return values
+}
+
+// parseArguments parses the arguments of a method call in order
+func parseArguments(node *sitter.Node, source []byte, ev *evaluation) []ast.Expr {
+	var arguments []*ast.Expr
+	for _, argument := range nodeutil.NamedChildrenOf(node) {
+		arguments = append(arguments, ev.parse(argument, source, identity))
+	}
+	return evaluated(arguments)
+}
+
+// add records an operand that has just been parsed
+func (ev *evaluation) add(operand *ast.Expr) {
+	if ev.ctx.hoisted == nil {
+		return
+	}
+
+	previous := ev.start
+	if len(ev.ends) > 0 {
+		previous = ev.ends[len(ev.ends)-1]
+	}
+	end := len(*ev.ctx.hoisted)
+
+	if end > previous {
+		hoisted := append([]ast.Stmt{}, (*ev.ctx.hoisted)[previous:end]...)
+		for ind, earlier := range ev.operands {
+			if !mayChange(*earlier, hoisted) {
+				continue
+			}
+
+			// Evaluate the earlier operand right after it would have been evaluated
+			name := ev.ctx.temporaryName()
+			stmts := *ev.ctx.hoisted
+			stmts = append(stmts[:ev.ends[ind]], append([]ast.Stmt{
+				&ast.AssignStmt{Lhs: []ast.Expr{name}, Tok: token.DEFINE, Rhs: []ast.Expr{*earlier}},
+			}, stmts[ev.ends[ind]:]...)...)
+			*ev.ctx.hoisted = stmts
+			*earlier = name
+
+			for later := ind; later < len(ev.ends); later++ {
+				ev.ends[later]++
+			}
+			end++
+		}
+	}
+
+	ev.operands = append(ev.operands, operand)
+	ev.ends = append(ev.ends, end)
+}
+
+// addTarget records the target of an assignment, which has to stay
+// assignable, so only the parts of it that are evaluated are recorded
+func (ev *evaluation) addTarget(target ast.Expr) {
+	switch target := target.(type) {
+	case *ast.IndexExpr:
+		ev.add(&target.X)
+		ev.add(&target.Index)
+	case *ast.SelectorExpr:
+		ev.add(&target.X)
+	case *ast.StarExpr:
+		ev.add(&target.X)
+	}
+}
+
+// stableTarget returns a version of the target of an assignment that can be
+// evaluated more than once, without any side effects, and with the same result
+func (ctx Ctx) stableTarget(target ast.Expr) ast.Expr {
+	stable := func(expr ast.Expr) ast.Expr {
+		if isConstant(expr) {
+			return expr
+		} else if ident, ok := expr.(*ast.Ident); ok {
+			return ident
+		}
+		return ctx.temporary(expr)
+	}
+
+	switch target := target.(type) {
+	case *ast.IndexExpr:
+		return &ast.IndexExpr{X: stable(target.X), Index: stable(target.Index)}
+	case *ast.SelectorExpr:
+		return &ast.SelectorExpr{X: stable(target.X), Sel: target.Sel}
+	}
+	return target
+}
+
+// mayChange tests if the value of an expression could be different after the
+// given statements have been run, or if evaluating it has side effects
+func mayChange(expr ast.Expr, stmts []ast.Stmt) bool {
+	if isConstant(expr) {
+		return false
+	}
+
+	switch expr := expr.(type) {
+	case *ast.FuncLit:
+		return false
+	case *ast.Ident:
+		return assignsTo(stmts, func(target ast.Expr) bool {
+			ident, ok := target.(*ast.Ident)
+			return ok && ident.Name == expr.Name
+		})
+	case *ast.ParenExpr:
+		return mayChange(expr.X, stmts)
+	case *ast.UnaryExpr:
+		return mayChange(expr.X, stmts)
+	case *ast.BinaryExpr:
+		return mayChange(expr.X, stmts) || mayChange(expr.Y, stmts)
+	case *ast.SelectorExpr:
+		// Fields can be changed through any call, or an assignment to a field
+		// with the same name
+		return mayChange(expr.X, stmts) || containsCall(stmts) || assignsTo(stmts, func(target ast.Expr) bool {
+			field, ok := target.(*ast.SelectorExpr)
+			return ok && field.Sel.Name == expr.Sel.Name
+		})
+	case *ast.IndexExpr, *ast.StarExpr:
+		// Elements can be changed through any call, or any assignment that is
+		// not to a local variable
+		return mayChange(childExpr(expr), stmts) || containsCall(stmts) || assignsTo(stmts, func(target ast.Expr) bool {
+			_, local := target.(*ast.Ident)
+			return !local
+		})
+	case *ast.CallExpr:
+		// Conversions between types don't have side effects
+		if fun, ok := expr.Fun.(*ast.Ident); ok && len(expr.Args) == 1 && isGoType(fun.Name) {
+			return mayChange(expr.Args[0], stmts)
+		}
+	}
+	return true
+}
+
+// childExpr returns the expression that an index or pointer expression is
+// applied to
+func childExpr(expr ast.Expr) ast.Expr {
+	switch expr := expr.(type) {
+	case *ast.IndexExpr:
+		return &ast.BinaryExpr{X: expr.X, Y: expr.Index}
+	case *ast.StarExpr:
+		return expr.X
+	}
+	return expr
+}
+
+// isConstant tests if an expression is made up of only literals
+func isConstant(expr ast.Expr) bool {
+	switch expr := expr.(type) {
+	case *ast.BasicLit:
+		return true
+	case *ast.Ident:
+		return expr.Name == "true" || expr.Name == "false" || expr.Name == "nil"
+	case *ast.ParenExpr:
+		return isConstant(expr.X)
+	case *ast.UnaryExpr:
+		return isConstant(expr.X)
+	case *ast.BinaryExpr:
+		return isConstant(expr.X) && isConstant(expr.Y)
+	}
+	return false
+}
+
+// isGoType tests if a name is one of Go's basic types
+func isGoType(name string) bool {
+	switch name {
+	case "int8", "int16", "int32", "int64", "int", "uint16", "rune", "float32", "float64", "bool", "string":
+		return true
+	}
+	return false
+}
+
+// assignsTo tests if any of the statements assign to a target that matches
+func assignsTo(stmts []ast.Stmt, matches func(target ast.Expr) bool) bool {
+	var found bool
+	for _, stmt := range stmts {
+		ast.Inspect(stmt, func(node ast.Node) bool {
+			switch node := node.(type) {
+			case *ast.AssignStmt:
+				for _, target := range node.Lhs {
+					found = found || (node.Tok != token.DEFINE && matches(target))
+				}
+			case *ast.IncDecStmt:
+				found = found || matches(node.X)
+			case *ast.FuncLit:
+				// Functions are only declared, not run
+				return false
+			}
+			return !found
+		})
+	}
+	return found
+}
+
+// containsCall tests if any of the statements call a function
+func containsCall(stmts []ast.Stmt) bool {
+	var found bool
+	for _, stmt := range stmts {
+		ast.Inspect(stmt, func(node ast.Node) bool {
+			switch node := node.(type) {
+			case *ast.CallExpr:
+				if fun, ok := node.Fun.(*ast.Ident); !ok || !isGoType(fun.Name) {
+					found = true
+				}
+			case *ast.FuncLit:
+				return false
+			}
+			return !found
+		})
+	}
+	return found
+}
+
+// updateExpression hoists an increment or decrement out of an expression,
+// such as `array[i++]`, and returns the value of the expression, which is the
+// value of the variable before the update for a postfix update, and after the
+// update for a prefix update
+func updateExpression(node *sitter.Node, source []byte, ctx Ctx) ast.Expr {
+	return withHoisting(node, source, ctx, func(ctx Ctx) ast.Expr {
+		operand, operator := node.Child(0), node.Child(1)
+		postfix := operand.IsNamed()
+		if !postfix {
+			operand, operator = node.Child(1), node.Child(0)
+		}
+
+		target := ctx.stableTarget(ParseExpr(operand, source, ctx))
+
+		var value ast.Expr = target
+		if postfix {
+			value = ctx.temporary(target)
+		}
+		if holdsBox(operand, source, ctx) {
+			ctx.hoist(boxedUpdate(target, boxedIncrement(target, node, source), ExpressionType(operand, source, ctx)))
+			return value
+		}
+		ctx.hoist(&ast.IncDecStmt{X: target, Tok: StrToToken(operator.Content(source))})
+		return value
+	})
+}
+
+// assignmentExpression hoists an assignment out of an expression, such as
+// `(line = reader.readLine()) != null`, and returns the value of the
+// variable after it has been assigned
+func assignmentExpression(node *sitter.Node, source []byte, ctx Ctx) ast.Expr {
+	return withHoisting(node, source, ctx, func(ctx Ctx) ast.Expr {
+		target := ctx.stableTarget(ParseExpr(node.Child(0), source, ctx))
+		ctx.hoist(assignmentStmt(node, target, source, ctx))
+		return target
+	})
+}
+
+// logicalExpression parses a conditional `&&` or `||` expression whose right
+// operand hoists statements, which can't run unless the right operand would
+// be evaluated, so the operation is replaced with an `if` statement
+//
+// Returns nil if the right operand does not hoist any statements
+func logicalExpression(node *sitter.Node, source []byte, ctx Ctx) ast.Expr {
+	if ctx.hoisted == nil || !hoistsStatements(node.Child(2)) {
+		return nil
+	}
+
+	left := ParseExpr(node.Child(0), source, ctx)
+	right, hoisted := parseCondition(node.Child(2), source, ctx)
+	if len(hoisted) == 0 {
+		return &ast.BinaryExpr{X: left, Op: StrToToken(node.Child(1).Content(source)), Y: right}
+	}
+
+	result := ctx.temporary(left)
+	var cond ast.Expr = result
+	if node.Child(1).Content(source) == "||" {
+		cond = &ast.UnaryExpr{Op: token.NOT, X: result}
+	}
+	ctx.hoist(&ast.IfStmt{
+		Cond: cond,
+		Body: &ast.BlockStmt{List: append(hoisted, &ast.AssignStmt{
+			Lhs: []ast.Expr{result},
+			Tok: token.ASSIGN,
+			Rhs: []ast.Expr{right},
+		})},
+	})
+	return result
+}
+
+// hoistsStatements tests if an expression contains any assignments, updates,
+// or ternaries outside of any lambdas, which hoist statements out of it
+func hoistsStatements(node *sitter.Node) bool {
+	switch node.Type() {
+	case "assignment_expression", "update_expression", "ternary_expression":
+		return true
+	case "lambda_expression", "class_body":
+		return false
+	}
+	for _, child := range nodeutil.NamedChildrenOf(node) {
+		if hoistsStatements(child) {
+			return true
+		}
+	}
+	return false
+}
+
+// conditionalLoop creates a loop whose condition hoists statements, which are
+// run before the condition is checked on each iteration
+func conditionalLoop(cond ast.Expr, hoisted []ast.Stmt, init, post ast.Stmt, body *ast.BlockStmt) *ast.ForStmt {
+	check := &ast.IfStmt{
+		Cond: &ast.UnaryExpr{Op: token.NOT, X: &ast.ParenExpr{X: cond}},
+		Body: &ast.BlockStmt{List: []ast.Stmt{&ast.BranchStmt{Tok: token.BREAK}}},
+	}
+	return &ast.ForStmt{
+		Init: init,
+		Post: post,
+		Body: &ast.BlockStmt{List: append(append(hoisted, check), body.List...)},
+	}
+}
@@ -12,6 +12,7 @@ import (
 	"strings"
 	"sync"
 
+	"github.com/NickyBoy89/java2go/astutil"
 	"github.com/NickyBoy89/java2go/parsing"
 	"github.com/NickyBoy89/java2go/symbol"
 	log "github.com/sirupsen/logrus"
@@ -27,11 +28,15 @@ var (
 	displayAST              bool
 	symbolAware             bool
 	parseFilesSynchronously bool
+	simplifyCaptures        bool
+	nativeCollections       bool
+	lowerStreams            bool
 )
 
 var (
 	outputDirectory    string
 	ignoredAnnotations string
+	charType           string
 )
 
 func main() {
@@ -42,12 +47,32 @@ func main() {
 	flag.BoolVar(&symbolAware, "symbols", true, `Whether the program is aware of the symbols of the parsed code
 Results in better code generation, but can be disabled for a more direct translation
 or to fix crashes with the symbol handling`,
+	)
+	flag.BoolVar(&simplifyCaptures, "simplify-captures", false, `Replace single-element arrays that are used to modify variables captured by lambdas,
+such as "final int[] counter = {0}", with the variables themselves`,
+	)
+	flag.BoolVar(&nativeCollections, "native-collections", false, `Translate local lists and maps that are never shared with any other code into Go's slices and maps,
+instead of stdjava's collections`,
+	)
+	flag.BoolVar(&lowerStreams, "lower-streams", false, `Translate simple stream pipelines, such as "list.stream().filter(...).map(...).collect(Collectors.toList())",
+into loops, instead of calls to stdjava's streams`,
 	)
 	flag.StringVar(&outputDirectory, "output", ".", "Specify a directory for the generated files")
 	flag.StringVar(&ignoredAnnotations, "exclude-annotations", "", "A comma-separated list of annotations to exclude from the final code generation")
+	flag.StringVar(&charType, "char", "rune", `The Go type that Java's chars are represented as, either "rune" or "uint16"
+A uint16 matches the size of a Java char, but a rune is easier to use with Go's strings`,
+	)
 
 	flag.Parse()
 
+	switch charType {
+	case "rune", "uint16":
+		astutil.CharType = charType
+		primitiveTypes["char"] = charType
+	default:
+		log.WithField("char", charType).Fatal("Chars must be represented as either a rune or a uint16")
+	}
+
 	for _, annotation := range strings.Split(ignoredAnnotations, ",") {
 		excludedAnnotations[annotation] = true
 	}
@@ -127,6 +152,11 @@ or to fix crashes with the symbol handling`,
 				ResolveFile(file)
 			}
 		}
+		for _, file := range files {
+			if !file.Ast.HasError() {
+				ResolveLocals(file)
+			}
+		}
 	}
 
 	// Transpile the files
@@ -0,0 +1,641 @@
+package main
+
+import (
+	"go/ast"
+	"strconv"
+
+	"github.com/NickyBoy89/java2go/astutil"
+	"github.com/NickyBoy89/java2go/nodeutil"
+	"github.com/NickyBoy89/java2go/symbol"
+	sitter "github.com/smacker/go-tree-sitter"
+)
+
+// ParseLambda translates a lambda expression into a function literal
+//
+// If the functional interface that the lambda implements is known, then the
+// function takes the types of the interface's method, and lambdas with a
+// single expression return its value. Otherwise, any parameters without types
+// are given the `any` type
+func ParseLambda(node *sitter.Node, source []byte, ctx Ctx, signature *symbol.FunctionalSignature) *ast.FuncLit {
+	var paramTypes []string
+	var resultType string
+
+	ctx.returnType = ""
+	ctx.exit = nil
+	if signature != nil {
+		paramTypes = signature.ParameterTypes(ctx.currentFile)
+		resultType = signature.ResultType(ctx.currentFile)
+		ctx.returnType = signature.Result
+	}
+
+	params := &ast.FieldList{}
+	for ind, param := range lambdaParameters(node.ChildByFieldName("parameters")) {
+		if param.Type() != "identifier" {
+			params.List = append(params.List, ParseNode(param, source, ctx).(*ast.Field))
+			continue
+		}
+
+		field := &ast.Field{
+			Names: []*ast.Ident{&ast.Ident{Name: param.Content(source)}},
+			Type:  &ast.Ident{Name: "any"},
+		}
+
+		var def *symbol.Definition
+		if ctx.localScope != nil {
+			def = ctx.localScope.FindVariableAt(param.Content(source), param.StartByte())
+		}
+
+		if ind < len(paramTypes) {
+			field.Type = &ast.Ident{Name: paramTypes[ind]}
+			// The parameters are declared without types, so they get their types
+			// from the interface, which is needed to translate the lambda's body
+			if def != nil {
+				def.OriginalType, def.Type = signature.Parameters[ind], paramTypes[ind]
+			}
+		}
+		if def != nil {
+			field.Names[0].Name = def.Name
+		}
+
+		params.List = append(params.List, field)
+	}
+
+	bodyNode := node.ChildByFieldName("body")
+
+	// Without a functional interface, a lambda whose body is an expression that
+	// can't be a statement, such as a ternary, still returns its value, which
+	// has the type of the expression
+	if signature == nil && bodyNode.Type() != "block" && !isStatementExpression(bodyNode) {
+		ctx.returnType = symbol.UnboxedType(ExpressionType(bodyNode, source, ctx))
+		if resultType = ctx.currentFile.GoType(ctx.returnType); resultType == "" {
+			resultType = "any"
+		}
+	}
+
+	funcType := &ast.FuncType{Params: params}
+	if resultType != "" {
+		funcType.Results = &ast.FieldList{List: []*ast.Field{&ast.Field{Type: &ast.Ident{Name: resultType}}}}
+	}
+
+	// The body of the lambda is a new statement, which anything that its
+	// expressions hoist is run before
+	hoisted := []ast.Stmt{}
+	ctx.hoisted = &hoisted
+	if ctx.temporaries == nil {
+		ctx.temporaries = new(int)
+	}
+
+	var body *ast.BlockStmt
+	switch {
+	case bodyNode.Type() == "block":
+		body = ParseStmt(bodyNode, source, ctx).(*ast.BlockStmt)
+	case resultType != "" && isTernary(bodyNode):
+		body = &ast.BlockStmt{List: returnTernary(bodyNode, source, ctx, ctx.returnType)}
+	case resultType != "":
+		// Lambdas with a single expression return its value
+		body = &ast.BlockStmt{List: []ast.Stmt{&ast.ReturnStmt{Results: []ast.Expr{
+			AssignmentConversion(ParseExpr(bodyNode, source, ctx), bodyNode, source, ctx, ctx.returnType),
+		}}}}
+	default:
+		// Otherwise, the expression is run as a statement, such as a method call
+		stmt := TryParseStmt(bodyNode, source, ctx)
+		if stmt == nil {
+			stmt = &ast.ExprStmt{X: ParseExpr(bodyNode, source, ctx)}
+		}
+		body = &ast.BlockStmt{List: []ast.Stmt{stmt}}
+	}
+	body.List = append(hoisted, body.List...)
+
+	return &ast.FuncLit{Type: funcType, Body: body}
+}
+
+// isStatementExpression tests if an expression can be used as a statement by
+// itself, such as a method call, in which case a lambda may discard its value
+func isStatementExpression(node *sitter.Node) bool {
+	switch node.Type() {
+	case "assignment_expression", "update_expression", "method_invocation", "object_creation_expression":
+		return true
+	}
+	return false
+}
+
+// lambdaParameters returns the nodes for each of a lambda's parameters, which
+// are either identifiers, or formal parameters that have types
+func lambdaParameters(node *sitter.Node) []*sitter.Node {
+	switch node.Type() {
+	case "identifier":
+		// A single parameter, without parentheses
+		return []*sitter.Node{node}
+	case "inferred_parameters", "formal_parameters":
+		return nodeutil.NamedChildrenOf(node)
+	}
+	return nil
+}
+
+// FunctionalConversion translates a lambda expression or a method reference
+// into a function with the types of the given functional interface
+//
+// Returns nil if the expression is neither, or if the type is not a known
+// functional interface
+func FunctionalConversion(node *sitter.Node, source []byte, ctx Ctx, to string) ast.Expr {
+	for node.Type() == "parenthesized_expression" {
+		node = node.NamedChild(0)
+	}
+	if node.Type() != "lambda_expression" && node.Type() != "method_reference" {
+		return nil
+	}
+
+	signature := ctx.currentFile.FunctionalSignatureOf(to)
+	if signature == nil {
+		return nil
+	}
+	if node.Type() == "method_reference" {
+		return ParseMethodReference(node, source, ctx, signature)
+	}
+	return ParseLambda(node, source, ctx, signature)
+}
+
+// functionalCall returns the signature of the functional interface whose method
+// is called by a method invocation, such as the `apply` in `function.apply(x)`,
+// or nil if the method is not called on a functional interface
+func functionalCall(node *sitter.Node, source []byte, ctx Ctx) *symbol.FunctionalSignature {
+	object := node.ChildByFieldName("object")
+	if object == nil {
+		return nil
+	}
+	signature := ctx.currentFile.FunctionalSignatureOf(ExpressionType(object, source, ctx))
+	if signature == nil || signature.Method != node.ChildByFieldName("name").Content(source) {
+		return nil
+	}
+	return signature
+}
+
+// ParseMethodReference translates a method reference into a function that
+// calls the referenced method
+//
+// If the functional interface that the reference implements is known, then the
+// reference becomes a function with the interface's types, which converts its
+// arguments into the types of the method. Otherwise, the reference becomes a
+// function or method value that refers to the method directly
+func ParseMethodReference(node *sitter.Node, source []byte, ctx Ctx, signature *symbol.FunctionalSignature) ast.Expr {
+	target := node.NamedChild(0)
+
+	// Constructor references, such as `Foo::new`, only have a single child
+	var methodName string
+	if node.NamedChildCount() > 1 {
+		methodName = node.NamedChild(1).Content(source)
+	}
+
+	// Array constructors, such as `int[]::new`, take the length of the array
+	if target.Type() == "array_type" {
+		arrayType := astutil.ParseType(target, source)
+		if signature == nil {
+			signature = &symbol.FunctionalSignature{Parameters: []string{"int"}, Result: target.Content(source)}
+		}
+		return referenceClosure(ctx, signature, func(args []ast.Expr) (ast.Expr, string) {
+			return callFunc("make", arrayType, convertValue(args[0], signature.Parameters[0], "int")), target.Content(source)
+		})
+	}
+
+	if !isTypeReference(target, source, ctx) {
+		return boundMethodReference(target, methodName, source, ctx, signature)
+	}
+
+	typeName := symbol.BaseTypeName(target.Content(source))
+	class := findClassScope(typeName, ctx)
+
+	if methodName == "" {
+		if class != nil {
+			if constructor := findReferencedMethod(class, class.Class.OriginalName, referenceArguments(signature, 0), true); constructor != nil {
+				if signature == nil {
+					return &ast.Ident{Name: constructor.Name}
+				}
+				return referenceClosure(ctx, signature, func(args []ast.Expr) (ast.Expr, string) {
+					return callFunc(constructor.Name, convertArguments(args, signature.Parameters, constructor)...), typeName
+				})
+			}
+		}
+
+		// Constructors that could not be resolved are handled the same way as in
+		// an object creation expression
+		constructorName := "Construct" + typeName
+		if signature == nil {
+			return &ast.Ident{Name: constructorName}
+		}
+		return referenceClosure(ctx, signature, func(args []ast.Expr) (ast.Expr, string) {
+			return callFunc(constructorName, args...), typeName
+		})
+	}
+
+	// Methods on strings are translated into functions, with the string as the
+	// first argument
+	if typeName == "String" && signature != nil && len(signature.Parameters) > 0 {
+		if _, known := stringMethodTypes[methodName]; known {
+			return referenceClosure(ctx, signature, func(args []ast.Expr) (ast.Expr, string) {
+				arguments, types := unboxedArguments(args[1:], signature.Parameters[1:])
+				return StringMethod(args[0], methodName, arguments, types), stringMethodTypes[methodName]
+			})
+		}
+	}
+
+	// The same goes for the static methods of the boxed types' classes, such as
+	// `Integer::parseInt`, and the methods of boxes, such as `Integer::intValue`
+	if className := wrapperClass(target, source, ctx); className != "" && signature != nil {
+		if wrapperStaticType(className, methodName) != "" {
+			return referenceClosure(ctx, signature, func(args []ast.Expr) (ast.Expr, string) {
+				return wrapperStaticCall(className, methodName, args, signature.Parameters)
+			})
+		}
+		if _, known := wrapperMethodTypes[methodName]; known && len(signature.Parameters) > 0 {
+			return referenceClosure(ctx, signature, func(args []ast.Expr) (ast.Expr, string) {
+				value := convertValue(args[0], signature.Parameters[0], symbol.UnboxedType(className))
+				return wrapperInstanceCall(className, methodName, value, args[1:], signature.Parameters[1:])
+			})
+		}
+	}
+
+	// And the methods of `Math`, such as `Math::max`
+	if isMathClass(target, source, ctx) && signature != nil && mathResultType(methodName, signature.Parameters) != "" {
+		return referenceClosure(ctx, signature, func(args []ast.Expr) (ast.Expr, string) {
+			return mathCall(methodName, args, signature.Parameters)
+		})
+	}
+
+	if class != nil {
+		paramTypes := referenceArguments(signature, 0)
+
+		// Static methods take all of the interface's arguments
+		if method := findReferencedMethod(class, methodName, paramTypes, true); method != nil {
+			if signature == nil {
+				return &ast.Ident{Name: method.Name}
+			}
+			return referenceClosure(ctx, signature, func(args []ast.Expr) (ast.Expr, string) {
+				return callFunc(method.Name, convertArguments(args, signature.Parameters, method)...), method.OriginalType
+			})
+		}
+
+		// Instance methods are called on the first argument
+		if signature == nil {
+			if method := findReferencedMethod(class, methodName, nil, false); method != nil {
+				return &ast.SelectorExpr{
+					X:   &ast.ParenExpr{X: &ast.StarExpr{X: &ast.Ident{Name: class.Class.Name}}},
+					Sel: &ast.Ident{Name: method.Name},
+				}
+			}
+		} else if len(paramTypes) > 0 {
+			if method := findReferencedMethod(class, methodName, referenceArguments(signature, 1), false); method != nil {
+				return referenceClosure(ctx, signature, func(args []ast.Expr) (ast.Expr, string) {
+					return &ast.CallExpr{
+						Fun:  &ast.SelectorExpr{X: args[0], Sel: &ast.Ident{Name: method.Name}},
+						Args: convertArguments(args[1:], signature.Parameters[1:], method),
+					}, method.OriginalType
+				})
+			}
+		}
+	}
+
+	// Methods of unknown classes are called as they are written
+	reference := &ast.SelectorExpr{X: ParseExpr(target, source, ctx), Sel: &ast.Ident{Name: methodName}}
+	if signature == nil {
+		return reference
+	}
+	return referenceClosure(ctx, signature, func(args []ast.Expr) (ast.Expr, string) {
+		return &ast.CallExpr{Fun: reference, Args: args}, ""
+	})
+}
+
+// isTypeReference tests if the target of a method reference is a class,
+// instead of a specific object, such as the `Foo` in `Foo::new`, `Math::max`,
+// or `String::length`
+func isTypeReference(target *sitter.Node, source []byte, ctx Ctx) bool {
+	switch target.Type() {
+	case "this", "super", "field_access", "method_invocation", "parenthesized_expression":
+		return false
+	}
+	return target.Type() != "identifier" || findVariable(target, source, ctx) == nil
+}
+
+// boundMethodReference translates a reference to the method of a specific
+// object, such as `this::handle` or `list::add`
+//
+// The object is evaluated when the reference is created, the same as a Go
+// method value, so references to methods with the same types as the
+// functional interface are translated as method values
+func boundMethodReference(target *sitter.Node, methodName string, source []byte, ctx Ctx, signature *symbol.FunctionalSignature) ast.Expr {
+	object := ParseExpr(target, source, ctx)
+	objectType := ExpressionType(target, source, ctx)
+
+	var method *symbol.Definition
+	if class := classOfExpression(target, source, ctx); class != nil {
+		method = findReferencedMethod(class, methodName, referenceArguments(signature, 0), false)
+	}
+
+	if signature == nil {
+		name := methodName
+		if method != nil {
+			name = method.Name
+		}
+		return &ast.SelectorExpr{X: object, Sel: &ast.Ident{Name: name}}
+	} else if method != nil && sameSignature(method, signature) {
+		return &ast.SelectorExpr{X: object, Sel: &ast.Ident{Name: method.Name}}
+	}
+
+	// A closure evaluates the object every time that it is called, so if the
+	// object could change, it is evaluated once and passed in
+	receiver := object
+	goType := ctx.currentFile.GoType(objectType)
+	snapshot := !isCapturedValue(target, source, ctx) && objectType != "" && goType != ""
+	if snapshot {
+		receiver = &ast.Ident{Name: "receiver"}
+	}
+
+	var closure *ast.FuncLit
+	switch {
+	case objectType == "String" && stringMethodTypes[methodName] != "":
+		closure = referenceClosure(ctx, signature, func(args []ast.Expr) (ast.Expr, string) {
+			arguments, types := unboxedArguments(args, signature.Parameters)
+			return StringMethod(receiver, methodName, arguments, types), stringMethodTypes[methodName]
+		})
+	case method != nil:
+		closure = referenceClosure(ctx, signature, func(args []ast.Expr) (ast.Expr, string) {
+			return &ast.CallExpr{
+				Fun:  &ast.SelectorExpr{X: receiver, Sel: &ast.Ident{Name: method.Name}},
+				Args: convertArguments(args, signature.Parameters, method),
+			}, method.OriginalType
+		})
+	default:
+		// Methods of unknown types are called as they are written
+		closure = referenceClosure(ctx, signature, func(args []ast.Expr) (ast.Expr, string) {
+			return &ast.CallExpr{Fun: &ast.SelectorExpr{X: receiver, Sel: &ast.Ident{Name: methodName}}, Args: args}, ""
+		})
+	}
+
+	if !snapshot {
+		return closure
+	}
+	return &ast.CallExpr{
+		Fun: &ast.FuncLit{
+			Type: &ast.FuncType{
+				Params: &ast.FieldList{List: []*ast.Field{
+					&ast.Field{Names: []*ast.Ident{receiver.(*ast.Ident)}, Type: &ast.Ident{Name: goType}},
+				}},
+				Results: &ast.FieldList{List: []*ast.Field{&ast.Field{Type: closure.Type}}},
+			},
+			Body: &ast.BlockStmt{List: []ast.Stmt{&ast.ReturnStmt{Results: []ast.Expr{closure}}}},
+		},
+		Args: []ast.Expr{object},
+	}
+}
+
+// isCapturedValue tests if an expression always has the same value, so that
+// it can be captured by a closure instead of being evaluated beforehand
+//
+// This is true for `this`, and for local variables, since Java only allows
+// lambdas to use them if they are effectively final
+func isCapturedValue(node *sitter.Node, source []byte, ctx Ctx) bool {
+	switch node.Type() {
+	case "this":
+		return true
+	case "identifier":
+		return ctx.localScope != nil && ctx.localScope.FindVariableAt(node.Content(source), node.StartByte()) != nil
+	}
+	return false
+}
+
+// findReferencedMethod finds the method that a method reference refers to,
+// which is either a static method, or an instance method, with parameters
+// that can take the given argument types
+//
+// If the argument types are nil, then the first method with the name is used
+func findReferencedMethod(class *symbol.ClassScope, name string, argumentTypes []string, static bool) *symbol.Definition {
+	var found *symbol.Definition
+	for _, method := range class.Methods {
+		if method.OriginalName != name || method.Static != static {
+			continue
+		}
+		if argumentTypes == nil {
+			return method
+		}
+		if len(method.Parameters) != len(argumentTypes) {
+			continue
+		}
+
+		exact := true
+		for ind, param := range method.Parameters {
+			if symbol.UnboxedType(param.OriginalType) != symbol.UnboxedType(argumentTypes[ind]) {
+				exact = false
+			}
+		}
+		if exact {
+			return method
+		} else if found == nil {
+			// The arguments may still be converted into the parameters' types
+			found = method
+		}
+	}
+	return found
+}
+
+// referenceArguments returns the types of the arguments that a method
+// reference passes to its method, skipping the given number of arguments that
+// are not passed as arguments, such as the receiver of an unbound method
+//
+// Returns nil if the functional interface is not known
+func referenceArguments(signature *symbol.FunctionalSignature, skip int) []string {
+	if signature == nil {
+		return nil
+	}
+	return append([]string{}, signature.Parameters[skip:]...)
+}
+
+// sameSignature tests if a method has the exact same Go types as the method of
+// a functional interface, so that it can be used in its place
+func sameSignature(method *symbol.Definition, signature *symbol.FunctionalSignature) bool {
+	if len(method.Parameters) != len(signature.Parameters) {
+		return false
+	}
+	for ind, param := range method.Parameters {
+		if param.OriginalType != signature.Parameters[ind] {
+			return false
+		}
+	}
+	return method.OriginalType == signature.Result
+}
+
+// convertArguments converts the arguments of a functional interface's method
+// into the types of the parameters of the method that they are passed to
+func convertArguments(args []ast.Expr, argumentTypes []string, method *symbol.Definition) []ast.Expr {
+	converted := make([]ast.Expr, len(args))
+	for ind, arg := range args {
+		converted[ind] = arg
+		if ind < len(method.Parameters) {
+			converted[ind] = convertValue(arg, argumentTypes[ind], method.Parameters[ind].OriginalType)
+		}
+	}
+	return converted
+}
+
+// unboxedArguments unboxes the arguments of a functional interface's method
+// that are boxes, for the methods that only take primitive values, such as
+// the methods of strings, and returns them along with their new Java types
+func unboxedArguments(args []ast.Expr, argumentTypes []string) ([]ast.Expr, []string) {
+	unboxed, types := make([]ast.Expr, len(args)), make([]string, len(args))
+	for ind, arg := range args {
+		types[ind] = symbol.UnboxedType(argumentTypes[ind])
+		unboxed[ind] = convertValue(arg, argumentTypes[ind], types[ind])
+	}
+	return unboxed, types
+}
+
+// convertValue converts a value between two Java types, which can be a wider
+// primitive type, the primitive type of a box, or the box of a primitive value
+func convertValue(value ast.Expr, from, to string) ast.Expr {
+	switch {
+	case isBoxedType(from) && isBoxedType(to):
+		return value
+	case isBoxedType(from):
+		value, from = UnboxingConversion(value, to), symbol.UnboxedType(from)
+	case isBoxedType(to) && isPrimitive(from):
+		return boxValue(convertValue(value, from, symbol.UnboxedType(to)), from, to)
+	}
+	if isPrimitive(from) && isPrimitive(to) && primitiveTypes[from] != primitiveTypes[to] {
+		return ConvertPrimitive(value, from, to)
+	}
+	return value
+}
+
+// referenceClosure generates a function with the types of a functional
+// interface, which calls the referenced method with the function's arguments
+//
+// The call returns the expression that calls the method, as well as the Java
+// type that the method returns
+func referenceClosure(ctx Ctx, signature *symbol.FunctionalSignature, call func(args []ast.Expr) (ast.Expr, string)) *ast.FuncLit {
+	params := &ast.FieldList{}
+	args := make([]ast.Expr, len(signature.Parameters))
+	for ind, paramType := range signature.ParameterTypes(ctx.currentFile) {
+		name := &ast.Ident{Name: "arg" + strconv.Itoa(ind)}
+		params.List = append(params.List, &ast.Field{Names: []*ast.Ident{name}, Type: &ast.Ident{Name: paramType}})
+		args[ind] = name
+	}
+
+	funcType := &ast.FuncType{Params: params}
+	result, resultType := call(args)
+
+	var body ast.Stmt = &ast.ExprStmt{X: result}
+	if goType := signature.ResultType(ctx.currentFile); goType != "" {
+		funcType.Results = &ast.FieldList{List: []*ast.Field{&ast.Field{Type: &ast.Ident{Name: goType}}}}
+		body = &ast.ReturnStmt{Results: []ast.Expr{convertValue(result, resultType, signature.Result)}}
+	}
+
+	return &ast.FuncLit{Type: funcType, Body: &ast.BlockStmt{List: []ast.Stmt{body}}}
+}
+
+// functionResultType returns the Java type that a lambda expression or a
+// method reference returns when it is called with arguments of the given
+// types, which Java uses to infer type arguments, such as the `R` of
+// `Stream.map`
+//
+// Returns an empty string if the type could not be determined
+func functionResultType(node *sitter.Node, source []byte, ctx Ctx, parameters []string) string {
+	for node.Type() == "parenthesized_expression" {
+		node = node.NamedChild(0)
+	}
+
+	switch node.Type() {
+	case "lambda_expression":
+		for ind, param := range lambdaParameters(node.ChildByFieldName("parameters")) {
+			if param.Type() != "identifier" || ind >= len(parameters) || ctx.localScope == nil {
+				continue
+			}
+			// The parameters get the same types as when the lambda is translated
+			if def := ctx.localScope.FindVariableAt(param.Content(source), param.StartByte()); def != nil {
+				def.OriginalType, def.Type = parameters[ind], ctx.currentFile.GoType(parameters[ind])
+			}
+		}
+		body := node.ChildByFieldName("body")
+		if body.Type() == "block" {
+			if body = returnedValue(body); body == nil {
+				return ""
+			}
+		}
+		return ExpressionType(body, source, ctx)
+	case "method_reference":
+		return referenceResultType(node, source, ctx, parameters)
+	}
+
+	// Otherwise, the function is the value of a functional interface
+	if signature := ctx.currentFile.FunctionalSignatureOf(ExpressionType(node, source, ctx)); signature != nil {
+		return signature.Result
+	}
+	return ""
+}
+
+// returnedValue returns the first value that is returned from a block, without
+// looking into any lambdas or classes in it, or nil if it doesn't return one
+func returnedValue(node *sitter.Node) *sitter.Node {
+	switch node.Type() {
+	case "return_statement":
+		return node.NamedChild(0)
+	case "lambda_expression", "class_body":
+		return nil
+	}
+	for _, child := range nodeutil.NamedChildrenOf(node) {
+		if value := returnedValue(child); value != nil {
+			return value
+		}
+	}
+	return nil
+}
+
+// referenceResultType returns the Java type that the method of a method
+// reference returns when it is called with arguments of the given types, or
+// an empty string if it isn't known
+func referenceResultType(node *sitter.Node, source []byte, ctx Ctx, parameters []string) string {
+	target := node.NamedChild(0)
+	// Constructor references create the class
+	if node.NamedChildCount() < 2 {
+		return target.Content(source)
+	}
+	methodName := node.NamedChild(1).Content(source)
+
+	if !isTypeReference(target, source, ctx) {
+		if ExpressionType(target, source, ctx) == "String" {
+			return stringMethodTypes[methodName]
+		}
+		if class := classOfExpression(target, source, ctx); class != nil {
+			if method := findReferencedMethod(class, methodName, parameters, false); method != nil {
+				return method.OriginalType
+			}
+		}
+		return ""
+	}
+
+	if className := wrapperClass(target, source, ctx); className != "" {
+		if result := wrapperStaticType(className, methodName); result != "" {
+			return result
+		}
+		return wrapperMethodTypes[methodName]
+	}
+	if isMathClass(target, source, ctx) {
+		return mathResultType(methodName, parameters)
+	}
+
+	typeName := symbol.BaseTypeName(target.Content(source))
+	if typeName == "String" {
+		if result, static := stringStaticTypes[methodName]; static {
+			return result
+		}
+		return stringMethodTypes[methodName]
+	}
+	if class := findClassScope(typeName, ctx); class != nil {
+		if method := findReferencedMethod(class, methodName, parameters, true); method != nil {
+			return method.OriginalType
+		}
+		if len(parameters) > 0 {
+			if method := findReferencedMethod(class, methodName, parameters[1:], false); method != nil {
+				return method.OriginalType
+			}
+		}
+	}
+	return ""
+}
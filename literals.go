@@ -0,0 +1,266 @@
+package main
+
+import (
+	"go/ast"
+	"go/token"
+	"strconv"
+	"strings"
+	"unicode/utf16"
+	"unicode/utf8"
+)
+
+// unescapeJava processes the escape sequences in the contents of a Java string
+// or character literal, and returns the UTF-16 code units that it represents
+//
+// This includes octal escapes such as `\7`, unicode escapes such as `\u0041`,
+// and the `\s` escape for a space
+func unescapeJava(content string) []uint16 {
+	var units []uint16
+
+	for ind := 0; ind < len(content); {
+		if content[ind] != '\\' || ind+1 >= len(content) {
+			char, size := utf8.DecodeRuneInString(content[ind:])
+			units = append(units, utf16.Encode([]rune{char})...)
+			ind += size
+			continue
+		}
+
+		ind++
+		switch escape := content[ind]; escape {
+		case 'b':
+			units = append(units, '\b')
+		case 't':
+			units = append(units, '\t')
+		case 'n':
+			units = append(units, '\n')
+		case 'f':
+			units = append(units, '\f')
+		case 'r':
+			units = append(units, '\r')
+		case 's':
+			units = append(units, ' ')
+		case '\n':
+			// A backslash at the end of a line in a text block joins it to the next line
+		case 'u':
+			// Unicode escapes can have any number of `u`s, such as `\uuu0041`
+			for ind < len(content) && content[ind] == 'u' {
+				ind++
+			}
+			if ind+4 <= len(content) {
+				if value, err := strconv.ParseUint(content[ind:ind+4], 16, 16); err == nil {
+					units = append(units, uint16(value))
+					ind += 4
+					continue
+				}
+			}
+			units = append(units, 'u')
+			continue
+		case '0', '1', '2', '3', '4', '5', '6', '7':
+			// Octal escapes have up to three digits, but can't be larger than `\377`
+			length := 1
+			for length < 3 && ind+length < len(content) && content[ind+length] >= '0' && content[ind+length] <= '7' {
+				length++
+			}
+			if length == 3 && escape > '3' {
+				length = 2
+			}
+			value, _ := strconv.ParseUint(content[ind:ind+length], 8, 16)
+			units = append(units, uint16(value))
+			ind += length
+			continue
+		default:
+			// Quotes and backslashes escape themselves
+			units = append(units, uint16(escape))
+		}
+		ind++
+	}
+
+	return units
+}
+
+// StringLiteral converts the contents of a Java string, which has already had
+// its quotes removed, into a Go string literal
+func StringLiteral(content string) *ast.BasicLit {
+	// Go's strings are UTF-8, so any surrogate pairs in the Java string are
+	// combined into a single character
+	value := string(utf16.Decode(unescapeJava(content)))
+	return &ast.BasicLit{Kind: token.STRING, Value: strconv.Quote(value)}
+}
+
+// TextBlock converts a Java text block, including its delimiters, into a Go
+// string literal
+//
+// The incidental indentation of the block, as well as any trailing spaces, are
+// removed before its escape sequences are processed. The result is written as
+// a raw string where possible, so that it keeps its original formatting
+func TextBlock(literal string) *ast.BasicLit {
+	content := translateUnicodeEscapes(strings.TrimSuffix(strings.TrimPrefix(literal, `"""`), `"""`))
+	content = strings.ReplaceAll(strings.ReplaceAll(content, "\r\n", "\n"), "\r", "\n")
+
+	// The content starts on the line after the opening delimiter
+	_, content, _ = strings.Cut(content, "\n")
+	lines := strings.Split(content, "\n")
+
+	// The indentation that is removed is the smallest indentation of any line,
+	// where blank lines are ignored, except for the line of the closing delimiter
+	indent := -1
+	for ind, line := range lines {
+		if isBlank(line) && ind != len(lines)-1 {
+			continue
+		}
+		if leading := len(line) - len(strings.TrimLeft(line, " \t\f")); indent == -1 || leading < indent {
+			indent = leading
+		}
+	}
+
+	for ind, line := range lines {
+		if isBlank(line) {
+			lines[ind] = ""
+		} else {
+			lines[ind] = strings.TrimRight(line[indent:], " \t\f")
+		}
+	}
+
+	value := string(utf16.Decode(unescapeJava(strings.Join(lines, "\n"))))
+
+	for _, line := range strings.Split(value, "\n") {
+		if !strconv.CanBackquote(line) {
+			return &ast.BasicLit{Kind: token.STRING, Value: strconv.Quote(value)}
+		}
+	}
+	return &ast.BasicLit{Kind: token.STRING, Value: "`" + value + "`"}
+}
+
+// translateUnicodeEscapes replaces any unicode escapes for ASCII characters,
+// such as `\u005C`, with the characters that they represent, since Java
+// translates them before a text block is processed, so they can form other
+// escape sequences, or be stripped as whitespace
+func translateUnicodeEscapes(content string) string {
+	var translated strings.Builder
+
+	// The number of backslashes directly before the current character
+	var backslashes int
+	for ind := 0; ind < len(content); ind++ {
+		if content[ind] == '\\' && backslashes%2 == 0 {
+			// Unicode escapes can have any number of `u`s
+			end := ind + 1
+			for end < len(content) && content[end] == 'u' {
+				end++
+			}
+			if end > ind+1 && end+4 <= len(content) {
+				if value, err := strconv.ParseUint(content[end:end+4], 16, 16); err == nil && value < utf8.RuneSelf {
+					translated.WriteByte(byte(value))
+					ind = end + 3
+					backslashes = 0
+					continue
+				}
+			}
+		}
+
+		if content[ind] == '\\' {
+			backslashes++
+		} else {
+			backslashes = 0
+		}
+		translated.WriteByte(content[ind])
+	}
+
+	return translated.String()
+}
+
+// isBlank tests if a line in a text block only contains whitespace
+func isBlank(line string) bool {
+	return strings.Trim(line, " \t\f") == ""
+}
+
+// CharLiteral converts a Java character literal, including its quotes, into an
+// equivalent Go literal
+func CharLiteral(literal string) *ast.BasicLit {
+	units := unescapeJava(strings.TrimSuffix(strings.TrimPrefix(literal, "'"), "'"))
+	if len(units) == 0 {
+		return &ast.BasicLit{Kind: token.CHAR, Value: literal}
+	}
+
+	// A single half of a surrogate pair is a valid Java char, but not a valid Go
+	// rune, so it is written as a number instead
+	if utf16.IsSurrogate(rune(units[0])) {
+		return &ast.BasicLit{Kind: token.INT, Value: "0x" + strings.ToUpper(strconv.FormatUint(uint64(units[0]), 16))}
+	}
+	return &ast.BasicLit{Kind: token.CHAR, Value: strconv.QuoteRune(rune(units[0]))}
+}
+
+// IntegerLiteral converts a Java integer literal in any base into a Go literal
+// of the same value, and negates it if it is the operand of a negation, since
+// values such as `-2147483648` can only be written when they are negated
+//
+// Java's hex, octal, and binary literals represent all the bits of an int or a
+// long, so literals such as `0xFFFFFFFF` are converted to negative numbers
+func IntegerLiteral(literal string, negated bool) ast.Expr {
+	digits := strings.ReplaceAll(literal, "_", "")
+	long := strings.HasSuffix(digits, "L") || strings.HasSuffix(digits, "l")
+	digits = strings.TrimRight(digits, "Ll")
+
+	var prefix string
+	base := 10
+	switch lower := strings.ToLower(digits); {
+	case strings.HasPrefix(lower, "0x"):
+		prefix, base, digits = "0x", 16, lower[2:]
+	case strings.HasPrefix(lower, "0b"):
+		prefix, base, digits = "0b", 2, lower[2:]
+	case len(lower) > 1 && lower[0] == '0':
+		// Java's octal literals only start with a zero, but Go's are clearer with `0o`
+		prefix, base, digits = "0o", 8, lower[1:]
+	}
+
+	value, _ := strconv.ParseUint(digits, base, 64)
+
+	// Any bits past the sign bit make the number negative, and negating it wraps
+	// around like any other negation, so `-0x80000000` stays the smallest int
+	if base != 10 {
+		signed := int64(value)
+		if !long {
+			signed = int64(int32(uint32(value)))
+		}
+		if negated {
+			signed = -signed
+			if !long {
+				signed = int64(int32(signed))
+			}
+		}
+		negated = signed < 0
+		if negated {
+			value = uint64(-signed)
+		} else {
+			value = uint64(signed)
+		}
+	}
+
+	var result ast.Expr = &ast.BasicLit{Kind: token.INT, Value: prefix + strings.ToUpper(strconv.FormatUint(value, base))}
+	if negated {
+		result = &ast.UnaryExpr{Op: token.SUB, X: result}
+	}
+
+	if long {
+		return callFunc("int64", result)
+	}
+	return result
+}
+
+// FloatLiteral converts a Java floating point literal, either in decimal or in
+// hex, into a Go literal of the same type
+func FloatLiteral(literal string) ast.Expr {
+	digits := strings.ReplaceAll(literal, "_", "")
+
+	var conversion string
+	switch digits[len(digits)-1] {
+	case 'F', 'f':
+		conversion = "float32"
+	case 'D', 'd':
+		conversion = "float64"
+	}
+
+	if conversion == "" {
+		return &ast.BasicLit{Kind: token.FLOAT, Value: digits}
+	}
+	return callFunc(conversion, &ast.BasicLit{Kind: token.FLOAT, Value: digits[:len(digits)-1]})
+}
@@ -0,0 +1,465 @@
+package main
+
+import (
+	"go/ast"
+	"go/token"
+
+	"github.com/NickyBoy89/java2go/symbol"
+	log "github.com/sirupsen/logrus"
+	sitter "github.com/smacker/go-tree-sitter"
+)
+
+// With `-lower-streams`, simple stream pipelines are lowered into plain loops,
+// where each lambda's parameter is declared as a variable in the loop's body,
+// and the lambda's body is run in place
+//
+// A pipeline is simple if it streams a collection, an array, or a range of
+// ints, only filters and maps its elements with lambdas that take a single
+// parameter and return an expression, and then runs an action for each
+// element, counts them, sums them, or collects them into a list or a set
+
+// The operations between the source of a lowered pipeline and its terminal
+// operation
+var loweredOperations = map[string]bool{
+	"filter":   true,
+	"map":      true,
+	"mapToInt": true,
+	"mapToObj": true,
+}
+
+// loweredStage is one of the operations of a pipeline that is lowered
+type loweredStage struct {
+	// The Java name of the operation, where the collectors are named after
+	// their collections, such as `toList`
+	name string
+	node *sitter.Node
+	// The Java type of the elements that the operation is given
+	element string
+	// The lambda that the operation is given, and its parameter, which are nil
+	// if it isn't given one
+	lambda    *sitter.Node
+	parameter *symbol.Definition
+}
+
+// loweredPipeline is a stream pipeline that can be lowered into a loop
+type loweredPipeline struct {
+	// The method invocation that creates the stream, and the function that it
+	// is translated into
+	source   *sitter.Node
+	function string
+	// The operations of the pipeline, where the last one is the terminal
+	// operation
+	stages []loweredStage
+}
+
+// loweredPipelineOf returns the pipeline that ends with the given terminal
+// operation, or nil if it isn't a pipeline that can be lowered
+func loweredPipelineOf(node *sitter.Node, source []byte, ctx Ctx) *loweredPipeline {
+	if node.Type() != "method_invocation" || ctx.localScope == nil || ctx.hoisted == nil {
+		return nil
+	}
+
+	pipeline := &loweredPipeline{}
+	for pipeline.source == nil {
+		if function, _ := streamSource(node, source, ctx); function != "" {
+			arguments := node.ChildByFieldName("arguments").NamedChildCount()
+			if len(pipeline.stages) == 0 || function == "Of" || function == "Ints" || function == "RangeClosed" || (function != "OfIterable" && function != "Range" && arguments != 1) {
+				return nil
+			}
+			pipeline.source, pipeline.function = node, function
+			break
+		}
+
+		stream, element, operation := streamCall(node, source, ctx)
+		if operation == nil {
+			return nil
+		}
+		stage := loweredStage{name: node.ChildByFieldName("name").Content(source), node: node, element: element}
+		argument := node.ChildByFieldName("arguments").NamedChild(0)
+
+		if len(pipeline.stages) == 0 {
+			switch {
+			case stage.name == "forEach":
+				if stage.lambda, stage.parameter = simpleLambda(argument, source, ctx, true); stage.lambda == nil {
+					return nil
+				}
+			case stage.name == "collect":
+				if stage.name = loweredCollector(argument, source, ctx); stage.name == "" {
+					return nil
+				}
+			case stage.name == "toList" && stream == "Stream", stage.name == "sum", stage.name == "count":
+			default:
+				return nil
+			}
+		} else if loweredOperations[stage.name] {
+			if stage.lambda, stage.parameter = simpleLambda(argument, source, ctx, false); stage.lambda == nil {
+				return nil
+			}
+		} else {
+			return nil
+		}
+
+		pipeline.stages = append([]loweredStage{stage}, pipeline.stages...)
+		if node = node.ChildByFieldName("object"); node.Type() != "method_invocation" {
+			return nil
+		}
+	}
+
+	// The parameters of the lambdas are declared in the same loop, so the same
+	// name can only be used again for elements of the same type
+	declared := make(map[string]string)
+	for _, stage := range pipeline.stages {
+		if stage.parameter == nil {
+			continue
+		}
+		goType := ctx.currentFile.GoType(stage.element)
+		if previous, found := declared[stage.parameter.Name]; found && previous != goType {
+			return nil
+		}
+		declared[stage.parameter.Name] = goType
+	}
+	return pipeline
+}
+
+// simpleLambda returns a lambda that takes a single parameter, and the
+// definition of its parameter, or nil if the expression isn't one
+//
+// The lambda's body has to be an expression, unless blocks are allowed, in
+// which case the block can't return from the lambda
+func simpleLambda(node *sitter.Node, source []byte, ctx Ctx, allowBlock bool) (*sitter.Node, *symbol.Definition) {
+	for node != nil && node.Type() == "parenthesized_expression" {
+		node = node.NamedChild(0)
+	}
+	if node == nil || node.Type() != "lambda_expression" {
+		return nil, nil
+	}
+	parameters := lambdaParameters(node.ChildByFieldName("parameters"))
+	if len(parameters) != 1 || parameters[0].Type() != "identifier" {
+		return nil, nil
+	}
+	if body := node.ChildByFieldName("body"); body.Type() == "block" && (!allowBlock || containsReturn(body)) {
+		return nil, nil
+	}
+	def := ctx.localScope.FindVariableAt(parameters[0].Content(source), parameters[0].StartByte())
+	if def == nil {
+		return nil, nil
+	}
+	return node, def
+}
+
+// loweredCollector returns the name of the collection that a collector collects
+// the elements of a lowered pipeline into, or an empty string if it isn't a
+// collector that can be lowered
+func loweredCollector(node *sitter.Node, source []byte, ctx Ctx) string {
+	if node.Type() != "method_invocation" || node.ChildByFieldName("arguments").NamedChildCount() > 0 || !isStandardReference(node.ChildByFieldName("object"), "Collectors", "java.util.stream", source, ctx) {
+		return ""
+	}
+	switch name := node.ChildByFieldName("name").Content(source); name {
+	case "toList", "toSet":
+		return name
+	}
+	return ""
+}
+
+// isLoweredPosition tests if the result of a pipeline is used somewhere that
+// the pipeline's loop can run before, without changing when anything else is
+// evaluated, which is as the initial value of a variable, the value of an
+// assignment to a variable, or the value that is returned
+func isLoweredPosition(node *sitter.Node) bool {
+	switch parent := node.Parent(); parent.Type() {
+	case "variable_declarator":
+		return parent.Parent().Type() == "local_variable_declaration"
+	case "return_statement":
+		return true
+	case "assignment_expression":
+		return parent.Child(1).Type() == "=" && parent.ChildByFieldName("left").Type() == "identifier" && parent.Parent().Type() == "expression_statement"
+	}
+	return false
+}
+
+// LowerStream lowers a pipeline whose result is used into a loop, which runs
+// before the statement that uses it, and returns the variable that the result
+// is stored in
+//
+// Returns nil if the pipeline can't be lowered
+func LowerStream(node *sitter.Node, source []byte, ctx Ctx) ast.Expr {
+	if !isLoweredPosition(node) {
+		return nil
+	}
+	pipeline := loweredPipelineOf(node, source, ctx)
+	if pipeline == nil || pipeline.stages[len(pipeline.stages)-1].name == "forEach" || !pipeline.enabled(ctx) {
+		return nil
+	}
+	loop, result := pipeline.lower(source, ctx)
+	ctx.hoist(loop)
+	return result
+}
+
+// LoweredStreamStmt lowers a pipeline that runs an action for each element,
+// which is used as a statement, into a loop
+//
+// Returns nil if the statement isn't a pipeline that can be lowered
+func LoweredStreamStmt(node *sitter.Node, source []byte, ctx Ctx) ast.Stmt {
+	pipeline := loweredPipelineOf(node, source, ctx)
+	if pipeline == nil || pipeline.stages[len(pipeline.stages)-1].name != "forEach" || !pipeline.enabled(ctx) {
+		return nil
+	}
+	loop, _ := pipeline.lower(source, ctx)
+	return loop
+}
+
+// enabled tests if pipelines are lowered, and reports the ones that could be
+// if they aren't
+func (pipeline *loweredPipeline) enabled(ctx Ctx) bool {
+	if !lowerStreams {
+		log.WithFields(log.Fields{
+			"className": ctx.className,
+			"operation": pipeline.stages[len(pipeline.stages)-1].name,
+		}).Info("Stream pipeline can be lowered into a loop with -lower-streams")
+	}
+	return lowerStreams
+}
+
+// lower translates the pipeline into a loop, along with the variable that the
+// pipeline's result is stored in, which is nil for `forEach`
+func (pipeline *loweredPipeline) lower(source []byte, ctx Ctx) (ast.Stmt, *ast.Ident) {
+	if ctx.temporaries == nil {
+		ctx.temporaries = new(int)
+	}
+	terminal := pipeline.stages[len(pipeline.stages)-1]
+	goElement := ctx.currentFile.GoType(terminal.element)
+
+	// The result is declared with the type that the terminal operation returns
+	var result *ast.Ident
+	declare := func(javaType string, value ast.Expr) {
+		result = ctx.temporaryName()
+		spec := &ast.ValueSpec{Names: []*ast.Ident{result}, Type: &ast.Ident{Name: ctx.currentFile.GoType(javaType)}}
+		if value != nil {
+			spec.Values = []ast.Expr{value}
+		}
+		ctx.hoist(&ast.DeclStmt{Decl: &ast.GenDecl{Tok: token.VAR, Specs: []ast.Spec{spec}}})
+	}
+	switch terminal.name {
+	case "count":
+		declare("long", nil)
+	case "sum":
+		declare("int", nil)
+	case "toList":
+		declare("List<"+terminal.element+">", &ast.CallExpr{Fun: &ast.IndexExpr{X: stdjavaType("NewArrayList"), Index: &ast.Ident{Name: goElement}}})
+	case "toSet":
+		declare("Set<"+terminal.element+">", &ast.CallExpr{Fun: &ast.IndexExpr{X: stdjavaType("NewHashSet"), Index: &ast.Ident{Name: goElement}}})
+	}
+
+	// The elements are stored in the first lambda's parameter, unless it could
+	// be changed by the lambda, or captured by another lambda
+	first := pipeline.stages[0]
+	variable := func(counter bool) *ast.Ident {
+		if first.parameter == nil || first.parameter.Captured || hoistsStatements(first.lambda.ChildByFieldName("body")) {
+			return ctx.temporaryName()
+		}
+		// A counter can't be assigned to by any of the other lambdas either
+		for _, stage := range pipeline.stages[1:] {
+			if counter && stage.parameter != nil && stage.parameter.Name == first.parameter.Name {
+				return ctx.temporaryName()
+			}
+		}
+		return &ast.Ident{Name: first.parameter.Name}
+	}
+
+	var loop ast.Stmt
+	var value ast.Expr
+	ev := ctx.evaluation()
+	switch pipeline.function {
+	case "OfIterable":
+		collection := ev.parse(pipeline.source.ChildByFieldName("object"), source, identity)
+		it := ctx.temporaryName()
+		loop = &ast.ForStmt{
+			Init: &ast.AssignStmt{Lhs: []ast.Expr{it}, Tok: token.DEFINE, Rhs: []ast.Expr{callMethod(*collection, "Iterator")}},
+			Cond: callMethod(it, "HasNext"),
+		}
+		value = callMethod(it, "Next")
+	case "OfSlice", "IntsOfSlice":
+		array := ev.parse(pipeline.source.ChildByFieldName("arguments").NamedChild(0), source, identity)
+		element := variable(false)
+		loop = &ast.RangeStmt{Key: &ast.Ident{Name: "_"}, Value: element, Tok: token.DEFINE, X: *array}
+		value = element
+	case "Range":
+		arguments := pipeline.source.ChildByFieldName("arguments")
+		start := ev.parse(arguments.NamedChild(0), source, func(value ast.Expr) ast.Expr {
+			return DeclarationConversion(value, arguments.NamedChild(0), source, ctx, "int")
+		})
+		end := parseStreamArgument(ev, arguments.NamedChild(1), source, "int")
+		// The end of the range is only evaluated once
+		if _, isVariable := (*end).(*ast.Ident); !isVariable && !isConstant(*end) {
+			*end = ctx.temporary(*end)
+		}
+		counter := variable(true)
+		loop = &ast.ForStmt{
+			Init: &ast.AssignStmt{Lhs: []ast.Expr{counter}, Tok: token.DEFINE, Rhs: []ast.Expr{*start}},
+			Cond: &ast.BinaryExpr{X: counter, Op: token.LSS, Y: *end},
+			Post: &ast.IncDecStmt{X: counter, Tok: token.INC},
+		}
+		value = counter
+	}
+
+	// Anything that the lambdas hoist runs in the loop's body
+	body := []ast.Stmt{}
+	ctx.hoisted = &body
+	declared := make(map[string]bool)
+	if element, isVariable := value.(*ast.Ident); isVariable {
+		declared[element.Name] = true
+	}
+
+	for _, stage := range pipeline.stages {
+		var lambdaBody *sitter.Node
+		if stage.parameter != nil {
+			lambdaBody = stage.lambda.ChildByFieldName("body")
+			// The parameter gets its type the same way as when a lambda is translated
+			stage.parameter.OriginalType, stage.parameter.Type = stage.element, ctx.currentFile.GoType(stage.element)
+			if element, isVariable := value.(*ast.Ident); !isVariable || element.Name != stage.parameter.Name {
+				tok := token.DEFINE
+				if declared[stage.parameter.Name] {
+					tok = token.ASSIGN
+				}
+				body = append(body, &ast.AssignStmt{Lhs: []ast.Expr{&ast.Ident{Name: stage.parameter.Name}}, Tok: tok, Rhs: []ast.Expr{value}})
+				declared[stage.parameter.Name] = true
+			}
+			value = &ast.Ident{Name: stage.parameter.Name}
+		}
+
+		switch stage.name {
+		case "filter":
+			cond := ParseExpr(lambdaBody, source, ctx)
+			body = append(body, &ast.IfStmt{
+				Cond: &ast.UnaryExpr{Op: token.NOT, X: &ast.ParenExpr{X: cond}},
+				Body: &ast.BlockStmt{List: []ast.Stmt{&ast.BranchStmt{Tok: token.CONTINUE}}},
+			})
+		case "map", "mapToInt", "mapToObj":
+			mapped := streamElement(ExpressionType(stage.node, source, ctx))
+			value = AssignmentConversion(ParseExpr(lambdaBody, source, ctx), lambdaBody, source, ctx, mapped)
+		case "forEach":
+			if lambdaBody.Type() != "block" {
+				stmt := TryParseStmt(lambdaBody, source, ctx)
+				if stmt == nil {
+					stmt = &ast.ExprStmt{X: ParseExpr(lambdaBody, source, ctx)}
+				}
+				body = append(body, stmt)
+				break
+			}
+			block := ParseStmt(lambdaBody, source, ctx).(*ast.BlockStmt)
+			// The block's variables can't be declared in the loop's body if any of
+			// the lambdas' parameters have the same names
+			if declaresAny(block.List, declared) {
+				body = append(body, block)
+			} else {
+				body = append(body, block.List...)
+			}
+		case "count":
+			body = append(body, discard(value)...)
+			body = append(body, &ast.IncDecStmt{X: result, Tok: token.INC})
+		case "sum":
+			body = append(body, &ast.AssignStmt{Lhs: []ast.Expr{result}, Tok: token.ADD_ASSIGN, Rhs: []ast.Expr{value}})
+		case "toList", "toSet":
+			body = append(body, &ast.ExprStmt{X: callMethod(result, "Add", value)})
+		}
+	}
+
+	body = removeUnused(body, declared)
+	switch loop := loop.(type) {
+	case *ast.ForStmt:
+		loop.Body = &ast.BlockStmt{List: body}
+	case *ast.RangeStmt:
+		loop.Body = &ast.BlockStmt{List: body}
+		if element := loop.Value.(*ast.Ident); !usesName(body, element.Name) {
+			loop.Key, loop.Value, loop.Tok = nil, nil, token.ILLEGAL
+		}
+	}
+	return loop, result
+}
+
+// discard evaluates a value that isn't used, which is left out if evaluating
+// it has no effect
+func discard(value ast.Expr) []ast.Stmt {
+	switch value := value.(type) {
+	case *ast.Ident, *ast.BasicLit:
+		return nil
+	case *ast.CallExpr:
+		return []ast.Stmt{&ast.ExprStmt{X: value}}
+	}
+	return []ast.Stmt{&ast.AssignStmt{Lhs: []ast.Expr{&ast.Ident{Name: "_"}}, Tok: token.ASSIGN, Rhs: []ast.Expr{value}}}
+}
+
+// declaresAny tests if any of the statements declare a variable with one of
+// the given names
+func declaresAny(stmts []ast.Stmt, names map[string]bool) bool {
+	for _, stmt := range stmts {
+		switch stmt := stmt.(type) {
+		case *ast.AssignStmt:
+			for _, target := range stmt.Lhs {
+				if ident, ok := target.(*ast.Ident); ok && stmt.Tok == token.DEFINE && names[ident.Name] {
+					return true
+				}
+			}
+		case *ast.DeclStmt:
+			for _, spec := range stmt.Decl.(*ast.GenDecl).Specs {
+				if value, ok := spec.(*ast.ValueSpec); ok {
+					for _, name := range value.Names {
+						if names[name.Name] {
+							return true
+						}
+					}
+				}
+			}
+		}
+	}
+	return false
+}
+
+// removeUnused replaces the declarations of the given variables with their
+// values, if the variables are never used, since Go doesn't allow unused
+// variables, such as the parameter of a lambda that ignores it
+func removeUnused(stmts []ast.Stmt, variables map[string]bool) []ast.Stmt {
+	for name := range variables {
+		if usesName(stmts, name) {
+			continue
+		}
+		var kept []ast.Stmt
+		for _, stmt := range stmts {
+			if assign, ok := stmt.(*ast.AssignStmt); ok && len(assign.Lhs) == 1 && isIdent(assign.Lhs[0], name) {
+				kept = append(kept, discard(assign.Rhs[0])...)
+				continue
+			}
+			kept = append(kept, stmt)
+		}
+		stmts = kept
+	}
+	return stmts
+}
+
+// usesName tests if any of the statements use the value of a variable, which
+// isn't the case for the variable's declaration, or assignments to it
+func usesName(stmts []ast.Stmt, name string) bool {
+	var found bool
+	for _, stmt := range stmts {
+		ast.Inspect(stmt, func(node ast.Node) bool {
+			if assign, ok := node.(*ast.AssignStmt); ok && len(assign.Lhs) == 1 && isIdent(assign.Lhs[0], name) {
+				for _, value := range assign.Rhs {
+					ast.Inspect(value, func(node ast.Node) bool {
+						found = found || isIdent(node, name)
+						return !found
+					})
+				}
+				return false
+			}
+			found = found || isIdent(node, name)
+			return !found
+		})
+	}
+	return found
+}
+
+// isIdent tests if a node is an identifier with the given name
+func isIdent(node ast.Node, name string) bool {
+	ident, ok := node.(*ast.Ident)
+	return ok && ident.Name == name
+}
@@ -0,0 +1,251 @@
+package main
+
+import (
+	"go/ast"
+	"strings"
+
+	"github.com/NickyBoy89/java2go/nodeutil"
+	"github.com/NickyBoy89/java2go/symbol"
+	sitter "github.com/smacker/go-tree-sitter"
+)
+
+// The static methods of `Math` and `StrictMath` are translated into Go's `math`
+// package, or into the functions in `stdjava` that have Java's results, where
+// the overload of a method is chosen from the types of its arguments
+
+// The methods that are overloaded for each of the numeric types, which are
+// generic functions in `stdjava`, along with the number of arguments that they
+// take
+var mathOverloads = map[string]struct {
+	function   string
+	parameters int
+}{
+	"max":      {"Max", 2},
+	"min":      {"Min", 2},
+	"abs":      {"Abs", 1},
+	"floorDiv": {"FloorDiv", 2},
+	"floorMod": {"FloorMod", 2},
+	"signum":   {"Signum", 1},
+	"round":    {"Round", 1},
+}
+
+// The methods that take doubles and return a double, along with the functions
+// that they are translated into, and the number of arguments that they take
+var mathFunctions = map[string]struct {
+	function   string
+	parameters int
+}{
+	"sqrt":          {"math.Sqrt", 1},
+	"cbrt":          {"math.Cbrt", 1},
+	"pow":           {"stdjava.Pow", 2},
+	"exp":           {"math.Exp", 1},
+	"expm1":         {"math.Expm1", 1},
+	"log":           {"math.Log", 1},
+	"log10":         {"math.Log10", 1},
+	"log1p":         {"math.Log1p", 1},
+	"sin":           {"math.Sin", 1},
+	"cos":           {"math.Cos", 1},
+	"tan":           {"math.Tan", 1},
+	"asin":          {"math.Asin", 1},
+	"acos":          {"math.Acos", 1},
+	"atan":          {"math.Atan", 1},
+	"atan2":         {"math.Atan2", 2},
+	"sinh":          {"math.Sinh", 1},
+	"cosh":          {"math.Cosh", 1},
+	"tanh":          {"math.Tanh", 1},
+	"hypot":         {"math.Hypot", 2},
+	"floor":         {"math.Floor", 1},
+	"ceil":          {"math.Ceil", 1},
+	"rint":          {"math.RoundToEven", 1},
+	"IEEEremainder": {"math.Remainder", 2},
+	"toRadians":     {"stdjava.ToRadians", 1},
+	"toDegrees":     {"stdjava.ToDegrees", 1},
+	"random":        {"stdjava.Random", 0},
+}
+
+// The constants of `Math`, which are the same as the constants in Go's `math`
+// package
+var mathConstants = map[string]string{
+	"PI": "Pi",
+	"E":  "E",
+}
+
+// isMathClass tests if an expression names either `Math` or `StrictMath`,
+// which are translated the same way
+func isMathClass(node *sitter.Node, source []byte, ctx Ctx) bool {
+	return isStandardReference(node, "Math", "java.lang", source, ctx) || isStandardReference(node, "StrictMath", "java.lang", source, ctx)
+}
+
+// mathResultType returns the Java type that a method of `Math` returns, when
+// it is called with arguments of the given Java types, or an empty string if the
+// method isn't translated
+//
+// The overloaded methods return an empty string if the types of their
+// arguments aren't known
+func mathResultType(name string, argumentTypes []string) string {
+	if function, known := mathFunctions[name]; known {
+		if function.parameters != len(argumentTypes) {
+			return ""
+		}
+		return "double"
+	}
+	if overload, known := mathOverloads[name]; !known || overload.parameters != len(argumentTypes) {
+		return ""
+	}
+
+	promoted := unaryPromotion(argumentTypes[0])
+	if len(argumentTypes) == 2 {
+		promoted = binaryPromotion(argumentTypes[0], argumentTypes[1])
+	}
+	switch name {
+	case "floorDiv", "floorMod":
+		if !isIntegral(promoted) {
+			return ""
+		}
+		// The remainder of dividing by an int is always an int
+		if name == "floorMod" && unaryPromotion(argumentTypes[1]) == "int" {
+			return "int"
+		}
+	case "signum", "round":
+		// The integers are converted into floats, which are the closest overload
+		if promoted == "" {
+			return ""
+		}
+		switch {
+		case name == "signum" && promoted == "double":
+			return "double"
+		case name == "signum":
+			return "float"
+		case promoted == "double":
+			return "long"
+		}
+		return "int"
+	}
+	return promoted
+}
+
+// mathMethodType returns the Java type that a method invocation returns, if it
+// calls one of the methods of `Math`, or an empty string if it doesn't
+func mathMethodType(node *sitter.Node, source []byte, ctx Ctx) string {
+	if !isMathClass(node.ChildByFieldName("object"), source, ctx) {
+		return ""
+	}
+	name := node.ChildByFieldName("name").Content(source)
+	if _, known := mathFunctions[name]; !known {
+		if _, known := mathOverloads[name]; !known {
+			return ""
+		}
+	}
+	return mathResultType(name, argumentTypes(node.ChildByFieldName("arguments"), source, ctx))
+}
+
+// MathMethod translates a method invocation that calls one of the methods of
+// `Math` or `StrictMath`, such as `Math.max(a, b)`
+//
+// Returns nil if the method invocation doesn't call one of them
+func MathMethod(node *sitter.Node, source []byte, ctx Ctx) ast.Expr {
+	if mathMethodType(node, source, ctx) == "" {
+		// The overloaded methods that have arguments of unknown types are still
+		// translated, and Go infers their types instead
+		name := node.ChildByFieldName("name").Content(source)
+		overload, known := mathOverloads[name]
+		if !known || int(node.ChildByFieldName("arguments").NamedChildCount()) != overload.parameters || !isMathClass(node.ChildByFieldName("object"), source, ctx) {
+			return nil
+		}
+	}
+	argumentNodes := nodeutil.NamedChildrenOf(node.ChildByFieldName("arguments"))
+
+	ev := ctx.evaluation()
+	arguments := parseArguments(node.ChildByFieldName("arguments"), source, ev)
+	types := make([]string, len(arguments))
+	for ind, argument := range argumentNodes {
+		types[ind] = ExpressionType(argument, source, ctx)
+		if holdsBox(argument, source, ctx) {
+			arguments[ind], types[ind] = callStdjava("Unbox", arguments[ind]), symbol.UnboxedType(types[ind])
+		}
+	}
+
+	call, _ := mathCall(node.ChildByFieldName("name").Content(source), arguments, types)
+	return call
+}
+
+// mathCall translates a call to one of the methods of `Math`, with arguments
+// of the given Java types, and returns the call along with the Java type that
+// it returns
+func mathCall(name string, arguments []ast.Expr, argumentTypes []string) (ast.Expr, string) {
+	result := mathResultType(name, argumentTypes)
+
+	// The arguments are converted into the type of the overload, and generic
+	// functions are given that type explicitly, since the arguments may be
+	// untyped constants
+	converted := func(ind int, to string) ast.Expr {
+		return convertValue(arguments[ind], argumentTypes[ind], to)
+	}
+	generic := func(function, javaType string, args ...ast.Expr) ast.Expr {
+		if javaType == "" {
+			return callStdjava(function, args...)
+		}
+		return &ast.CallExpr{
+			Fun:  &ast.IndexExpr{X: stdjavaType(function), Index: &ast.Ident{Name: primitiveTypes[javaType]}},
+			Args: args,
+		}
+	}
+
+	if function, known := mathFunctions[name]; known {
+		args := make([]ast.Expr, len(arguments))
+		for ind := range arguments {
+			args[ind] = converted(ind, "double")
+		}
+		pkg, function, _ := strings.Cut(function.function, ".")
+		return &ast.CallExpr{Fun: &ast.SelectorExpr{X: &ast.Ident{Name: pkg}, Sel: &ast.Ident{Name: function}}, Args: args}, result
+	}
+
+	overload := mathOverloads[name]
+	switch name {
+	case "round":
+		if result == "int" {
+			return callStdjava("RoundFloat", converted(0, "float")), result
+		}
+		return callStdjava("Round", converted(0, "double")), result
+	case "signum":
+		return generic(overload.function, result, converted(0, result)), result
+	case "floorMod":
+		if result == "int" && binaryPromotion(argumentTypes[0], argumentTypes[1]) == "long" {
+			// A long is divided as a long, and its remainder is narrowed into an int
+			mod := generic(overload.function, "long", converted(0, "long"), converted(1, "long"))
+			return ConvertPrimitive(mod, "long", "int"), result
+		}
+	}
+
+	args := make([]ast.Expr, len(arguments))
+	for ind := range arguments {
+		args[ind] = converted(ind, result)
+	}
+	return generic(overload.function, result, args...), result
+}
+
+// mathFieldType returns the Java type of a field access that refers to one of
+// the constants of `Math`, such as `Math.PI`, or an empty string if it doesn't
+// refer to one of them
+func mathFieldType(node *sitter.Node, source []byte, ctx Ctx) string {
+	if _, known := mathConstants[node.ChildByFieldName("field").Content(source)]; !known {
+		return ""
+	}
+	if !isMathClass(node.ChildByFieldName("object"), source, ctx) {
+		return ""
+	}
+	return "double"
+}
+
+// MathField translates a field access of one of the constants of `Math`
+//
+// Returns nil if the field access doesn't refer to one of them
+func MathField(node *sitter.Node, source []byte, ctx Ctx) ast.Expr {
+	if mathFieldType(node, source, ctx) == "" {
+		return nil
+	}
+	return &ast.SelectorExpr{
+		X:   &ast.Ident{Name: "math"},
+		Sel: &ast.Ident{Name: mathConstants[node.ChildByFieldName("field").Content(source)]},
+	}
+}
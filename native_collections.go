@@ -0,0 +1,245 @@
+package main
+
+import (
+	"go/ast"
+	"go/token"
+	"strconv"
+	"strings"
+
+	"github.com/NickyBoy89/java2go/symbol"
+	log "github.com/sirupsen/logrus"
+	sitter "github.com/smacker/go-tree-sitter"
+)
+
+// With `-native-collections`, local lists and maps that are never shared with
+// any other code are translated into Go's slices and maps, and their methods
+// into the operations on them, such as `append`
+
+// The parameters of the methods of lists and maps that are translated into
+// operations on Go's slices and maps
+var (
+	nativeListParameters = map[string][]string{
+		"add":    {"E"},
+		"set":    {"int", "E"},
+		"remove": {"int"},
+		"get":    {"int"},
+	}
+	nativeMapParameters = map[string][]string{
+		"put":         {"K", "V"},
+		"remove":      {"K"},
+		"get":         {"K"},
+		"containsKey": {"K"},
+	}
+)
+
+// resolveNativeCollections gives the local lists and maps of a method that can
+// be Go's slices and maps their Go types, and reports why the others can't be
+func resolveNativeCollections(method *symbol.Definition, file *symbol.FileScope) {
+	for _, local := range method.Locals() {
+		if !local.NativeCollection && local.CollectionEscape == "" {
+			continue
+		}
+		// The list or map may be one of the package's classes instead
+		if file.JavaCollection(local.OriginalType) == "" {
+			local.NativeCollection, local.CollectionEscape = false, ""
+			continue
+		}
+
+		if !nativeCollections {
+			if local.NativeCollection {
+				log.WithFields(log.Fields{
+					"variable": local.OriginalName,
+					"method":   method.OriginalName,
+				}).Info("Collection is never shared, and can be a Go slice or map with -native-collections")
+			}
+			continue
+		}
+
+		if !local.NativeCollection {
+			log.WithFields(log.Fields{
+				"variable": local.OriginalName,
+				"method":   method.OriginalName,
+				"reason":   local.CollectionEscape,
+			}).Info("Collection can't be a Go slice or map, so it is one of stdjava's collections instead")
+			continue
+		}
+
+		_, typeArguments := symbol.SplitTypeArguments(local.OriginalType)
+		if len(typeArguments) == 1 {
+			local.Type = "[]" + file.GoType(typeArguments[0])
+		} else {
+			local.Type = "map[" + file.GoType(typeArguments[0]) + "]" + file.GoType(typeArguments[1])
+		}
+	}
+}
+
+// nativeCollection returns the definition of a local list or map that is a Go
+// slice or map, or nil if the expression isn't one of them
+func nativeCollection(node *sitter.Node, source []byte, ctx Ctx) *symbol.Definition {
+	if !nativeCollections || node == nil || node.Type() != "identifier" || ctx.localScope == nil {
+		return nil
+	}
+	if def := ctx.localScope.FindVariableAt(node.Content(source), node.StartByte()); def != nil && def.NativeCollection {
+		return def
+	}
+	return nil
+}
+
+// nativeArguments parses the arguments of a call to one of the methods of a
+// list or a map that is a Go slice or map, and converts them to the types of
+// the method's parameters
+func nativeArguments(node *sitter.Node, source []byte, ctx Ctx, def *symbol.Definition) []ast.Expr {
+	_, typeArguments := symbol.SplitTypeArguments(def.OriginalType)
+	name := node.ChildByFieldName("name").Content(source)
+	parameters := nativeMapParameters[name]
+	if len(typeArguments) == 1 {
+		parameters = nativeListParameters[name]
+	}
+
+	collection := ctx.currentFile.JavaCollection(def.OriginalType)
+	arguments := parseArguments(node.ChildByFieldName("arguments"), source, ctx.evaluation())
+	return convertCollectionArguments(node, source, ctx, collection, typeArguments, &collectionMethod{parameters: parameters}, arguments)
+}
+
+// NativeCollectionMethod translates a call to a method of a list or a map that
+// is a Go slice or map, where the result of the method is used
+//
+// Returns nil if the object of the call isn't a Go slice or map
+func NativeCollectionMethod(node *sitter.Node, source []byte, ctx Ctx) ast.Expr {
+	def := nativeCollection(node.ChildByFieldName("object"), source, ctx)
+	if def == nil {
+		return nil
+	}
+	collection := &ast.Ident{Name: def.Name}
+	arguments := nativeArguments(node, source, ctx, def)
+
+	switch node.ChildByFieldName("name").Content(source) {
+	case "get":
+		return &ast.IndexExpr{X: collection, Index: arguments[0]}
+	case "size":
+		return callFunc("int32", callFunc("len", collection))
+	case "isEmpty":
+		return &ast.BinaryExpr{X: callFunc("len", collection), Op: token.EQL, Y: &ast.BasicLit{Kind: token.INT, Value: "0"}}
+	case "containsKey":
+		// Looking up a key in a map is only an expression when it doesn't test if
+		// the key is there, so that is done in a statement first
+		return withHoisting(node, source, ctx, func(ctx Ctx) ast.Expr {
+			found := ctx.temporaryName()
+			ctx.hoist(&ast.AssignStmt{
+				Lhs: []ast.Expr{&ast.Ident{Name: "_"}, found},
+				Tok: token.DEFINE,
+				Rhs: []ast.Expr{&ast.IndexExpr{X: collection, Index: arguments[0]}},
+			})
+			return found
+		})
+	}
+	return nil
+}
+
+// NativeCollectionStmt translates a call to a method of a list or a map that
+// is a Go slice or map, where the result of the method isn't used, such as
+// `add`, which is translated into an `append`
+//
+// Returns nil if the object of the call isn't a Go slice or map, or if the
+// method's result would be used
+func NativeCollectionStmt(node *sitter.Node, source []byte, ctx Ctx) ast.Stmt {
+	if node.Type() != "method_invocation" {
+		return nil
+	}
+	def := nativeCollection(node.ChildByFieldName("object"), source, ctx)
+	if def == nil {
+		return nil
+	}
+	collection := &ast.Ident{Name: def.Name}
+	assign := func(target, value ast.Expr) ast.Stmt {
+		return &ast.AssignStmt{Lhs: []ast.Expr{target}, Tok: token.ASSIGN, Rhs: []ast.Expr{value}}
+	}
+	_, typeArguments := symbol.SplitTypeArguments(def.OriginalType)
+	isList := len(typeArguments) == 1
+
+	switch name := node.ChildByFieldName("name").Content(source); {
+	case name == "add":
+		arguments := nativeArguments(node, source, ctx, def)
+		return assign(collection, callFunc("append", collection, arguments[0]))
+	case name == "set", name == "put":
+		arguments := nativeArguments(node, source, ctx, def)
+		return assign(&ast.IndexExpr{X: collection, Index: arguments[0]}, arguments[1])
+	case name == "remove" && isList:
+		// The index is used twice, so it is only evaluated once
+		index := nativeArguments(node, source, ctx, def)[0]
+		if _, simple := index.(*ast.BasicLit); !simple && node.ChildByFieldName("arguments").NamedChild(0).Type() != "identifier" {
+			index = ctx.temporary(index)
+		}
+		var next ast.Expr = &ast.BinaryExpr{X: index, Op: token.ADD, Y: &ast.BasicLit{Kind: token.INT, Value: "1"}}
+		if literal, isLiteral := index.(*ast.BasicLit); isLiteral {
+			if value, err := strconv.ParseInt(literal.Value, 0, 32); err == nil {
+				next = &ast.BasicLit{Kind: token.INT, Value: strconv.FormatInt(value+1, 10)}
+			}
+		}
+		return &ast.AssignStmt{
+			Lhs: []ast.Expr{collection},
+			Tok: token.ASSIGN,
+			Rhs: []ast.Expr{&ast.CallExpr{
+				Fun: &ast.Ident{Name: "append"},
+				Args: []ast.Expr{
+					&ast.SliceExpr{X: collection, High: index},
+					&ast.SliceExpr{X: collection, Low: next},
+				},
+				Ellipsis: 1,
+			}},
+		}
+	case name == "remove":
+		return &ast.ExprStmt{X: callFunc("delete", collection, nativeArguments(node, source, ctx, def)[0])}
+	case name == "clear" && isList:
+		return assign(collection, &ast.SliceExpr{X: collection, High: &ast.BasicLit{Kind: token.INT, Value: "0"}})
+	case name == "clear":
+		return assign(collection, &ast.CompositeLit{Type: &ast.Ident{Name: def.Type}})
+	}
+	return nil
+}
+
+// NativeCollectionCreation creates the Go slice or map that a new list or map
+// is assigned to, or returns nil if it isn't assigned to one of them
+func NativeCollectionCreation(node *sitter.Node, source []byte, ctx Ctx) ast.Expr {
+	var target *sitter.Node
+	switch parent := node.Parent(); parent.Type() {
+	case "variable_declarator":
+		target = parent.ChildByFieldName("name")
+	case "assignment_expression":
+		target = parent.ChildByFieldName("left")
+	}
+	def := nativeCollection(target, source, ctx)
+	if def == nil {
+		return nil
+	}
+
+	// A collection that is given a capacity is made with that capacity
+	if capacity := node.ChildByFieldName("arguments").NamedChild(0); capacity != nil {
+		size := ParseExpr(capacity, source, ctx)
+		if strings.HasPrefix(def.Type, "[]") {
+			return callFunc("make", &ast.Ident{Name: def.Type}, &ast.BasicLit{Kind: token.INT, Value: "0"}, size)
+		}
+		return callFunc("make", &ast.Ident{Name: def.Type}, size)
+	}
+	return &ast.CompositeLit{Type: &ast.Ident{Name: def.Type}}
+}
+
+// nativeRange returns what an enhanced for statement iterates over when it
+// iterates over a list, or the keys or values of a map, that is a Go slice or
+// map, and whether it iterates over the keys of a map, which are the keys of
+// the range, instead of its values
+//
+// Returns nil if the statement doesn't iterate over a Go slice or map
+func nativeRange(node *sitter.Node, source []byte, ctx Ctx) (ast.Expr, bool) {
+	if def := nativeCollection(node, source, ctx); def != nil {
+		return &ast.Ident{Name: def.Name}, false
+	}
+	if node.Type() != "method_invocation" {
+		return nil, false
+	}
+	def := nativeCollection(node.ChildByFieldName("object"), source, ctx)
+	if def == nil {
+		return nil, false
+	}
+	return &ast.Ident{Name: def.Name}, node.ChildByFieldName("name").Content(source) == "keySet"
+}
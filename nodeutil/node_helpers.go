@@ -21,3 +21,30 @@ func UnnamedChildrenOf(node *sitter.Node) []*sitter.Node {
 	}
 	return children
 }
+
+// NamedChildrenOfType gets all the named children of a given node that have
+// the given type, such as every declarator of a declaration like `int a = 1, b;`
+func NamedChildrenOfType(node *sitter.Node, nodeType string) []*sitter.Node {
+	var children []*sitter.Node
+	for _, child := range NamedChildrenOf(node) {
+		if child.Type() == nodeType {
+			children = append(children, child)
+		}
+	}
+	return children
+}
+
+// ChildrenByFieldName gets all the children of a given node that have the
+// given field name, such as every update of a loop like `i++, j--`, where
+// `ChildByFieldName` only returns the first one
+func ChildrenByFieldName(node *sitter.Node, fieldName string) []*sitter.Node {
+	var children []*sitter.Node
+	cursor := sitter.NewTreeCursor(node)
+	defer cursor.Close()
+	for ok := cursor.GoToFirstChild(); ok; ok = cursor.GoToNextSibling() {
+		if cursor.CurrentFieldName() == fieldName {
+			children = append(children, cursor.CurrentNode())
+		}
+	}
+	return children
+}
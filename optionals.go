@@ -0,0 +1,222 @@
+package main
+
+import (
+	"go/ast"
+	"strings"
+
+	"github.com/NickyBoy89/java2go/nodeutil"
+	"github.com/NickyBoy89/java2go/symbol"
+	sitter "github.com/smacker/go-tree-sitter"
+)
+
+// Java's optionals from `java.util` are translated into the optionals in
+// `stdjava`, and their methods are described the same way as the operations
+// of streams, where `T` is the type of the optional's value, and `map` and
+// `flatMap` are functions instead of methods
+
+// The methods of `Object`, which every optional has
+var optionalObjectMethods = map[string][]streamOperation{
+	"equals":   {{name: "Equals", parameters: []string{"Object"}, result: "boolean"}},
+	"hashCode": {{name: "HashCode", result: "int"}},
+	"toString": {{name: "String", result: "String"}},
+}
+
+// The methods of each of the optionals, which are looked up by their Java
+// names, after the methods of `Object`
+var optionalMethods = map[string]map[string][]streamOperation{
+	"Optional": {
+		"isPresent":       {{name: "IsPresent", result: "boolean"}},
+		"isEmpty":         {{name: "IsEmpty", result: "boolean"}},
+		"get":             {{name: "Get", result: "T"}},
+		"orElse":          {{name: "OrElse", parameters: []string{"T"}, result: "T"}},
+		"orElseGet":       {{name: "OrElseGet", parameters: []string{"Supplier<T>"}, result: "T"}},
+		"ifPresent":       {{name: "IfPresent", parameters: []string{"Consumer<T>"}, result: "void"}},
+		"ifPresentOrElse": {{name: "IfPresentOrElse", parameters: []string{"Consumer<T>", "Runnable"}, result: "void"}},
+		"filter":          {{name: "Filter", parameters: []string{"Predicate<T>"}, result: "Optional<T>"}},
+		"map":             {{name: "MapOptional", function: true, parameters: []string{"Function<T, R>"}, result: "Optional<R>"}},
+		"flatMap":         {{name: "FlatMapOptional", function: true, parameters: []string{"Function<T, Optional<R>>"}, result: "Optional<R>"}},
+		"orElseThrow": {
+			{name: "OrElseThrow", result: "T"},
+			{name: "OrElseThrowWith", parameters: []string{"Supplier<Object>"}, result: "T"},
+		},
+	},
+	"OptionalInt":    primitiveOptionalMethods("Int"),
+	"OptionalLong":   primitiveOptionalMethods("Long"),
+	"OptionalDouble": primitiveOptionalMethods("Double"),
+}
+
+// primitiveOptionalMethods returns the methods of one of the primitive
+// optionals, such as `OptionalInt`, which are the same as `Optional`'s methods,
+// except that they take the primitive versions of the functional interfaces,
+// and `get` is named after the primitive type, such as `getAsInt`
+func primitiveOptionalMethods(primitive string) map[string][]streamOperation {
+	return map[string][]streamOperation{
+		"isPresent":         {{name: "IsPresent", result: "boolean"}},
+		"isEmpty":           {{name: "IsEmpty", result: "boolean"}},
+		"getAs" + primitive: {{name: "GetAs" + primitive, result: "T"}},
+		"orElse":            {{name: "OrElse", parameters: []string{"T"}, result: "T"}},
+		"orElseGet":         {{name: "OrElseGet", parameters: []string{primitive + "Supplier"}, result: "T"}},
+		"ifPresent":         {{name: "IfPresent", parameters: []string{primitive + "Consumer"}, result: "void"}},
+		"ifPresentOrElse":   {{name: "IfPresentOrElse", parameters: []string{primitive + "Consumer", "Runnable"}, result: "void"}},
+		"orElseThrow": {
+			{name: "OrElseThrow", result: "T"},
+			{name: "OrElseThrowWith", parameters: []string{"Supplier<Object>"}, result: "T"},
+		},
+	}
+}
+
+// The functions in `stdjava` that create each of the optionals, which are
+// looked up by the names of Java's static methods
+var optionalConstructors = map[string]map[string]string{
+	"Optional":       {"of": "OptionalOf", "ofNullable": "OptionalOfNullable", "empty": "OptionalEmpty"},
+	"OptionalInt":    {"of": "OptionalIntOf", "empty": "OptionalIntEmpty"},
+	"OptionalLong":   {"of": "OptionalLongOf", "empty": "OptionalLongEmpty"},
+	"OptionalDouble": {"of": "OptionalDoubleOf", "empty": "OptionalDoubleEmpty"},
+}
+
+// optionalValue returns the Java type of the value that an optional contains
+func optionalValue(optionalType string) string {
+	optional, typeArguments := symbol.SplitTypeArguments(optionalType)
+	switch {
+	case strings.HasSuffix(optional, "OptionalInt"):
+		return "int"
+	case strings.HasSuffix(optional, "OptionalLong"):
+		return "long"
+	case strings.HasSuffix(optional, "OptionalDouble"):
+		return "double"
+	case len(typeArguments) > 0:
+		return typeArguments[0]
+	}
+	return "Object"
+}
+
+// optionalSource finds the function that creates the optional that a method
+// invocation returns, such as `Optional.of(value)`, along with the Java type of
+// the optional
+//
+// Both are empty if the method invocation doesn't create an optional
+func optionalSource(node *sitter.Node, source []byte, ctx Ctx) (string, string) {
+	object := node.ChildByFieldName("object")
+	argumentNodes := nodeutil.NamedChildrenOf(node.ChildByFieldName("arguments"))
+	for optional, constructors := range optionalConstructors {
+		if !isStandardReference(object, optional, "java.util", source, ctx) {
+			continue
+		}
+		name := node.ChildByFieldName("name").Content(source)
+		function, known := constructors[name]
+		if !known || (name == "empty") != (len(argumentNodes) == 0) || len(argumentNodes) > 1 {
+			return "", ""
+		}
+		if optional != "Optional" {
+			return function, optional
+		}
+
+		// The type of the value is given explicitly, or is the type that the
+		// optional is assigned to, or is the type of the value that it contains
+		value := "Object"
+		if typeArguments := node.ChildByFieldName("type_arguments"); typeArguments != nil && typeArguments.NamedChildCount() == 1 {
+			value = typeArguments.NamedChild(0).Content(source)
+		} else if target := targetType(node, source, ctx); ctx.currentFile.JavaOptional(target) == "Optional" && optionalValue(target) != "Object" {
+			value = optionalValue(target)
+		} else if len(argumentNodes) == 1 {
+			if argumentType := ExpressionType(argumentNodes[0], source, ctx); argumentType != "" && argumentType != "null" {
+				value = symbol.BoxedType(argumentType)
+			}
+		}
+		return function, "Optional<" + value + ">"
+	}
+	return "", ""
+}
+
+// NewOptional translates a method invocation that creates an optional, such
+// as `Optional.of(value)`, into the function from `stdjava` that creates the
+// same optional
+func NewOptional(node *sitter.Node, source []byte, ctx Ctx, function, javaType string) ast.Expr {
+	ev := ctx.evaluation()
+	value := optionalValue(javaType)
+	var arguments []*ast.Expr
+	for _, argument := range nodeutil.NamedChildrenOf(node.ChildByFieldName("arguments")) {
+		arguments = append(arguments, parseStreamArgument(ev, argument, source, value))
+	}
+
+	// The value's type is given explicitly, since it may not be the type of the
+	// argument, and there isn't an argument for an empty optional
+	var constructor ast.Expr = stdjavaType(function)
+	if ctx.currentFile.JavaOptional(javaType) == "Optional" {
+		constructor = &ast.IndexExpr{X: constructor, Index: &ast.Ident{Name: ctx.currentFile.GoType(value)}}
+	}
+	return &ast.CallExpr{Fun: constructor, Args: evaluated(arguments)}
+}
+
+// optionalCall finds the Java type of the value of the optional that a method
+// invocation calls a method of, and the method that it calls
+//
+// The method is nil if the method invocation doesn't call one of the methods
+// of an optional
+func optionalCall(node *sitter.Node, source []byte, ctx Ctx) (string, *streamOperation) {
+	object := node.ChildByFieldName("object")
+	if object == nil || ctx.currentFile == nil {
+		return "", nil
+	}
+	objectType := ExpressionType(object, source, ctx)
+	optional := ctx.currentFile.JavaOptional(objectType)
+	if optional == "" {
+		return "", nil
+	}
+
+	name := node.ChildByFieldName("name").Content(source)
+	arguments := int(node.ChildByFieldName("arguments").NamedChildCount())
+	for _, methods := range []map[string][]streamOperation{optionalMethods[optional], optionalObjectMethods} {
+		for _, method := range methods[name] {
+			if len(method.parameters) == arguments {
+				return optionalValue(objectType), &method
+			}
+		}
+	}
+	return "", nil
+}
+
+// optionalMethodType returns the Java type that a method invocation that either
+// creates an optional, or calls one of the methods of an optional returns, or
+// an empty string if it does neither
+func optionalMethodType(node *sitter.Node, source []byte, ctx Ctx) string {
+	if _, optionalType := optionalSource(node, source, ctx); optionalType != "" {
+		return optionalType
+	}
+	value, method := optionalCall(node, source, ctx)
+	if method == nil {
+		return ""
+	}
+	return instantiateStream(method.result, value, mappedType(node, source, ctx, value, method))
+}
+
+// OptionalMethod translates a method invocation that either creates an
+// optional, or calls one of the methods of an optional
+//
+// Returns nil if the method invocation does neither
+func OptionalMethod(node *sitter.Node, source []byte, ctx Ctx) ast.Expr {
+	if ctx.currentFile == nil {
+		return nil
+	}
+	if function, optionalType := optionalSource(node, source, ctx); function != "" {
+		return NewOptional(node, source, ctx, function, optionalType)
+	}
+	value, method := optionalCall(node, source, ctx)
+	if method == nil {
+		return nil
+	}
+	mapped := mappedType(node, source, ctx, value, method)
+	argumentNodes := nodeutil.NamedChildrenOf(node.ChildByFieldName("arguments"))
+
+	ev := ctx.evaluation()
+	object := ev.parse(node.ChildByFieldName("object"), source, identity)
+	var operands []*ast.Expr
+	for ind, parameter := range method.parameters {
+		operands = append(operands, parseStreamArgument(ev, argumentNodes[ind], source, instantiateStream(parameter, value, mapped)))
+	}
+
+	if method.function {
+		return &ast.CallExpr{Fun: stdjavaType(method.name), Args: append([]ast.Expr{*object}, evaluated(operands)...)}
+	}
+	return callMethod(*object, method.name, evaluated(operands)...)
+}
@@ -23,6 +23,7 @@ func (file SourceFile) String() string {
 func (file *SourceFile) ParseAST() error {
 	parser := sitter.NewParser()
 	parser.SetLanguage(java.GetLanguage())
+	file.Source = EscapeTextBlocks(file.Source)
 	tree, err := parser.ParseCtx(context.Background(), nil, file.Source)
 	if err != nil {
 		return err
@@ -0,0 +1,87 @@
+package parsing
+
+import "bytes"
+
+// EscapeTextBlocks rewrites the contents of any text blocks in a Java source
+// file so that they can be parsed by tree-sitter, which does not accept quotes
+// or line continuations inside of text blocks
+//
+// Quotes are escaped as `\"`, and the backslash of a line continuation is
+// written as the unicode escape `\u005C`, neither of which change the value of
+// the text block
+func EscapeTextBlocks(source []byte) []byte {
+	if !bytes.Contains(source, []byte(`"""`)) {
+		return source
+	}
+
+	escaped := make([]byte, 0, len(source))
+	for ind := 0; ind < len(source); {
+		switch {
+		case bytes.HasPrefix(source[ind:], []byte("//")):
+			end := skipUntil(source, ind+2, "\n")
+			escaped = append(escaped, source[ind:end]...)
+			ind = end
+		case bytes.HasPrefix(source[ind:], []byte("/*")):
+			end := skipUntil(source, ind+2, "*/")
+			escaped = append(escaped, source[ind:end]...)
+			ind = end
+		case bytes.HasPrefix(source[ind:], []byte(`"""`)):
+			escaped = append(escaped, `"""`...)
+			ind += 3
+			for ind < len(source) && !bytes.HasPrefix(source[ind:], []byte(`"""`)) {
+				switch {
+				case source[ind] == '\\' && ind+1 < len(source) && (source[ind+1] == '\n' || source[ind+1] == '\r'):
+					escaped = append(escaped, `\u005C`...)
+					ind++
+				case source[ind] == '\\' && ind+1 < len(source):
+					escaped = append(escaped, source[ind:ind+2]...)
+					ind += 2
+				case source[ind] == '"':
+					escaped = append(escaped, `\"`...)
+					ind++
+				default:
+					escaped = append(escaped, source[ind])
+					ind++
+				}
+			}
+			// The closing delimiter ends the text block, instead of starting another
+			if ind < len(source) {
+				escaped = append(escaped, `"""`...)
+				ind += 3
+			}
+		case source[ind] == '"' || source[ind] == '\'':
+			end := skipLiteral(source, ind)
+			escaped = append(escaped, source[ind:end]...)
+			ind = end
+		default:
+			escaped = append(escaped, source[ind])
+			ind++
+		}
+	}
+
+	return escaped
+}
+
+// skipUntil returns the index after the next occurrence of the terminator, or
+// the end of the source if it does not occur
+func skipUntil(source []byte, start int, terminator string) int {
+	if end := bytes.Index(source[start:], []byte(terminator)); end != -1 {
+		return start + end + len(terminator)
+	}
+	return len(source)
+}
+
+// skipLiteral returns the index after the end of the string or character
+// literal that starts at the given index
+func skipLiteral(source []byte, start int) int {
+	quote := source[start]
+	for ind := start + 1; ind < len(source); ind++ {
+		switch source[ind] {
+		case '\\':
+			ind++
+		case quote, '\n':
+			return ind + 1
+		}
+	}
+	return len(source)
+}
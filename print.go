@@ -0,0 +1,53 @@
+package main
+
+import (
+	"go/ast"
+
+	"github.com/NickyBoy89/java2go/nodeutil"
+	sitter "github.com/smacker/go-tree-sitter"
+)
+
+// Printing to `System.out` and `System.err` is translated into the functions
+// of Go's `fmt` package, which are given the values after they are converted
+// into strings the same way that Java converts them
+
+// The methods of `PrintStream` that are translated, along with the functions
+// in `fmt` that they are translated into
+var printMethods = map[string]string{
+	"print":   "Print",
+	"println": "Println",
+}
+
+// PrintMethod translates a method invocation that prints a value to either
+// `System.out` or `System.err`, such as `System.out.println(value)`
+//
+// Returns nil if the method invocation doesn't print to either of them
+func PrintMethod(node *sitter.Node, source []byte, ctx Ctx) ast.Expr {
+	function, known := printMethods[node.ChildByFieldName("name").Content(source)]
+	stream := node.ChildByFieldName("object")
+	if !known || stream == nil || stream.Type() != "field_access" || !isStandardReference(stream.ChildByFieldName("object"), "System", "java.lang", source, ctx) {
+		return nil
+	}
+
+	argumentNodes := nodeutil.NamedChildrenOf(node.ChildByFieldName("arguments"))
+	if len(argumentNodes) > 1 {
+		return nil
+	}
+	arguments := parseArguments(node.ChildByFieldName("arguments"), source, ctx.evaluation())
+	for ind, argument := range argumentNodes {
+		arguments[ind] = StringConversion(arguments[ind], ExpressionType(argument, source, ctx))
+	}
+
+	fmtFunction := func(name string) ast.Expr {
+		return &ast.SelectorExpr{X: &ast.Ident{Name: "fmt"}, Sel: &ast.Ident{Name: name}}
+	}
+	switch stream.ChildByFieldName("field").Content(source) {
+	case "out":
+		return &ast.CallExpr{Fun: fmtFunction(function), Args: arguments}
+	case "err":
+		// Printing to a different stream is done with the `Fprint` functions
+		stderr := &ast.SelectorExpr{X: &ast.Ident{Name: "os"}, Sel: &ast.Ident{Name: "Stderr"}}
+		return &ast.CallExpr{Fun: fmtFunction("F" + function), Args: append([]ast.Expr{stderr}, arguments...)}
+	}
+	return nil
+}
@@ -8,28 +8,57 @@ import (
 )
 
 func ResolveFile(file parsing.SourceFile) {
+	// Classes are renamed first, so that the types of everything else can refer
+	// to their new names
+	RenameClass(file.Symbols.BaseClass)
+	for _, subclass := range file.Symbols.BaseClass.Subclasses {
+		RenameClass(subclass)
+	}
+
 	ResolveClass(file.Symbols.BaseClass, file)
 	for _, subclass := range file.Symbols.BaseClass.Subclasses {
 		ResolveClass(subclass, file)
 	}
 }
 
-func ResolveClass(class *symbol.ClassScope, file parsing.SourceFile) {
-	// Resolve all the fields in that respective class
-	for _, field := range class.Fields {
+// ResolveLocals renames the parameters and local variables of every method in
+// a file, which must be done after the static fields and methods of every file
+// in the package have been renamed, since locals can't shadow them
+func ResolveLocals(file parsing.SourceFile) {
+	ResolveClassLocals(file.Symbols.BaseClass, file)
+	for _, subclass := range file.Symbols.BaseClass.Subclasses {
+		ResolveClassLocals(subclass, file)
+	}
+}
 
-		// Since a private global variable is able to be accessed in the package, it must be renamed
-		// to avoid conflicts with other global variables
+// RenameClass renames a class if its name conflicts with anything that is
+// declared at the package level in Go
+func RenameClass(class *symbol.ClassScope) {
+	renameUnique(class.Class, symbol.Collides)
+}
 
-		packageScope := symbol.GlobalScope.FindPackage(file.Symbols.Package)
+func ResolveClass(class *symbol.ClassScope, file parsing.SourceFile) {
+	packageScope := symbol.GlobalScope.FindPackage(file.Symbols.Package)
 
+	// Resolve all the fields in that respective class
+	for _, field := range class.Fields {
 		symbol.ResolveDefinition(field, file.Symbols)
 
-		// Rename the field if its name conflits with any keyword
-		for i := 0; symbol.IsReserved(field.Name) ||
-			len(packageScope.ExcludeFile(class.Class.Name).FindStaticField().ByName(field.Name)) > 0; i++ {
-			field.Rename(field.Name + strconv.Itoa(i))
+		// Since a field is always accessed through its struct, it only has to avoid
+		// Go's keywords
+		if !field.Static {
+			renameUnique(field, symbol.IsReserved)
+			continue
 		}
+
+		// Static fields are translated into global variables, which are visible
+		// across the whole package, so they must be renamed to avoid conflicts with
+		// the package's other global variables
+		renameUnique(field, func(name string) bool {
+			return symbol.Collides(name) || len(packageScope.FindStaticField().By(func(d *symbol.Definition) bool {
+				return d != field && d.Name == name
+			})) > 0
+		})
 	}
 
 	// Resolve all the methods
@@ -40,9 +69,9 @@ func ResolveClass(class *symbol.ClassScope, file parsing.SourceFile) {
 		// Comparison compares the method against the found method
 		// This tests for a method of the same name, but with different
 		// aspects of it, so that it can be identified as a duplicate
-		comparison := func(d *symbol.Definition) bool {
+		comparison := func(name string, d *symbol.Definition) bool {
 			// The names must match, but everything else must be different
-			if method.Name != d.Name {
+			if name != d.Name {
 				return false
 			}
 
@@ -63,16 +92,81 @@ func ResolveClass(class *symbol.ClassScope, file parsing.SourceFile) {
 			return false
 		}
 
-		for i := 0; symbol.IsReserved(method.Name) || len(class.FindMethod().By(comparison)) > 0; i++ {
-			method.Rename(method.Name + strconv.Itoa(i))
-		}
-		// Resolve all the paramters of the method
-		for _, param := range method.Parameters {
-			symbol.ResolveDefinition(param, file.Symbols)
+		renameUnique(method, func(name string) bool {
+			if symbol.IsReserved(name) || len(class.FindMethod().By(func(d *symbol.Definition) bool {
+				return comparison(name, d)
+			})) > 0 {
+				return true
+			}
 
-			for i := 0; symbol.IsReserved(param.Name); i++ {
-				param.Rename(param.Name + strconv.Itoa(i))
+			// Static methods are declared as functions at the package level
+			if method.Static {
+				return symbol.Collides(name) || len(packageScope.FindStaticMethod().By(func(d *symbol.Definition) bool {
+					return d != method && d.Name == name
+				})) > 0
 			}
+
+			// Otherwise, a method can't have the same name as a field on its struct
+			if field := class.FindFieldByDisplayName(name); field != nil && !field.Static {
+				return true
+			}
+			return false
+		})
+
+	}
+}
+
+// ResolveClassLocals renames the parameters and local variables of each of a
+// class's methods
+func ResolveClassLocals(class *symbol.ClassScope, file parsing.SourceFile) {
+	packageScope := symbol.GlobalScope.FindPackage(file.Symbols.Package)
+
+	// The name of the receiver for the class's methods
+	receiver := ShortName(class.Class.Name)
+
+	for _, method := range class.Methods {
+		// Every parameter and local variable is declared in the same function, so
+		// they have to be renamed if they conflict with each other, or with the
+		// method's receiver
+		variables := append(append([]*symbol.Definition{}, method.Parameters...), method.Locals()...)
+
+		// Constructors declare the receiver as a variable
+		hasReceiver := !method.Static || method.Constructor
+
+		for _, variable := range variables {
+			// Resolve the types of all the parameters of the method
+			symbol.ResolveDefinition(variable, file.Symbols)
+
+			renameUnique(variable, func(name string) bool {
+				if symbol.Collides(name) || (hasReceiver && name == receiver) {
+					return true
+				}
+				// Static fields and methods are declared at the package level, so a
+				// variable with the same name would hide them
+				if len(packageScope.FindStaticField().ByName(name)) > 0 || len(packageScope.FindStaticMethod().ByName(name)) > 0 {
+					return true
+				}
+				// Variables with the same original name are declared in separate scopes,
+				// and are renamed the same way
+				for _, other := range variables {
+					if other != variable && other.Name == name && other.OriginalName != variable.OriginalName {
+						return true
+					}
+				}
+				return false
+			})
 		}
+
+		// Lists and maps that are never shared may be Go's slices and maps
+		resolveNativeCollections(method, file.Symbols)
+	}
+}
+
+// renameUnique renames a definition by adding a number to the end of its
+// name, until the name no longer conflicts with anything
+func renameUnique(def *symbol.Definition, conflicts func(name string) bool) {
+	name := def.Name
+	for i := 0; conflicts(def.Name); i++ {
+		def.Rename(name + strconv.Itoa(i))
 	}
 }
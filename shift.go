@@ -0,0 +1,105 @@
+package main
+
+import (
+	"go/ast"
+	"go/token"
+	"strconv"
+
+	sitter "github.com/smacker/go-tree-sitter"
+)
+
+// shiftMask returns the bits of a shift distance that Java uses for a value of
+// the given promoted type, which are the lowest 5 bits for an int, and the
+// lowest 6 bits for a long
+func shiftMask(promoted string) int64 {
+	if promoted == "long" {
+		return 63
+	}
+	return 31
+}
+
+// ShiftDistance converts the distance of a shift into the distance that Java
+// uses, which only keeps the lowest bits of the distance, so that shifting an
+// int by 33 is the same as shifting it by 1
+//
+// Go panics when shifting by a negative number, instead of masking it, so any
+// distance that isn't a constant is masked explicitly
+func ShiftDistance(distance ast.Expr, node *sitter.Node, source []byte, promoted string) ast.Expr {
+	mask := shiftMask(promoted)
+	if value, ok := integerConstant(node, source); ok {
+		if value >= 0 && value <= mask {
+			return distance
+		}
+		return &ast.BasicLit{Kind: token.INT, Value: strconv.FormatInt(value&mask, 10)}
+	}
+	return &ast.ParenExpr{X: &ast.BinaryExpr{
+		X:  distance,
+		Op: token.AND,
+		Y:  &ast.BasicLit{Kind: token.INT, Value: strconv.FormatInt(mask, 10)},
+	}}
+}
+
+// ShiftExpr shifts a value that has already been promoted by a distance that
+// has already been masked
+//
+// Go has no unsigned right shift operator (`>>>`), so the value is shifted as
+// an unsigned number of the same width, which fills in the topmost bits with
+// zeroes, and then converted back
+func ShiftExpr(value, distance ast.Expr, operator, promoted string) ast.Expr {
+	if operator != ">>>" {
+		return &ast.BinaryExpr{X: value, Op: StrToToken(operator), Y: distance}
+	}
+
+	signed, unsigned := "int32", "uint32"
+	if promoted == "long" {
+		signed, unsigned = "int64", "uint64"
+	}
+	return callFunc(signed, &ast.BinaryExpr{
+		X:  callFunc(unsigned, value),
+		Op: token.SHR,
+		Y:  distance,
+	})
+}
+
+// shiftConstant evaluates a shift of two constants, since Go evaluates
+// constants with unlimited precision, instead of with the width of an int or a
+// long, so `1 << 31` is a positive number that doesn't fit in an int
+//
+// Returns nil if either of the operands are not integer constants
+func shiftConstant(left, right *sitter.Node, source []byte, operator, promoted string) ast.Expr {
+	value, ok := integerConstant(left, source)
+	if !ok {
+		return nil
+	}
+	distance, ok := integerConstant(right, source)
+	if !ok {
+		return nil
+	}
+	distance &= shiftMask(promoted)
+
+	var result int64
+	if promoted == "long" {
+		switch operator {
+		case "<<":
+			result = value << distance
+		case ">>":
+			result = value >> distance
+		case ">>>":
+			result = int64(uint64(value) >> distance)
+		}
+		return callFunc("int64", &ast.BasicLit{Kind: token.INT, Value: strconv.FormatInt(result, 10)})
+	}
+
+	switch operator {
+	case "<<":
+		result = int64(int32(value) << distance)
+	case ">>":
+		result = int64(int32(value) >> distance)
+	case ">>>":
+		result = int64(int32(uint32(value) >> distance))
+	}
+	if result < 0 {
+		return &ast.UnaryExpr{Op: token.SUB, X: &ast.BasicLit{Kind: token.INT, Value: strconv.FormatInt(-result, 10)}}
+	}
+	return &ast.BasicLit{Kind: token.INT, Value: strconv.FormatInt(result, 10)}
+}
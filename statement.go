@@ -7,6 +7,7 @@ import (
 
 	"github.com/NickyBoy89/java2go/astutil"
 	"github.com/NickyBoy89/java2go/nodeutil"
+	"github.com/NickyBoy89/java2go/symbol"
 	log "github.com/sirupsen/logrus"
 	sitter "github.com/smacker/go-tree-sitter"
 )
@@ -26,67 +27,16 @@ func TryParseStmt(node *sitter.Node, source []byte, ctx Ctx) ast.Stmt {
 			"className": ctx.className,
 		}).Warn("Statement parse error")
 		return &ast.BadStmt{}
-	case "comment":
+	case "comment", "line_comment", "block_comment":
 		return &ast.BadStmt{}
 	case "local_variable_declaration":
-		variableType := astutil.ParseType(node.ChildByFieldName("type"), source)
-		variableDeclarator := node.ChildByFieldName("declarator")
-
-		// If a variable is being declared, but not set to a value
-		// Ex: `int value;`
-		if variableDeclarator.NamedChildCount() == 1 {
-			return &ast.DeclStmt{
-				Decl: &ast.GenDecl{
-					Tok: token.VAR,
-					Specs: []ast.Spec{
-						&ast.ValueSpec{
-							Names: []*ast.Ident{ParseExpr(variableDeclarator.ChildByFieldName("name"), source, ctx).(*ast.Ident)},
-							Type:  variableType,
-						},
-					},
-				},
-			}
-		}
-
-		ctx.lastType = variableType
-
-		declaration := ParseStmt(variableDeclarator, source, ctx).(*ast.AssignStmt)
-
-		// Now, if a variable is assigned to `null`, we can't infer its type, so
-		// don't throw out the type information associated with it
-		var containsNull bool
-
-		// Go through the values and see if there is a `null_literal`
-		for _, child := range nodeutil.NamedChildrenOf(variableDeclarator) {
-			if child.Type() == "null_literal" {
-				containsNull = true
-				break
-			}
-		}
-
-		names := make([]*ast.Ident, len(declaration.Lhs))
-		for ind, decl := range declaration.Lhs {
-			names[ind] = decl.(*ast.Ident)
+		// Each of the variables in a declaration such as `int a = 1, b;` is
+		// declared by its own statement, in order
+		declarators := nodeutil.NamedChildrenOfType(node, "variable_declarator")
+		for _, declarator := range declarators[:len(declarators)-1] {
+			ctx.hoist(localDeclaration(node, declarator, source, ctx))
 		}
-
-		// If the declaration contains null, declare it with the `var` keyword instead
-		// of implicitly
-		if containsNull {
-			return &ast.DeclStmt{
-				Decl: &ast.GenDecl{
-					Tok: token.VAR,
-					Specs: []ast.Spec{
-						&ast.ValueSpec{
-							Names:  names,
-							Type:   variableType,
-							Values: declaration.Rhs,
-						},
-					},
-				},
-			}
-		}
-
-		return declaration
+		return localDeclaration(node, declarators[len(declarators)-1], source, ctx)
 	case "variable_declarator":
 		var names, values []ast.Expr
 
@@ -103,23 +53,17 @@ func TryParseStmt(node *sitter.Node, source []byte, ctx Ctx) ast.Stmt {
 
 		return &ast.AssignStmt{Lhs: names, Tok: token.DEFINE, Rhs: values}
 	case "assignment_expression":
-		assignVar := ParseExpr(node.Child(0), source, ctx)
-		assignVal := ParseExpr(node.Child(2), source, ctx)
-
-		// Unsigned right shift
-		if node.Child(1).Content(source) == ">>>=" {
-			return &ast.ExprStmt{X: &ast.CallExpr{
-				Fun:  &ast.Ident{Name: "UnsignedRightShiftAssignment"},
-				Args: []ast.Expr{assignVar, assignVal},
-			}}
-		}
-
-		return &ast.AssignStmt{
-			Lhs: []ast.Expr{assignVar},
-			Tok: StrToToken(node.Child(1).Content(source)),
-			Rhs: []ast.Expr{assignVal},
-		}
+		return assignmentStmt(node, ParseExpr(node.Child(0), source, ctx), source, ctx)
 	case "update_expression":
+		// A box is given a new box with the updated value
+		if holdsBox(node.NamedChild(0), source, ctx) {
+			// The box is read and then replaced, so the target is only evaluated once
+			target := ParseExpr(node.NamedChild(0), source, ctx)
+			if ctx.hoisted != nil {
+				target = ctx.stableTarget(target)
+			}
+			return boxedUpdate(target, boxedIncrement(target, node, source), ExpressionType(node.NamedChild(0), source, ctx))
+		}
 		if node.Child(0).IsNamed() {
 			return &ast.IncDecStmt{
 				X:   ParseExpr(node.Child(0), source, ctx),
@@ -148,18 +92,19 @@ func TryParseStmt(node *sitter.Node, source []byte, ctx Ctx) ast.Stmt {
 	case "constructor_body", "block":
 		body := &ast.BlockStmt{}
 		for _, line := range nodeutil.NamedChildrenOf(node) {
-			if line.Type() == "comment" {
+			if line.Type() == "comment" || line.Type() == "line_comment" || line.Type() == "block_comment" {
 				continue
 			}
-			if stmt := TryParseStmt(line, source, ctx); stmt != nil {
-				body.List = append(body.List, stmt)
-			} else {
-				// Try statements are ignored, so they return a list of statements
-				body.List = append(body.List, ParseNode(line, source, ctx).([]ast.Stmt)...)
-			}
+			body.List = append(body.List, ParseBlockStmts(line, source, ctx)...)
 		}
 		return body
 	case "expression_statement":
+		if stmt := NativeCollectionStmt(node.NamedChild(0), source, ctx); stmt != nil {
+			return stmt
+		}
+		if stmt := LoweredStreamStmt(node.NamedChild(0), source, ctx); stmt != nil {
+			return stmt
+		}
 		if stmt := TryParseStmt(node.NamedChild(0), source, ctx); stmt != nil {
 			return stmt
 		}
@@ -167,105 +112,141 @@ func TryParseStmt(node *sitter.Node, source []byte, ctx Ctx) ast.Stmt {
 	case "explicit_constructor_invocation":
 		// This is when a constructor calls another constructor with the use of
 		// something such as `this(args...)`
-		return &ast.ExprStmt{
-			&ast.CallExpr{
-				Fun:  &ast.Ident{Name: "New" + ctx.className},
-				Args: ParseNode(node.NamedChild(1), source, ctx).([]ast.Expr),
-			},
+		if node.ChildByFieldName("constructor").Type() == "super" {
+			if stmt := SuperExceptionStmt(node, source, ctx); stmt != nil {
+				return stmt
+			}
+		}
+		return &ast.ExprStmt{X: &ast.CallExpr{
+			Fun:  &ast.Ident{Name: "New" + ctx.className},
+			Args: ParseNode(node.NamedChild(1), source, ctx).([]ast.Expr),
+		},
 		}
 	case "return_statement":
 		if node.NamedChildCount() < 1 {
-			return &ast.ReturnStmt{Results: []ast.Expr{}}
+			return ctx.splitLast(ctx.returnStmts(nil))
+		}
+		// Each branch of a ternary returns its own value
+		if isTernary(node.NamedChild(0)) && ctx.hoisted != nil {
+			return ctx.splitLast(returnTernary(node.NamedChild(0), source, ctx, ctx.returnType))
 		}
-		return &ast.ReturnStmt{Results: []ast.Expr{ParseExpr(node.NamedChild(0), source, ctx)}}
+		return ctx.splitLast(ctx.returnStmts(
+			AssignmentConversion(ParseExpr(node.NamedChild(0), source, ctx), node.NamedChild(0), source, ctx, ctx.returnType),
+		))
 	case "labeled_statement":
 		return &ast.LabeledStmt{
-			Label: ParseExpr(node.NamedChild(0), source, ctx).(*ast.Ident),
+			Label: &ast.Ident{Name: node.NamedChild(0).Content(source)},
 			Stmt:  ParseStmt(node.NamedChild(1), source, ctx),
 		}
-	case "break_statement":
-		if node.NamedChildCount() > 0 {
-			return &ast.BranchStmt{Tok: token.BREAK, Label: ParseExpr(node.NamedChild(0), source, ctx).(*ast.Ident)}
-		}
-		return &ast.BranchStmt{Tok: token.BREAK}
-	case "continue_statement":
-		if node.NamedChildCount() > 0 {
-			return &ast.BranchStmt{Tok: token.CONTINUE, Label: ParseExpr(node.NamedChild(0), source, ctx).(*ast.Ident)}
-		}
-		return &ast.BranchStmt{Tok: token.CONTINUE}
+	case "break_statement", "continue_statement":
+		return ctx.splitLast(ctx.branchStmts(node, source))
 	case "throw_statement":
 		return &ast.ExprStmt{X: &ast.CallExpr{
 			Fun:  &ast.Ident{Name: "panic"},
 			Args: []ast.Expr{ParseExpr(node.NamedChild(0), source, ctx)},
 		}}
 	case "if_statement":
+		// The condition is evaluated first, so anything that it hoists runs first
+		cond := ParseExpr(node.ChildByFieldName("condition"), source, ctx)
+
 		var other ast.Stmt
 		if node.ChildByFieldName("alternative") != nil {
-			other = ParseStmt(node.ChildByFieldName("alternative"), source, ctx)
-		}
-
-		// If the `if` statement is inline, replace the line with a full block
-		var body ast.Stmt = ParseStmt(node.ChildByFieldName("consequence"), source, ctx)
-		if _, ok := body.(*ast.BlockStmt); !ok {
-			body = &ast.BlockStmt{List: []ast.Stmt{
-				body,
-			}}
+			// An `else if` stays as it is, unless its condition hoisted statements,
+			// which have to run inside of the `else`
+			stmts := ParseBlockStmts(node.ChildByFieldName("alternative"), source, ctx)
+			switch stmts[0].(type) {
+			case *ast.IfStmt, *ast.BlockStmt:
+				if len(stmts) == 1 {
+					other = stmts[0]
+				}
+			}
+			if other == nil {
+				other = &ast.BlockStmt{List: stmts}
+			}
 		}
 
 		return &ast.IfStmt{
-			Cond: ParseExpr(node.ChildByFieldName("condition"), source, ctx),
-			Body: body.(*ast.BlockStmt),
+			Cond: cond,
+			// If the `if` statement is inline, replace the line with a full block
+			Body: parseBody(node.ChildByFieldName("consequence"), source, ctx),
 			Else: other,
 		}
 	case "enhanced_for_statement":
-		// An enhanced for statement has the following fields:
-		// variables for the variable being declared (ex: int n)
-		// then the expression that is being ranged over
-		// and finally, the block of the expression
-
-		total := int(node.NamedChildCount())
-
-		return &ast.RangeStmt{
-			// We don't need the type of the variable for the range expression
-			Key:   &ast.Ident{Name: "_"},
-			Value: ParseExpr(node.NamedChild(total-3), source, ctx),
-			Tok:   token.DEFINE,
-			X:     ParseExpr(node.NamedChild(total-2), source, ctx),
-			Body:  ParseStmt(node.NamedChild(total-1), source, ctx).(*ast.BlockStmt),
-		}
+		return EnhancedFor(node, source, ctx)
 	case "for_statement":
 		var init, post ast.Stmt
+		var declared []ast.Stmt
 		if node.ChildByFieldName("init") != nil {
-			init = ParseStmt(node.ChildByFieldName("init"), source, ctx)
-		}
-		if node.ChildByFieldName("update") != nil {
-			post = ParseStmt(node.ChildByFieldName("update"), source, ctx)
+			init, declared = loopInit(node.ChildByFieldName("init"), source, ctx)
 		}
 		var cond ast.Expr
+		var hoisted []ast.Stmt
 		if node.ChildByFieldName("condition") != nil {
-			cond = ParseExpr(node.ChildByFieldName("condition"), source, ctx)
+			cond, hoisted = parseCondition(node.ChildByFieldName("condition"), source, ctx)
+		}
+		if node.ChildByFieldName("update") != nil {
+			// The update of a loop has to be a single simple statement, so any
+			// statements that it hoists, or that it is lowered into, such as the
+			// `if` statement of a ternary, are run together in a function
+			var stmts []ast.Stmt
+			for _, update := range nodeutil.ChildrenByFieldName(node, "update") {
+				stmts = append(stmts, ParseBlockStmts(update, source, ctx)...)
+			}
+			post = stmts[len(stmts)-1]
+			if len(stmts) > 1 || !isSimpleStmt(post) {
+				post = &ast.ExprStmt{X: &ast.CallExpr{Fun: &ast.FuncLit{
+					Type: &ast.FuncType{Params: &ast.FieldList{}},
+					Body: &ast.BlockStmt{List: stmts},
+				}}}
+			}
 		}
 
-		return &ast.ForStmt{
+		body := parseBody(node.ChildByFieldName("body"), source, ctx)
+		loop := &ast.ForStmt{
 			Init: init,
 			Cond: cond,
 			Post: post,
-			Body: ParseStmt(node.ChildByFieldName("body"), source, ctx).(*ast.BlockStmt),
+			Body: body,
+		}
+		if len(hoisted) > 0 {
+			loop = conditionalLoop(cond, hoisted, init, post, body)
+		}
+
+		// Variables that couldn't be declared in the loop's initializer are only
+		// visible to the loop, unless a label has to be attached to the loop itself
+		if len(declared) > 0 {
+			if node.Parent().Type() == "labeled_statement" {
+				ctx.hoist(declared...)
+				return loop
+			}
+			return &ast.BlockStmt{List: append(declared, loop)}
 		}
+		return loop
 	case "while_statement":
+		cond, hoisted := parseCondition(node.NamedChild(0), source, ctx)
+		body := parseBody(node.NamedChild(1), source, ctx)
+
+		// A condition such as `(line = reader.readLine()) != null` assigns to the
+		// variable before the condition is checked, on every iteration
+		if len(hoisted) > 0 {
+			return conditionalLoop(cond, hoisted, nil, nil, body)
+		}
+
 		return &ast.ForStmt{
-			Cond: ParseExpr(node.NamedChild(0), source, ctx),
-			Body: ParseStmt(node.NamedChild(1), source, ctx).(*ast.BlockStmt),
+			Cond: cond,
+			Body: body,
 		}
 	case "do_statement":
 		// A do statement is handled as a blank for loop with the condition
 		// inserted as a break condition in the final part of the loop
-		body := ParseStmt(node.NamedChild(0), source, ctx).(*ast.BlockStmt)
+		body := parseBody(node.NamedChild(0), source, ctx)
+		cond, hoisted := parseCondition(node.NamedChild(1), source, ctx)
 
-		body.List = append(body.List, &ast.IfStmt{
+		body.List = append(append(body.List, hoisted...), &ast.IfStmt{
 			Cond: &ast.UnaryExpr{
+				Op: token.NOT,
 				X: &ast.ParenExpr{
-					X: ParseExpr(node.NamedChild(1), source, ctx),
+					X: cond,
 				},
 			},
 			Body: &ast.BlockStmt{List: []ast.Stmt{&ast.BranchStmt{Tok: token.BREAK}}},
@@ -294,7 +275,7 @@ func TryParseStmt(node *sitter.Node, source []byte, ctx Ctx) ast.Stmt {
 				if exprs := TryParseStmts(c, source, ctx); exprs != nil {
 					currentCase.Body = append(currentCase.Body, exprs...)
 				} else {
-					currentCase.Body = append(currentCase.Body, ParseStmt(c, source, ctx))
+					currentCase.Body = append(currentCase.Body, ParseBlockStmts(c, source, ctx)...)
 				}
 			}
 		}
@@ -304,6 +285,168 @@ func TryParseStmt(node *sitter.Node, source []byte, ctx Ctx) ast.Stmt {
 	return nil
 }
 
+// loopInit parses the initializer of a `for` loop, which declares all of the
+// variables of a declaration such as `int i = 0, j = n` together, and returns
+// it along with any statements that have to be run before the loop
+func loopInit(node *sitter.Node, source []byte, ctx Ctx) (ast.Stmt, []ast.Stmt) {
+	stmts := ParseBlockStmts(node, source, ctx)
+	if len(stmts) == 1 {
+		return stmts[0], nil
+	}
+
+	// The variables can only be declared together if none of their values use
+	// any of the variables that were declared before them
+	merged := &ast.AssignStmt{Tok: token.DEFINE}
+	declared := make(map[string]bool)
+	for _, stmt := range stmts {
+		assign, ok := stmt.(*ast.AssignStmt)
+		if !ok || assign.Tok != token.DEFINE || len(assign.Lhs) != 1 || usesAny(assign.Rhs[0], declared) {
+			return stmts[len(stmts)-1], stmts[:len(stmts)-1]
+		}
+		declared[assign.Lhs[0].(*ast.Ident).Name] = true
+		merged.Lhs = append(merged.Lhs, assign.Lhs...)
+		merged.Rhs = append(merged.Rhs, assign.Rhs...)
+	}
+	return merged, nil
+}
+
+// usesAny tests if an expression refers to any of the given names
+func usesAny(expr ast.Expr, names map[string]bool) bool {
+	var uses bool
+	ast.Inspect(expr, func(node ast.Node) bool {
+		if ident, ok := node.(*ast.Ident); ok && names[ident.Name] {
+			uses = true
+		}
+		return !uses
+	})
+	return uses
+}
+
+// localDeclaration translates the declaration of one of the variables that a
+// local variable declaration declares
+func localDeclaration(node, variableDeclarator *sitter.Node, source []byte, ctx Ctx) ast.Stmt {
+	variableType := astutil.ParseType(node.ChildByFieldName("type"), source)
+
+	// The type of the variable may refer to a class that has been renamed, so
+	// prefer the resolved type from the variable's definition
+	nameNode := variableDeclarator.ChildByFieldName("name")
+	def := ctx.localScope.FindVariableAt(nameNode.Content(source), nameNode.StartByte())
+	if def != nil && def.Type != "" {
+		variableType = &ast.Ident{Name: def.Type}
+	}
+
+	// Used when generating arrays, which need the full type of the variable
+	ctx.lastType = node.ChildByFieldName("type").Content(source)
+	if def != nil && def.OriginalType != "" {
+		ctx.lastType = def.OriginalType
+	}
+
+	// A single-element array that is only used to modify a captured variable
+	// can be replaced with the element itself, since Go's closures capture
+	// variables by reference
+	if def != nil && def.CaptureBox {
+		if simplifyCaptures {
+			return captureBoxDeclaration(node, variableDeclarator, source, ctx)
+		}
+		log.WithFields(log.Fields{
+			"variable":  nameNode.Content(source),
+			"className": ctx.className,
+		}).Info("Single-element array is used to modify a captured variable, and can be simplified with -simplify-captures")
+	}
+
+	// If a variable is being declared, but not set to a value
+	// Ex: `int value;`
+	if variableDeclarator.NamedChildCount() == 1 {
+		return &ast.DeclStmt{
+			Decl: &ast.GenDecl{
+				Tok: token.VAR,
+				Specs: []ast.Spec{
+					&ast.ValueSpec{
+						Names: []*ast.Ident{ParseExpr(variableDeclarator.ChildByFieldName("name"), source, ctx).(*ast.Ident)},
+						Type:  variableType,
+					},
+				},
+			},
+		}
+	}
+
+	// A ternary is lowered into an `if` statement that assigns to the variable
+	if value := variableDeclarator.ChildByFieldName("value"); isTernary(value) && ctx.hoisted != nil {
+		name := ParseExpr(nameNode, source, ctx).(*ast.Ident)
+		ctx.hoist(&ast.DeclStmt{
+			Decl: &ast.GenDecl{
+				Tok:   token.VAR,
+				Specs: []ast.Spec{&ast.ValueSpec{Names: []*ast.Ident{name}, Type: variableType}},
+			},
+		})
+		return ctx.splitLast(assignTernary(value, name, node.ChildByFieldName("type").Content(source), source, ctx))
+	}
+
+	declaration := ParseStmt(variableDeclarator, source, ctx).(*ast.AssignStmt)
+
+	// Go infers the type of the variable from its value, so the value has to be
+	// converted to the variable's declared type
+	javaType := node.ChildByFieldName("type").Content(source)
+	declaration.Rhs[0] = DeclarationConversion(declaration.Rhs[0], variableDeclarator.ChildByFieldName("value"), source, ctx, javaType)
+
+	// Now, if a variable is assigned to `null`, we can't infer its type, so
+	// don't throw out the type information associated with it
+	var containsNull bool
+
+	// Go through the values and see if there is a `null_literal`
+	for _, child := range nodeutil.NamedChildrenOf(variableDeclarator) {
+		if child.Type() == "null_literal" {
+			containsNull = true
+			break
+		}
+	}
+
+	names := make([]*ast.Ident, len(declaration.Lhs))
+	for ind, decl := range declaration.Lhs {
+		names[ind] = decl.(*ast.Ident)
+	}
+
+	// If the declaration contains null, or the variable is declared as a
+	// supertype of its value, such as `Object o = "str"`, declare it with the
+	// `var` keyword instead of implicitly, so that it keeps its declared type
+	if containsNull || declaredAsSupertype(variableDeclarator.ChildByFieldName("value"), source, ctx, javaType) {
+		return &ast.DeclStmt{
+			Decl: &ast.GenDecl{
+				Tok: token.VAR,
+				Specs: []ast.Spec{
+					&ast.ValueSpec{
+						Names:  names,
+						Type:   variableType,
+						Values: declaration.Rhs,
+					},
+				},
+			},
+		}
+	}
+
+	return declaration
+}
+
+// declaredAsSupertype tests if a variable of a reference type is declared with
+// a value that Go would give a different type, such as a list that is
+// declared as a `List`, but is initialized with an `ArrayList`
+func declaredAsSupertype(value *sitter.Node, source []byte, ctx Ctx, javaType string) bool {
+	// Arrays are already converted to their declared types
+	if isPrimitive(javaType) || isBoxedType(javaType) || isArrayType(javaType) || javaType == "var" {
+		return false
+	}
+	goType := ctx.currentFile.GoType(javaType)
+	if goType == "" {
+		return false
+	}
+	// Anything can be assigned to an object, even if its type isn't known
+	valueType := ExpressionType(value, source, ctx)
+	if valueType == "" {
+		return goType == "any"
+	}
+	return ctx.currentFile.GoType(valueType) != goType
+}
+
 func ParseStmts(node *sitter.Node, source []byte, ctx Ctx) []ast.Stmt {
 	if stmts := TryParseStmts(node, source, ctx); stmts != nil {
 		return stmts
@@ -324,3 +467,218 @@ func TryParseStmts(node *sitter.Node, source []byte, ctx Ctx) []ast.Stmt {
 	}
 	return nil
 }
+
+// isSimpleStmt tests if a statement is one of the simple statements that Go
+// allows as the post statement of a `for` loop
+func isSimpleStmt(stmt ast.Stmt) bool {
+	switch stmt.(type) {
+	case *ast.ExprStmt, *ast.SendStmt, *ast.IncDecStmt, *ast.AssignStmt:
+		return true
+	}
+	return false
+}
+
+// captureBoxDeclaration declares the element of a single-element array, such
+// as `final int[] counter = {0}`, as a variable by itself
+func captureBoxDeclaration(node, declarator *sitter.Node, source []byte, ctx Ctx) ast.Stmt {
+	name := ParseExpr(declarator.ChildByFieldName("name"), source, ctx)
+	elementType := node.ChildByFieldName("type").ChildByFieldName("element")
+
+	// An array such as `new int[1]` starts with the zero value of its type
+	value := declarator.ChildByFieldName("value")
+	if value.Type() != "array_initializer" {
+		return &ast.DeclStmt{
+			Decl: &ast.GenDecl{
+				Tok: token.VAR,
+				Specs: []ast.Spec{
+					&ast.ValueSpec{
+						Names: []*ast.Ident{name.(*ast.Ident)},
+						Type:  astutil.ParseType(elementType, source),
+					},
+				},
+			},
+		}
+	}
+
+	element := value.NamedChild(0)
+	return &ast.AssignStmt{
+		Lhs: []ast.Expr{name},
+		Tok: token.DEFINE,
+		Rhs: []ast.Expr{DeclarationConversion(ParseExpr(element, source, ctx), element, source, ctx, elementType.Content(source))},
+	}
+}
+
+// assignmentStmt translates an assignment to a target that has already been
+// parsed, converting the value to the type of the target
+func assignmentStmt(node *sitter.Node, assignVar ast.Expr, source []byte, ctx Ctx) ast.Stmt {
+	// An element of an array of references is stored with `stdjava.Store`, since
+	// the array may be shared with an array of another type, and its target is
+	// used twice when it is added to
+	if storesElement(node.Child(0), source, ctx) {
+		if node.Child(1).Content(source) != "=" && ctx.hoisted != nil {
+			assignVar = ctx.stableTarget(assignVar)
+		}
+		elementType := ctx.currentFile.GoType(ExpressionType(node.Child(0), source, ctx))
+		return storeElement(assignValue(node, assignVar, source, ctx), elementType)
+	}
+	return assignValue(node, assignVar, source, ctx)
+}
+
+// assignValue translates the assignment of a value to a target, which may be
+// assigned in multiple statements, such as in each branch of a ternary
+func assignValue(node *sitter.Node, assignVar ast.Expr, source []byte, ctx Ctx) ast.Stmt {
+	operator := node.Child(1).Content(source)
+
+	// Targets that aren't boxes, such as the elements of a `List<Integer>`, are
+	// assigned primitive values
+	varType := ExpressionType(node.Child(0), source, ctx)
+	boxed := holdsBox(node.Child(0), source, ctx)
+	if !boxed {
+		varType = symbol.UnboxedType(varType)
+	}
+
+	// Each branch of a ternary assigns its own value to the target
+	if operator == "=" && isTernary(node.Child(2)) && ctx.hoisted != nil && !storesElement(node.Child(0), source, ctx) {
+		target := ctx.stableTarget(assignVar)
+		return ctx.splitLast(assignTernary(node.Child(2), target, varType, source, ctx))
+	}
+
+	valType := ExpressionType(node.Child(2), source, ctx)
+
+	// A compound assignment that computes the new value from the target, such
+	// as `arr[idx()] += 1.5`, uses the target twice, so its parts are only
+	// evaluated once
+	if operator != "=" && ctx.hoisted != nil && (boxed || operator == ">>>=" || narrowsResult(operator, varType, valType) || concatenatesChar(operator, varType, valType)) {
+		assignVar = ctx.stableTarget(assignVar)
+	}
+
+	// The parts of the target are evaluated before the value, and so is the
+	// current value of the target in a compound assignment
+	ev := ctx.evaluation()
+	ev.addTarget(assignVar)
+	current := assignVar
+	if operator != "=" {
+		ev.add(&current)
+	}
+	assignVal := *ev.parse(node.Child(2), source, identity)
+
+	// A compound assignment of a box is done with its value, which is put into
+	// a new box
+	if boxed && operator != "=" {
+		operator = operator[:len(operator)-1]
+		return boxedUpdate(assignVar, compoundValue(callStdjava("Unbox", current), assignVal, node, source, operator, symbol.UnboxedType(varType), valType), varType)
+	}
+
+	// If evaluating the value changed the target, then the operation uses the
+	// value that the target had before
+	if current != assignVar && operator != "=" {
+		operator = operator[:len(operator)-1]
+		return &ast.AssignStmt{
+			Lhs: []ast.Expr{assignVar},
+			Tok: token.ASSIGN,
+			Rhs: []ast.Expr{compoundValue(current, assignVal, node, source, operator, varType, valType)},
+		}
+	}
+
+	switch operator {
+	case ">>>=":
+		// Go has no unsigned right shift, so the variable is assigned the result
+		return &ast.AssignStmt{
+			Lhs: []ast.Expr{assignVar},
+			Tok: token.ASSIGN,
+			Rhs: []ast.Expr{compoundValue(assignVar, assignVal, node, source, ">>>", varType, valType)},
+		}
+	case "=":
+		assignVal = AssignmentConversion(assignVal, node.Child(2), source, ctx, varType)
+	case "<<=", ">>=":
+		// Go allows shifting by any integer type, but only the lowest bits of the
+		// distance are used
+		assignVal = ShiftDistance(assignVal, node.Child(2), source, unaryPromotion(varType))
+	default:
+		if operator == "+=" && varType == "String" {
+			// Chars are concatenated with a function, so the string is assigned
+			// the result
+			if concatenatesChar(operator, varType, valType) {
+				return &ast.AssignStmt{
+					Lhs: []ast.Expr{assignVar},
+					Tok: token.ASSIGN,
+					Rhs: []ast.Expr{compoundValue(assignVar, assignVal, node, source, "+", varType, valType)},
+				}
+			}
+			assignVal = StringConversion(assignVal, valType)
+			break
+		}
+
+		promoted := binaryPromotion(varType, valType)
+		if promoted == "" {
+			break
+		}
+
+		// A compound assignment implicitly narrows its result back into the type
+		// of the variable, such as `byteValue += 1`, so the operation has to be
+		// done in the promoted type, and then converted back
+		if narrowsResult(operator, varType, valType) {
+			return &ast.AssignStmt{
+				Lhs: []ast.Expr{assignVar},
+				Tok: token.ASSIGN,
+				Rhs: []ast.Expr{compoundValue(assignVar, assignVal, node, source, operator[:len(operator)-1], varType, valType)},
+			}
+		}
+		assignVal = PromoteOperand(assignVal, node.Child(2), source, valType, promoted)
+	}
+
+	return &ast.AssignStmt{
+		Lhs: []ast.Expr{assignVar},
+		Tok: StrToToken(operator),
+		Rhs: []ast.Expr{assignVal},
+	}
+}
+
+// narrowsResult tests if a compound assignment does its operation in a wider
+// type than its target, such as `byteValue += 1`, and converts the result back
+func narrowsResult(operator, varType, valType string) bool {
+	switch operator {
+	case "=", "<<=", ">>=", ">>>=":
+		return false
+	case "+=":
+		if varType == "String" {
+			return false
+		}
+	}
+	promoted := binaryPromotion(varType, valType)
+	return promoted != "" && primitiveTypes[promoted] != primitiveTypes[varType]
+}
+
+// concatenatesChar tests if a compound assignment concatenates a char to a
+// string, such as `text += c`
+func concatenatesChar(operator, varType, valType string) bool {
+	return operator == "+=" && varType == "String" && isCharType(valType)
+}
+
+// compoundValue computes the value that a compound assignment, such as
+// `value += 1`, assigns to its target, given the current value of the target
+func compoundValue(current, value ast.Expr, node *sitter.Node, source []byte, operator, varType, valType string) ast.Expr {
+	switch {
+	case operator == "+" && varType == "String":
+		return Concatenation(current, varType, value, valType)
+	case operator == "<<" || operator == ">>" || operator == ">>>":
+		// The variable is shifted in its promoted type, and then narrowed back
+		promoted := unaryPromotion(varType)
+		return ConvertPrimitive(ShiftExpr(
+			PromoteOperand(current, node.Child(0), source, varType, promoted),
+			ShiftDistance(value, node.Child(2), source, promoted),
+			operator,
+			promoted,
+		), promoted, varType)
+	}
+
+	promoted := binaryPromotion(varType, valType)
+	if promoted == "" {
+		return &ast.BinaryExpr{X: current, Op: StrToToken(operator), Y: value}
+	}
+	return ConvertPrimitive(&ast.BinaryExpr{
+		X:  PromoteOperand(current, node.Child(0), source, varType, promoted),
+		Op: StrToToken(operator),
+		Y:  PromoteOperand(value, node.Child(2), source, valType, promoted),
+	}, promoted, varType)
+}
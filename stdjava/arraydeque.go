@@ -0,0 +1,281 @@
+package stdjava
+
+// ArrayDeque is an implementation of Java's `ArrayDeque`, which is a deque
+// that is backed by a circular buffer
+//
+// Like Java's, it can't contain null elements
+type ArrayDeque[E any] struct {
+	abstractCollection[E]
+	// The elements start at the head, and wrap around to the start of the
+	// buffer
+	buffer        []E
+	head, size    int
+	modifications int
+}
+
+// NewArrayDeque creates an empty deque
+func NewArrayDeque[E any]() *ArrayDeque[E] {
+	deque := &ArrayDeque[E]{}
+	deque.abstractCollection = abstractCollection[E]{iterator: deque.Iterator, size: deque.Size, add: deque.Add}
+	return deque
+}
+
+// NewArrayDequeCapacity creates an empty deque with room for the given number
+// of elements
+func NewArrayDequeCapacity[E any](capacity int32) *ArrayDeque[E] {
+	deque := NewArrayDeque[E]()
+	if capacity > 0 {
+		deque.buffer = make([]E, capacity)
+	}
+	return deque
+}
+
+// NewArrayDequeFrom creates a deque that contains the elements of a collection
+func NewArrayDequeFrom[E any](values Collection[E]) *ArrayDeque[E] {
+	deque := NewArrayDeque[E]()
+	deque.AddAll(values)
+	return deque
+}
+
+// position returns the position in the buffer of the element at an index
+func (d *ArrayDeque[E]) position(index int) int {
+	return (d.head + index) % len(d.buffer)
+}
+
+// grow makes room for another element
+func (d *ArrayDeque[E]) grow() {
+	if d.size < len(d.buffer) {
+		return
+	}
+	buffer := make([]E, 2*len(d.buffer)+16)
+	for ind := 0; ind < d.size; ind++ {
+		buffer[ind] = d.buffer[d.position(ind)]
+	}
+	d.buffer, d.head = buffer, 0
+}
+
+// checkElement panics with a `NullPointerException` if an element is null
+func checkElement(value any) {
+	if isNull(value) {
+		panic(New[NullPointerException]("", nil))
+	}
+}
+
+func (d *ArrayDeque[E]) Size() int32 {
+	return int32(d.size)
+}
+
+// AddFirst panics with a `NullPointerException` if the element is null, like
+// all of the methods that add an element
+func (d *ArrayDeque[E]) AddFirst(value E) {
+	checkElement(value)
+	d.grow()
+	d.head = (d.head - 1 + len(d.buffer)) % len(d.buffer)
+	d.buffer[d.head] = value
+	d.size++
+	d.modifications++
+}
+
+func (d *ArrayDeque[E]) AddLast(value E) {
+	checkElement(value)
+	d.grow()
+	d.buffer[d.position(d.size)] = value
+	d.size++
+	d.modifications++
+}
+
+func (d *ArrayDeque[E]) Add(value E) bool {
+	d.AddLast(value)
+	return true
+}
+
+func (d *ArrayDeque[E]) OfferFirst(value E) bool {
+	d.AddFirst(value)
+	return true
+}
+
+func (d *ArrayDeque[E]) OfferLast(value E) bool {
+	d.AddLast(value)
+	return true
+}
+
+func (d *ArrayDeque[E]) Offer(value E) bool {
+	return d.OfferLast(value)
+}
+
+func (d *ArrayDeque[E]) Push(value E) {
+	d.AddFirst(value)
+}
+
+// removeAt removes the element at an index, and returns it
+func (d *ArrayDeque[E]) removeAt(index int) E {
+	removed := d.buffer[d.position(index)]
+	for ind := index; ind < d.size-1; ind++ {
+		d.buffer[d.position(ind)] = d.buffer[d.position(ind+1)]
+	}
+	var zero E
+	d.buffer[d.position(d.size-1)] = zero
+	d.size--
+	d.modifications++
+	return removed
+}
+
+// PollFirst removes the first element, or returns the zero value if the deque
+// is empty, like all of the methods that poll or peek
+func (d *ArrayDeque[E]) PollFirst() E {
+	var zero E
+	if d.size == 0 {
+		return zero
+	}
+	removed := d.buffer[d.head]
+	d.buffer[d.head] = zero
+	d.head = (d.head + 1) % len(d.buffer)
+	d.size--
+	d.modifications++
+	return removed
+}
+
+func (d *ArrayDeque[E]) PollLast() E {
+	if d.size == 0 {
+		var zero E
+		return zero
+	}
+	return d.removeAt(d.size - 1)
+}
+
+func (d *ArrayDeque[E]) Poll() E {
+	return d.PollFirst()
+}
+
+// RemoveFirst panics with a `NoSuchElementException` if the deque is empty,
+// like all of the methods that remove or get an element from either end
+func (d *ArrayDeque[E]) RemoveFirst() E {
+	if d.size == 0 {
+		noSuchElement()
+	}
+	return d.PollFirst()
+}
+
+func (d *ArrayDeque[E]) RemoveLast() E {
+	if d.size == 0 {
+		noSuchElement()
+	}
+	return d.PollLast()
+}
+
+func (d *ArrayDeque[E]) RemoveHead() E {
+	return d.RemoveFirst()
+}
+
+func (d *ArrayDeque[E]) Pop() E {
+	return d.RemoveFirst()
+}
+
+func (d *ArrayDeque[E]) PeekFirst() E {
+	if d.size == 0 {
+		var zero E
+		return zero
+	}
+	return d.buffer[d.head]
+}
+
+func (d *ArrayDeque[E]) PeekLast() E {
+	if d.size == 0 {
+		var zero E
+		return zero
+	}
+	return d.buffer[d.position(d.size-1)]
+}
+
+func (d *ArrayDeque[E]) Peek() E {
+	return d.PeekFirst()
+}
+
+func (d *ArrayDeque[E]) GetFirst() E {
+	if d.size == 0 {
+		noSuchElement()
+	}
+	return d.PeekFirst()
+}
+
+func (d *ArrayDeque[E]) GetLast() E {
+	if d.size == 0 {
+		noSuchElement()
+	}
+	return d.PeekLast()
+}
+
+func (d *ArrayDeque[E]) Element() E {
+	return d.GetFirst()
+}
+
+func (d *ArrayDeque[E]) Clear() {
+	d.buffer, d.head, d.size = nil, 0, 0
+	d.modifications++
+}
+
+// Iterator returns a fail-fast iterator over the elements, from the first
+// element to the last
+func (d *ArrayDeque[E]) Iterator() Iterator[E] {
+	return &arrayDequeIterator[E]{deque: d, last: -1, expected: d.modifications}
+}
+
+// DescendingIterator returns a fail-fast iterator over the elements, from the
+// last element to the first
+func (d *ArrayDeque[E]) DescendingIterator() Iterator[E] {
+	return &arrayDequeIterator[E]{deque: d, cursor: d.size - 1, last: -1, expected: d.modifications, descending: true}
+}
+
+// Equals compares deques by their identity, since Java's `ArrayDeque` does not
+// override `equals`
+func (d *ArrayDeque[E]) Equals(other any) bool {
+	return any(d) == other
+}
+
+func (d *ArrayDeque[E]) HashCode() int32 {
+	return identityHash(d)
+}
+
+// arrayDequeIterator is the iterator of an `ArrayDeque`, which goes in either
+// direction
+type arrayDequeIterator[E any] struct {
+	deque *ArrayDeque[E]
+	// The index of the next element, and of the last element that was returned,
+	// which is -1 if it was removed
+	cursor, last int
+	expected     int
+	descending   bool
+}
+
+func (it *arrayDequeIterator[E]) HasNext() bool {
+	return it.cursor >= 0 && it.cursor < it.deque.size
+}
+
+func (it *arrayDequeIterator[E]) Next() E {
+	checkModification(it.deque.modifications, it.expected)
+	if !it.HasNext() {
+		noSuchElement()
+	}
+	it.last = it.cursor
+	if it.descending {
+		it.cursor--
+	} else {
+		it.cursor++
+	}
+	return it.deque.buffer[it.deque.position(it.last)]
+}
+
+// Remove panics with an `IllegalStateException` if `Next` has not been called
+// since the iterator was created, or since the last element was removed
+func (it *arrayDequeIterator[E]) Remove() {
+	if it.last < 0 {
+		panic(New[IllegalStateException]("", nil))
+	}
+	checkModification(it.deque.modifications, it.expected)
+	it.deque.removeAt(it.last)
+	if !it.descending {
+		it.cursor = it.last
+	}
+	it.last = -1
+	it.expected = it.deque.modifications
+}
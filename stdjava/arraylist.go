@@ -0,0 +1,247 @@
+package stdjava
+
+import "sort"
+
+// ArrayList is an implementation of Java's `ArrayList`, which is a list that is
+// backed by a slice
+type ArrayList[E any] struct {
+	abstractCollection[E]
+	elements []E
+	// The number of times that the list has been structurally modified, which
+	// its iterators check to be fail-fast
+	modifications int
+}
+
+// NewArrayList creates an empty list
+func NewArrayList[E any]() *ArrayList[E] {
+	list := &ArrayList[E]{}
+	list.abstractCollection = abstractCollection[E]{iterator: list.Iterator, size: list.Size, add: list.Add}
+	return list
+}
+
+// NewArrayListCapacity creates an empty list with room for the given number of
+// elements
+//
+// Panics with an `IllegalArgumentException` if the capacity is negative
+func NewArrayListCapacity[E any](capacity int32) *ArrayList[E] {
+	if capacity < 0 {
+		panic(New[IllegalArgumentException]("Illegal Capacity: "+ValueOf(capacity), nil))
+	}
+	list := NewArrayList[E]()
+	list.elements = make([]E, 0, capacity)
+	return list
+}
+
+// NewArrayListFrom creates a list that contains the elements of a collection
+func NewArrayListFrom[E any](values Collection[E]) *ArrayList[E] {
+	list := NewArrayList[E]()
+	list.elements = values.ToArray()
+	return list
+}
+
+func (l *ArrayList[E]) Size() int32 {
+	return int32(len(l.elements))
+}
+
+func (l *ArrayList[E]) IsEmpty() bool {
+	return len(l.elements) == 0
+}
+
+// Get panics with an `IndexOutOfBoundsException` if the index is outside of
+// the list, like all of the methods that take an index
+func (l *ArrayList[E]) Get(index int32) E {
+	checkIndex(index, l.Size())
+	return l.elements[index]
+}
+
+// Set replaces the element at an index, and returns the element that was
+// replaced
+func (l *ArrayList[E]) Set(index int32, value E) E {
+	checkIndex(index, l.Size())
+	previous := l.elements[index]
+	l.elements[index] = value
+	return previous
+}
+
+func (l *ArrayList[E]) Add(value E) bool {
+	l.modifications++
+	l.elements = append(l.elements, value)
+	return true
+}
+
+// AddAt inserts an element at an index, which may be the end of the list
+func (l *ArrayList[E]) AddAt(index int32, value E) {
+	checkIndex(index, l.Size()+1)
+	l.modifications++
+	var zero E
+	l.elements = append(l.elements, zero)
+	copy(l.elements[index+1:], l.elements[index:])
+	l.elements[index] = value
+}
+
+// RemoveAt removes the element at an index, and returns it
+func (l *ArrayList[E]) RemoveAt(index int32) E {
+	checkIndex(index, l.Size())
+	l.modifications++
+	removed := l.elements[index]
+	copy(l.elements[index:], l.elements[index+1:])
+	var zero E
+	l.elements[len(l.elements)-1] = zero
+	l.elements = l.elements[:len(l.elements)-1]
+	return removed
+}
+
+func (l *ArrayList[E]) Remove(value any) bool {
+	if index := l.IndexOf(value); index >= 0 {
+		l.RemoveAt(index)
+		return true
+	}
+	return false
+}
+
+func (l *ArrayList[E]) Clear() {
+	l.modifications++
+	l.elements = nil
+}
+
+func (l *ArrayList[E]) AddAll(values Collection[E]) bool {
+	added := values.ToArray()
+	l.modifications++
+	l.elements = append(l.elements, added...)
+	return len(added) > 0
+}
+
+func (l *ArrayList[E]) Contains(value any) bool {
+	return l.IndexOf(value) >= 0
+}
+
+// IndexOf returns the index of the first element that is equal to the value,
+// or -1 if there isn't one
+func (l *ArrayList[E]) IndexOf(value any) int32 {
+	for ind, element := range l.elements {
+		if Equals(element, value) {
+			return int32(ind)
+		}
+	}
+	return -1
+}
+
+// LastIndexOf returns the index of the last element that is equal to the
+// value, or -1 if there isn't one
+func (l *ArrayList[E]) LastIndexOf(value any) int32 {
+	for ind := len(l.elements) - 1; ind >= 0; ind-- {
+		if Equals(l.elements[ind], value) {
+			return int32(ind)
+		}
+	}
+	return -1
+}
+
+func (l *ArrayList[E]) RemoveIf(filter func(E) bool) bool {
+	expected := l.modifications
+	kept := l.elements[:0]
+	for _, element := range l.elements {
+		if !filter(element) {
+			kept = append(kept, element)
+		}
+		checkModification(l.modifications, expected)
+	}
+	removed := len(kept) != len(l.elements)
+	if removed {
+		l.modifications++
+		var zero E
+		for ind := len(kept); ind < len(l.elements); ind++ {
+			l.elements[ind] = zero
+		}
+		l.elements = kept
+	}
+	return removed
+}
+
+// Sort sorts the list with a stable sort, like Java does
+func (l *ArrayList[E]) Sort(compare func(a, b E) int32) {
+	expected := l.modifications
+	sort.SliceStable(l.elements, func(i, j int) bool {
+		if compare == nil {
+			return Compare(l.elements[i], l.elements[j]) < 0
+		}
+		return compare(l.elements[i], l.elements[j]) < 0
+	})
+	checkModification(l.modifications, expected)
+	l.modifications++
+}
+
+// ReplaceAll replaces each element with the result of a function
+func (l *ArrayList[E]) ReplaceAll(operator func(E) E) {
+	expected := l.modifications
+	for ind := range l.elements {
+		l.elements[ind] = operator(l.elements[ind])
+		checkModification(l.modifications, expected)
+	}
+	l.modifications++
+}
+
+// ForEach runs an action for each of the elements
+//
+// Panics with a `ConcurrentModificationException` if the action modifies the
+// list
+func (l *ArrayList[E]) ForEach(action func(E)) {
+	expected := l.modifications
+	for ind := 0; ind < len(l.elements) && l.modifications == expected; ind++ {
+		action(l.elements[ind])
+	}
+	checkModification(l.modifications, expected)
+}
+
+// ToArray returns a copy of the elements
+func (l *ArrayList[E]) ToArray() []E {
+	return append([]E{}, l.elements...)
+}
+
+// Iterator returns a fail-fast iterator over the elements
+func (l *ArrayList[E]) Iterator() Iterator[E] {
+	return &arrayListIterator[E]{list: l, last: -1, expected: l.modifications}
+}
+
+func (l *ArrayList[E]) Equals(other any) bool {
+	return listEquals[E](l, other)
+}
+
+func (l *ArrayList[E]) HashCode() int32 {
+	return listHashCode[E](l)
+}
+
+// arrayListIterator is the iterator of an `ArrayList`
+type arrayListIterator[E any] struct {
+	list *ArrayList[E]
+	// The index of the next element, and of the last element that was returned,
+	// which is -1 if it was removed
+	cursor, last int32
+	expected     int
+}
+
+func (it *arrayListIterator[E]) HasNext() bool {
+	return it.cursor != it.list.Size()
+}
+
+func (it *arrayListIterator[E]) Next() E {
+	checkModification(it.list.modifications, it.expected)
+	if it.cursor >= it.list.Size() {
+		noSuchElement()
+	}
+	it.last = it.cursor
+	it.cursor++
+	return it.list.elements[it.last]
+}
+
+// Remove panics with an `IllegalStateException` if `Next` has not been called
+// since the iterator was created, or since the last element was removed
+func (it *arrayListIterator[E]) Remove() {
+	if it.last < 0 {
+		panic(New[IllegalStateException]("", nil))
+	}
+	checkModification(it.list.modifications, it.expected)
+	it.list.RemoveAt(it.last)
+	it.cursor, it.last = it.last, -1
+	it.expected = it.list.modifications
+}
@@ -0,0 +1,155 @@
+package stdjava
+
+import (
+	"fmt"
+	"runtime"
+	"sync"
+	"unsafe"
+	"weak"
+
+	"golang.org/x/exp/constraints"
+)
+
+// CloneArray copies an array, which is what calling `clone` on a Java array
+// does
+//
+// The copy is shallow, so the arrays inside of a multi-dimensional array are
+// shared with the original
+func CloneArray[T any](arr []T) []T {
+	return append(make([]T, 0, len(arr)), arr...)
+}
+
+// Java's arrays are covariant, so a `String[]` can be used as an `Object[]`,
+// and both refer to the same elements. Go's slices can't be converted into
+// slices of another type, so each type that an array is used as is a separate
+// slice, which is a view of the same array. Storing an element into an array
+// of references stores it into every view of the array, which keeps them the
+// same
+
+// An arrayView is one of the slices that represent the same Java array
+//
+// Views refer to their slices weakly, so a view doesn't keep the slice alive
+// once the program no longer uses it
+type arrayView struct {
+	// key is the weak pointer to the first element of the view's slice
+	key any
+	// accepts tests if a value can be stored in the view's slice
+	accepts func(value any) bool
+	// store stores a value into an element of the view's slice, if the slice
+	// is still alive
+	store func(ind int, value any)
+}
+
+// arrayViews maps the first element of each slice that is a view of an array
+// to all the views of that array, which is guarded by arrayViewsLock
+//
+// The keys are weak pointers, and are removed when their slices are collected
+var (
+	arrayViews     = make(map[any]*[]arrayView)
+	arrayViewsLock sync.Mutex
+)
+
+// viewOf creates a view of a non-empty slice
+func viewOf[T any](arr []T) arrayView {
+	first, length := weak.Make(&arr[0]), len(arr)
+	return arrayView{
+		key: first,
+		accepts: func(value any) bool {
+			_, ok := value.(T)
+			return ok || isNull(value)
+		},
+		store: func(ind int, value any) {
+			if element := first.Value(); element != nil {
+				unsafe.Slice(element, length)[ind] = Cast[T](value)
+			}
+		},
+	}
+}
+
+// shareView adds a view of a slice to the views of an array, and removes it
+// once the slice is collected
+//
+// arrayViewsLock must be held
+func shareView[T any](arr []T, views *[]arrayView) {
+	view := viewOf(arr)
+	*views = append(*views, view)
+	arrayViews[view.key] = views
+	runtime.AddCleanup(&arr[0], removeView, view.key)
+}
+
+// removeView removes the view of a slice that has been collected
+func removeView(key any) {
+	arrayViewsLock.Lock()
+	defer arrayViewsLock.Unlock()
+
+	views := arrayViews[key]
+	delete(arrayViews, key)
+	for ind, view := range *views {
+		if view.key == key {
+			*views = append((*views)[:ind], (*views)[ind+1:]...)
+			break
+		}
+	}
+}
+
+// CovariantArray converts an array into an array of one of its element's
+// supertypes, such as a `String[]` into an `Object[]`, which Java allows, but
+// Go does not, as well as casting an array back into an array of a subtype
+//
+// The new array is a view of the same array, so storing an element into either
+// of them with `Store` changes both of them
+//
+// Panics with a `ClassCastException` if any of the elements are not instances
+// of the new type
+func CovariantArray[U, T any](arr []T) []U {
+	if arr == nil {
+		return nil
+	}
+	converted := make([]U, len(arr))
+	for ind, value := range arr {
+		converted[ind] = Cast[U](value)
+	}
+
+	// Empty arrays don't have any elements to share
+	if len(arr) > 0 {
+		arrayViewsLock.Lock()
+		defer arrayViewsLock.Unlock()
+
+		views, shared := arrayViews[weak.Make(&arr[0])]
+		if !shared {
+			views = &[]arrayView{}
+			shareView(arr, views)
+		}
+		shareView(converted, views)
+	}
+	return converted
+}
+
+// Store stores a value into an element of an array of references, which is
+// also stored into every other view of the array that was created with
+// `CovariantArray`
+//
+// Panics with an `ArrayStoreException` if the value can't be stored into any
+// of the views, such as storing an `Integer` into a `String[]` through an
+// `Object[]`
+func Store[T any, I constraints.Integer](arr []T, index I, value T) {
+	// Indexes that are out of bounds panic the same way as any other index
+	_ = arr[index]
+
+	arrayViewsLock.Lock()
+	defer arrayViewsLock.Unlock()
+
+	views, shared := arrayViews[weak.Make(&arr[0])]
+	if !shared {
+		arr[index] = value
+		return
+	}
+	for _, view := range *views {
+		if !view.accepts(value) {
+			panic(New[ArrayStoreException](fmt.Sprintf("%T", value), nil))
+		}
+	}
+	for _, view := range *views {
+		view.store(int(index), value)
+	}
+}
@@ -0,0 +1,101 @@
+package stdjava
+
+import (
+	"runtime"
+	"testing"
+	"time"
+)
+
+func TestCloneArray(t *testing.T) {
+	arr := []int32{1, 2, 3}
+	clone := CloneArray(arr)
+	clone[0] = 4
+	if arr[0] != 1 {
+		t.Errorf("Changing the clone changed the original to %d", arr[0])
+	}
+	if len(clone) != 3 || clone[2] != 3 {
+		t.Errorf("Got %v, expected [4 2 3]", clone)
+	}
+}
+
+func TestCloneEmptyArray(t *testing.T) {
+	if clone := CloneArray([]int32{}); clone == nil {
+		t.Errorf("Cloning an empty array returned nil")
+	}
+}
+
+type shape interface{ sides() int }
+type square struct{}
+
+func (square) sides() int { return 4 }
+
+func TestCovariantArray(t *testing.T) {
+	objects := CovariantArray[any]([]string{"a", "b"})
+	if len(objects) != 2 || objects[1] != "b" {
+		t.Errorf("Got %v, expected [a b]", objects)
+	}
+
+	shapes := CovariantArray[shape]([]*square{&square{}, nil})
+	if shapes[0].sides() != 4 {
+		t.Errorf("Got %d sides, expected %d", shapes[0].sides(), 4)
+	}
+	if CovariantArray[any]([]string(nil)) != nil {
+		t.Errorf("Converting a null array did not return nil")
+	}
+}
+
+func TestCovariantArrayShared(t *testing.T) {
+	words := []string{"x", "y"}
+	objects := CovariantArray[any](words)
+	Store(objects, 0, any("z"))
+	Store(words, int32(1), "w")
+	if words[0] != "z" || objects[1] != "w" {
+		t.Errorf("Expected both arrays to be changed, got %v and %v", words, objects)
+	}
+
+	// A cast back into the original type is a view of the same array
+	back := CovariantArray[string](objects)
+	Store(back, 0, "v")
+	if words[0] != "v" || objects[0] != "v" {
+		t.Errorf("Expected the cast array to share its elements, got %v and %v", words, objects)
+	}
+
+	defer func() {
+		if _, ok := recover().(ArrayStoreException); !ok {
+			t.Errorf("Expected an ArrayStoreException")
+		}
+		if words[1] != "w" {
+			t.Errorf("Expected the failed store to not change the array, got %v", words)
+		}
+	}()
+	Store(objects, 1, any(int32(1)))
+}
+
+func TestCovariantArrayCast(t *testing.T) {
+	defer func() {
+		if _, ok := recover().(ClassCastException); !ok {
+			t.Errorf("Expected a ClassCastException")
+		}
+	}()
+	CovariantArray[string]([]any{"a", int32(1)})
+}
+
+func TestCovariantArrayCollected(t *testing.T) {
+	func() {
+		words := []string{"x", "y"}
+		Store(CovariantArray[any](words), 0, any("z"))
+	}()
+
+	// The views are removed in the background once both arrays are collected
+	for attempt := 0; attempt < 100; attempt++ {
+		runtime.GC()
+		arrayViewsLock.Lock()
+		remaining := len(arrayViews)
+		arrayViewsLock.Unlock()
+		if remaining == 0 {
+			return
+		}
+		time.Sleep(time.Millisecond)
+	}
+	t.Errorf("Expected the views of the collected arrays to be removed")
+}
@@ -0,0 +1,263 @@
+package stdjava
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+	"unsafe"
+
+	"golang.org/x/exp/constraints"
+)
+
+// Java's boxed types, such as `Integer`, are pointers to their primitive
+// values, where a nil pointer is `null`
+//
+// The same as in Java, boxing a small value gives the same box every time, so
+// comparing boxes with `==` is only true for small values, or the same box
+
+// The boxes that are shared by the values from -128 to 127, which is the range
+// that Java caches for `Byte`, `Short`, `Integer` and `Long`, as well as the
+// chars up to 127, and both booleans
+var (
+	byteCache  [256]int8
+	shortCache [256]int16
+	intCache   [256]int32
+	longCache  [256]int64
+	charCache  [128]uint16
+	boolCache  = [2]bool{false, true}
+)
+
+func init() {
+	for ind := range intCache {
+		byteCache[ind] = int8(ind - 128)
+		shortCache[ind] = int16(ind - 128)
+		intCache[ind] = int32(ind - 128)
+		longCache[ind] = int64(ind - 128)
+	}
+	for ind := range charCache {
+		charCache[ind] = uint16(ind)
+	}
+}
+
+// Box is an implementation of Java's autoboxing, which is what `valueOf` does
+// for each of the boxed types, and puts a primitive value into a new box, or
+// into the box that is shared by that value
+//
+// Chars are represented as runes, so they share the boxes of ints, which
+// doesn't change the result of comparing boxes of the same type
+func Box[T any](value T) *T {
+	var box any
+	switch v := any(value).(type) {
+	case int8:
+		box = &byteCache[int(v)+128]
+	case int16:
+		if -128 <= v && v <= 127 {
+			box = &shortCache[v+128]
+		}
+	case int32:
+		if -128 <= v && v <= 127 {
+			box = &intCache[v+128]
+		}
+	case int64:
+		if -128 <= v && v <= 127 {
+			box = &longCache[v+128]
+		}
+	case uint16:
+		// Chars are only cached up to 127, since they are unsigned
+		if v <= 127 {
+			box = &charCache[v]
+		}
+	case bool:
+		if v {
+			box = &boolCache[1]
+		} else {
+			box = &boolCache[0]
+		}
+	}
+	if box != nil {
+		return box.(*T)
+	}
+	return &value
+}
+
+// Unbox is an implementation of Java's unboxing, which returns the value in a
+// box
+//
+// Panics with a `NullPointerException` if the box is null
+func Unbox[T any](box *T) T {
+	if box == nil {
+		panic(New[NullPointerException]("", nil))
+	}
+	return *box
+}
+
+// BoxedValue converts a box into a value that is assigned to an `Object`,
+// which holds the primitive value itself, or nil for `null`
+func BoxedValue[T any](box *T) any {
+	if box == nil {
+		return nil
+	}
+	return *box
+}
+
+// CastBox is an implementation of casting an `Object` to one of the boxed
+// types, such as `(Integer) value`, where the object holds a primitive value
+//
+// Panics with a `ClassCastException` if the object holds anything else
+func CastBox[T any](value any) *T {
+	switch v := value.(type) {
+	case nil:
+		return nil
+	case T:
+		return Box(v)
+	case *T:
+		return v
+	}
+	var zero T
+	panic(New[ClassCastException](fmt.Sprintf("class %T cannot be cast to class %T", value, zero), nil))
+}
+
+// CharValueOf converts a `Character` into a string, which is either a char, or
+// a box of a char, where null is "null"
+//
+// Chars can't be told apart from other numbers at runtime, so they aren't
+// converted by `ValueOf`
+func CharValueOf[C Char](value any) string {
+	switch v := value.(type) {
+	case C:
+		return CharString(v)
+	case *C:
+		if v != nil {
+			return CharString(*v)
+		}
+	}
+	return "null"
+}
+
+// The limits of the boxed types, such as `Integer.MAX_VALUE`, which are
+// variables, so that arithmetic with them overflows the same way as in Java,
+// instead of being a constant that can't overflow
+var (
+	MinByte   int8    = math.MinInt8
+	MaxByte   int8    = math.MaxInt8
+	MinShort  int16   = math.MinInt16
+	MaxShort  int16   = math.MaxInt16
+	MinInt    int32   = math.MinInt32
+	MaxInt    int32   = math.MaxInt32
+	MinLong   int64   = math.MinInt64
+	MaxLong   int64   = math.MaxInt64
+	MinFloat  float32 = math.SmallestNonzeroFloat32
+	MaxFloat  float32 = math.MaxFloat32
+	MinDouble float64 = math.SmallestNonzeroFloat64
+	MaxDouble float64 = math.MaxFloat64
+)
+
+// CompareNumbers is an implementation of the `compare` methods of the boxed
+// numbers, such as `Integer.compare`, where floating point numbers are compared
+// the same way as `Double.compare`
+func CompareNumbers[T constraints.Integer | constraints.Float](a, b T) int32 {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	case a == b && a != 0:
+		return 0
+	}
+	// Zero may be negative, and NaN is not equal to itself
+	return compareFloat(float64(a), float64(b))
+}
+
+// ParseInteger is an implementation of the methods that parse integers, such as
+// `Integer.parseInt` and `Long.parseLong`, which accept an optional sign and
+// the digits of the given radix
+//
+// Panics with a `NumberFormatException` if the string is not a number, or if it
+// is out of range
+func ParseInteger[T int8 | int16 | int32 | int64](s string, radix int32) T {
+	bits := int(unsafe.Sizeof(T(0))) * 8
+	value, err := strconv.ParseInt(s, int(radix), bits)
+	if err == nil {
+		return T(value)
+	}
+
+	var message string
+	switch {
+	case s == "":
+		message = "For input string: \"\""
+	case bits < 32 && err.(*strconv.NumError).Err == strconv.ErrRange:
+		// Bytes and shorts are parsed as ints, which are then checked
+		message = fmt.Sprintf("Value out of range. Value:\"%s\" Radix:%d", s, radix)
+	default:
+		message = fmt.Sprintf("For input string: \"%s\"", s)
+		if radix != 10 {
+			message += fmt.Sprintf(" under radix %d", radix)
+		}
+	}
+	panic(New[NumberFormatException](message, nil))
+}
+
+// ParseDouble is an implementation of `Double.parseDouble`, which ignores any
+// whitespace around the number, and allows a suffix of `d` or `f`
+//
+// Panics with a `NumberFormatException` if the string is not a number
+func ParseDouble(s string) float64 {
+	return parseFloating(s, 64)
+}
+
+// ParseFloat is an implementation of `Float.parseFloat`, which rounds the
+// number directly to a float
+func ParseFloat(s string) float32 {
+	return float32(parseFloating(s, 32))
+}
+
+func parseFloating(s string, bits int) float64 {
+	trimmed := strings.TrimFunc(s, func(char rune) bool { return char <= ' ' })
+	number := trimmed
+	if number != "" && strings.ContainsRune("dDfF", rune(number[len(number)-1])) {
+		number = number[:len(number)-1]
+	}
+
+	// Java only accepts these spellings of infinity and NaN, which Go's are
+	// case-insensitive versions of
+	unsigned := strings.TrimLeft(number, "+-")
+	valid := trimmed != "" && len(number)-len(unsigned) <= 1
+	if lower := strings.ToLower(unsigned); strings.HasPrefix(lower, "inf") || lower == "nan" {
+		valid = valid && (unsigned == "Infinity" || unsigned == "NaN") && number == trimmed
+	}
+
+	value, err := strconv.ParseFloat(number, bits)
+	if err != nil && err.(*strconv.NumError).Err == strconv.ErrRange {
+		// Numbers that are too large are infinite, and too small are zero
+		err = nil
+	}
+	if !valid || err != nil {
+		if trimmed == "" {
+			panic(New[NumberFormatException]("empty String", nil))
+		}
+		panic(New[NumberFormatException](fmt.Sprintf("For input string: \"%s\"", s), nil))
+	}
+	return value
+}
+
+// isBox tests if a value is one of the boxed types, which point to a boolean or
+// a number
+func isBox(value reflect.Value) bool {
+	if value.Kind() != reflect.Pointer {
+		return false
+	}
+	kind := value.Type().Elem().Kind()
+	return reflect.Bool <= kind && kind <= reflect.Float64
+}
+
+// unboxed returns the value in a box that is used as an object, such as an
+// element of a `List<Integer>`, so that it is compared and hashed by its value,
+// the same as in Java, and returns any other value as it is
+func unboxed(value any) any {
+	if reflected := reflect.ValueOf(value); isBox(reflected) && !reflected.IsNil() {
+		return reflected.Elem().Interface()
+	}
+	return value
+}
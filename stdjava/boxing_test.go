@@ -0,0 +1,133 @@
+package stdjava
+
+import (
+	"math"
+	"testing"
+)
+
+func TestBoxCache(t *testing.T) {
+	if Box[int32](127) != Box[int32](127) || Box[int32](-128) != Box[int32](-128) {
+		t.Errorf("Expected small ints to share the same box")
+	}
+	if Box[int32](128) == Box[int32](128) || Box(1.0) == Box(1.0) {
+		t.Errorf("Expected large ints and doubles to be boxed separately")
+	}
+	if Box(true) != Box(true) || Box[int8](-100) != Box[int8](-100) || Box[uint16](200) == Box[uint16](200) {
+		t.Errorf("Expected booleans and bytes to always be cached, and chars to be cached up to 127")
+	}
+	if *Box[int64](5) != 5 || *Box[int64](1000) != 1000 {
+		t.Errorf("Expected the boxes to contain their values")
+	}
+}
+
+func TestUnboxNull(t *testing.T) {
+	defer func() {
+		if !InstanceOf[NullPointerException](recover()) {
+			t.Errorf("Expected a NullPointerException")
+		}
+	}()
+	Unbox[int32](nil)
+}
+
+func TestBoxedObjects(t *testing.T) {
+	if BoxedValue[int32](nil) != nil || BoxedValue(Box[int32](3)) != int32(3) {
+		t.Errorf("Expected boxes to be objects that hold their values")
+	}
+	if CastBox[int32](nil) != nil || *CastBox[int32](int32(3)) != 3 {
+		t.Errorf("Expected objects to be cast back into boxes")
+	}
+	if ValueOf(Box[int32](3)) != "3" || ValueOf((*float64)(nil)) != "null" || ValueOf(Box(1.0)) != "1.0" {
+		t.Errorf("Expected boxes to be formatted as their values")
+	}
+	if formatted := Format("%d %s %b", Box[int64](4), Box(true), (*bool)(nil)); formatted != "4 true false" {
+		t.Errorf("Expected boxes to be formatted as their values, got %s", formatted)
+	}
+}
+
+func TestBoxedElements(t *testing.T) {
+	counts := NewHashMap[*int32, *int32]()
+	counts.Put(Box[int32](1000), Box[int32](1))
+	counts.Put(Box[int32](7), nil)
+	if Unbox(counts.Get(Box[int32](1000))) != 1 || counts.Get(Box[int32](5)) != nil || !counts.ContainsKey(int32(1000)) {
+		t.Errorf("Expected boxed keys to be compared by their values")
+	}
+	if !counts.ContainsKey(Box[int32](7)) || counts.Get(Box[int32](7)) != nil {
+		t.Errorf("Expected a key to be mapped to null")
+	}
+	if Compare(Box[int32](1000), Box[int32](2000)) != -1 || HashOf(Box[int64](5)) != HashOf(int64(5)) {
+		t.Errorf("Expected boxes to be compared and hashed by their values")
+	}
+}
+
+func TestBoxCastException(t *testing.T) {
+	defer func() {
+		if _, ok := recover().(ClassCastException); !ok {
+			t.Errorf("Expected a ClassCastException")
+		}
+	}()
+	CastBox[int32]("string")
+}
+
+func TestCompareNumbers(t *testing.T) {
+	if CompareNumbers[int32](1, 2) != -1 || CompareNumbers[int64](MaxLong, MinLong) != 1 || CompareNumbers[int8](0, 0) != 0 {
+		t.Errorf("Expected the integers to be compared by their values")
+	}
+	if CompareNumbers(math.Copysign(0, -1), 0) != -1 || CompareNumbers(math.NaN(), math.Inf(1)) != 1 || CompareNumbers(math.NaN(), math.NaN()) != 0 {
+		t.Errorf("Expected the doubles to be compared like Double.compare")
+	}
+}
+
+func TestParseInteger(t *testing.T) {
+	if ParseInteger[int32]("-123", 10) != -123 || ParseInteger[int32]("+7f", 16) != 127 {
+		t.Errorf("Expected the ints to be parsed")
+	}
+	if ParseInteger[int64]("9223372036854775807", 10) != math.MaxInt64 || ParseInteger[int8]("-128", 10) != -128 {
+		t.Errorf("Expected the largest and smallest values to be parsed")
+	}
+
+	for _, test := range []struct {
+		parse   func()
+		message string
+	}{
+		{func() { ParseInteger[int32]("2147483648", 10) }, "For input string: \"2147483648\""},
+		{func() { ParseInteger[int32]("zz", 16) }, "For input string: \"zz\" under radix 16"},
+		{func() { ParseInteger[int32](" 1", 10) }, "For input string: \" 1\""},
+		{func() { ParseInteger[int8]("200", 10) }, "Value out of range. Value:\"200\" Radix:10"},
+		{func() { ParseInteger[int64]("", 10) }, "For input string: \"\""},
+	} {
+		func() {
+			defer func() {
+				if exception, ok := recover().(NumberFormatException); !ok || exception.GetMessage() != test.message {
+					t.Errorf("Expected a NumberFormatException with the message %s, got %v", test.message, exception)
+				}
+			}()
+			test.parse()
+		}()
+	}
+}
+
+func TestParseDouble(t *testing.T) {
+	if ParseDouble(" 1.5d\n") != 1.5 || ParseDouble("-Infinity") != math.Inf(-1) || !math.IsNaN(ParseDouble("NaN")) {
+		t.Errorf("Expected the doubles to be parsed")
+	}
+	if ParseFloat("0.1") != 0.1 || ParseDouble("1e400") != math.Inf(1) {
+		t.Errorf("Expected the numbers to be rounded to their types")
+	}
+
+	for _, invalid := range []string{"", "inf", "1.0.0", "--1", "nan"} {
+		func() {
+			defer func() {
+				if _, ok := recover().(NumberFormatException); !ok {
+					t.Errorf("Expected %q to be invalid", invalid)
+				}
+			}()
+			ParseDouble(invalid)
+		}()
+	}
+}
+
+func TestCharValueOf(t *testing.T) {
+	if CharValueOf[rune]('x') != "x" || CharValueOf[rune](Box('y')) != "y" || CharValueOf[uint16]((*uint16)(nil)) != "null" {
+		t.Errorf("Expected chars and their boxes to be converted into strings")
+	}
+}
@@ -0,0 +1,346 @@
+package stdjava
+
+import (
+	"strings"
+)
+
+// Java's collections from `java.util` are implemented as generic types, where
+// each of Java's interfaces, such as `List`, is a Go interface, and each of the
+// classes, such as `ArrayList`, is a pointer to a struct
+//
+// Java's methods that take an `Object`, such as `contains` and `remove`, take
+// `any`, and compare their values with `Equals`, which calls a value's `Equals`
+// method, like Java does
+//
+// Methods that would return null, such as `Map.get` when the map does not
+// contain a key, return the zero value of their type
+
+// Iterator is Java's `Iterator`, which goes through each of the elements of a
+// collection once
+//
+// The iterators of the collections are fail-fast, so they panic with a
+// `ConcurrentModificationException` if their collection is modified by anything
+// other than the iterator itself
+type Iterator[E any] interface {
+	HasNext() bool
+	// Next panics with a `NoSuchElementException` if there are no more elements
+	Next() E
+	// Remove removes the element that was last returned by `Next`
+	Remove()
+}
+
+// Iterable is Java's `Iterable`, which is anything that can be iterated over
+type Iterable[E any] interface {
+	Iterator() Iterator[E]
+	ForEach(action func(E))
+}
+
+// Collection is Java's `Collection`, which is the interface that all of the
+// collections except for maps implement
+type Collection[E any] interface {
+	Iterable[E]
+	Size() int32
+	IsEmpty() bool
+	Contains(value any) bool
+	Add(value E) bool
+	Remove(value any) bool
+	Clear()
+	AddAll(values Collection[E]) bool
+	ContainsAll(values Collection[E]) bool
+	RemoveAll(values Collection[E]) bool
+	RetainAll(values Collection[E]) bool
+	RemoveIf(filter func(E) bool) bool
+	ToArray() []E
+	Equals(other any) bool
+	HashCode() int32
+	String() string
+}
+
+// List is Java's `List`, which is a collection that keeps its elements in order
+//
+// Java overloads `add` and `remove` to take an index, which are `AddAt` and
+// `RemoveAt`
+type List[E any] interface {
+	Collection[E]
+	Get(index int32) E
+	Set(index int32, value E) E
+	AddAt(index int32, value E)
+	RemoveAt(index int32) E
+	IndexOf(value any) int32
+	LastIndexOf(value any) int32
+	// Sort sorts the list with a comparison function, or by the natural order of
+	// its elements if it is nil
+	Sort(compare func(a, b E) int32)
+	ReplaceAll(operator func(E) E)
+}
+
+// Set is Java's `Set`, which is a collection that contains each element once
+type Set[E any] interface {
+	Collection[E]
+}
+
+// Queue is Java's `Queue`, which is a collection that elements are added to,
+// and removed from its head
+//
+// Java's `remove` method without any arguments is `RemoveHead`
+type Queue[E any] interface {
+	Collection[E]
+	Offer(value E) bool
+	Poll() E
+	Peek() E
+	Element() E
+	RemoveHead() E
+}
+
+// Deque is Java's `Deque`, which is a queue that can be added to and removed
+// from at both ends, and is also used as a stack
+type Deque[E any] interface {
+	Queue[E]
+	AddFirst(value E)
+	AddLast(value E)
+	OfferFirst(value E) bool
+	OfferLast(value E) bool
+	RemoveFirst() E
+	RemoveLast() E
+	PollFirst() E
+	PollLast() E
+	GetFirst() E
+	GetLast() E
+	PeekFirst() E
+	PeekLast() E
+	Push(value E)
+	Pop() E
+	DescendingIterator() Iterator[E]
+}
+
+// Map is Java's `Map`, which maps keys to values
+//
+// The sets and collections that are returned by `KeySet`, `Values` and
+// `EntrySet` are views of the map, like they are in Java, so removing from
+// them removes from the map
+type Map[K, V any] interface {
+	Size() int32
+	IsEmpty() bool
+	Get(key any) V
+	GetOrDefault(key any, defaultValue V) V
+	ContainsKey(key any) bool
+	ContainsValue(value any) bool
+	Put(key K, value V) V
+	PutIfAbsent(key K, value V) V
+	PutAll(other Map[K, V])
+	Remove(key any) V
+	Clear()
+	KeySet() Set[K]
+	Values() Collection[V]
+	EntrySet() Set[Entry[K, V]]
+	ForEach(action func(K, V))
+	// ComputeIfAbsent puts the value that the function returns if the map does
+	// not contain the key, and returns the key's value
+	ComputeIfAbsent(key K, mapping func(K) V) V
+	// Merge puts the value if the map does not contain the key, and otherwise
+	// combines it with the key's current value
+	Merge(key K, value V, remapping func(V, V) V) V
+	Equals(other any) bool
+	HashCode() int32
+	String() string
+}
+
+// Entry is Java's `Map.Entry`, which is a key and its value in a map
+type Entry[K, V any] interface {
+	GetKey() K
+	GetValue() V
+	SetValue(value V) V
+	Equals(other any) bool
+	HashCode() int32
+	String() string
+}
+
+// abstractCollection implements the methods of a collection that can be
+// written in terms of its iterator, like Java's `AbstractCollection`, which a
+// collection embeds, and then replaces any methods that it can do faster
+type abstractCollection[E any] struct {
+	iterator func() Iterator[E]
+	size     func() int32
+	// Collections that can't be added to, such as the views of a map, leave
+	// this as nil
+	add func(E) bool
+}
+
+func (c abstractCollection[E]) IsEmpty() bool {
+	return c.size() == 0
+}
+
+func (c abstractCollection[E]) Contains(value any) bool {
+	for it := c.iterator(); it.HasNext(); {
+		if Equals(it.Next(), value) {
+			return true
+		}
+	}
+	return false
+}
+
+// Add panics with an `UnsupportedOperationException` if the collection can't
+// be added to
+func (c abstractCollection[E]) Add(value E) bool {
+	if c.add == nil {
+		panic(New[UnsupportedOperationException]("", nil))
+	}
+	return c.add(value)
+}
+
+func (c abstractCollection[E]) Remove(value any) bool {
+	for it := c.iterator(); it.HasNext(); {
+		if Equals(it.Next(), value) {
+			it.Remove()
+			return true
+		}
+	}
+	return false
+}
+
+func (c abstractCollection[E]) Clear() {
+	for it := c.iterator(); it.HasNext(); {
+		it.Next()
+		it.Remove()
+	}
+}
+
+func (c abstractCollection[E]) AddAll(values Collection[E]) bool {
+	var changed bool
+	// The values are copied first, in case they are being added to themselves
+	for _, value := range values.ToArray() {
+		if c.Add(value) {
+			changed = true
+		}
+	}
+	return changed
+}
+
+func (c abstractCollection[E]) ContainsAll(values Collection[E]) bool {
+	for it := values.Iterator(); it.HasNext(); {
+		if !c.Contains(it.Next()) {
+			return false
+		}
+	}
+	return true
+}
+
+func (c abstractCollection[E]) RemoveAll(values Collection[E]) bool {
+	return c.RemoveIf(func(value E) bool { return values.Contains(value) })
+}
+
+func (c abstractCollection[E]) RetainAll(values Collection[E]) bool {
+	return c.RemoveIf(func(value E) bool { return !values.Contains(value) })
+}
+
+func (c abstractCollection[E]) RemoveIf(filter func(E) bool) bool {
+	var removed bool
+	for it := c.iterator(); it.HasNext(); {
+		if filter(it.Next()) {
+			it.Remove()
+			removed = true
+		}
+	}
+	return removed
+}
+
+func (c abstractCollection[E]) ForEach(action func(E)) {
+	for it := c.iterator(); it.HasNext(); {
+		action(it.Next())
+	}
+}
+
+func (c abstractCollection[E]) ToArray() []E {
+	values := make([]E, 0, c.size())
+	for it := c.iterator(); it.HasNext(); {
+		values = append(values, it.Next())
+	}
+	return values
+}
+
+// String formats the collection like Java, such as `[1, 2, 3]`
+func (c abstractCollection[E]) String() string {
+	var builder strings.Builder
+	builder.WriteByte('[')
+	for it := c.iterator(); it.HasNext(); {
+		builder.WriteString(ValueOf(it.Next()))
+		if it.HasNext() {
+			builder.WriteString(", ")
+		}
+	}
+	builder.WriteByte(']')
+	return builder.String()
+}
+
+// listEquals tests if a list is equal to another value, which is when the
+// other value is also a list, with equal elements in the same order
+func listEquals[E any](list List[E], other any) bool {
+	if any(list) == other {
+		return true
+	}
+	otherList, ok := other.(List[E])
+	if !ok || list.Size() != otherList.Size() {
+		return false
+	}
+	otherValues := otherList.ToArray()
+	for ind, value := range list.ToArray() {
+		if !Equals(value, otherValues[ind]) {
+			return false
+		}
+	}
+	return true
+}
+
+// listHashCode computes the hash code of a list the way that Java does
+func listHashCode[E any](list List[E]) int32 {
+	var hash int32 = 1
+	for it := list.Iterator(); it.HasNext(); {
+		hash = 31*hash + HashOf(it.Next())
+	}
+	return hash
+}
+
+// setEquals tests if a set is equal to another value, which is when the other
+// value is also a set, with the same elements
+func setEquals[E any](set Set[E], other any) bool {
+	if any(set) == other {
+		return true
+	}
+	otherSet, ok := other.(Set[E])
+	if !ok || set.Size() != otherSet.Size() {
+		return false
+	}
+	return set.ContainsAll(otherSet)
+}
+
+// setHashCode computes the hash code of a set the way that Java does, which is
+// the sum of the hash codes of its elements
+func setHashCode[E any](set Set[E]) int32 {
+	var hash int32
+	for it := set.Iterator(); it.HasNext(); {
+		hash += HashOf(it.Next())
+	}
+	return hash
+}
+
+// checkIndex panics with an `IndexOutOfBoundsException` if an index is not
+// inside of a collection of the given size
+func checkIndex(index, size int32) {
+	if index < 0 || index >= size {
+		panic(New[IndexOutOfBoundsException]("Index "+ValueOf(index)+" out of bounds for length "+ValueOf(size), nil))
+	}
+}
+
+// checkModification panics with a `ConcurrentModificationException` if a
+// collection has been modified since an iterator was created
+func checkModification(modifications, expected int) {
+	if modifications != expected {
+		panic(New[ConcurrentModificationException]("", nil))
+	}
+}
+
+// noSuchElement panics with a `NoSuchElementException`, which is what an
+// iterator or queue panics with when it is out of elements
+func noSuchElement() {
+	panic(New[NoSuchElementException]("", nil))
+}
@@ -0,0 +1,125 @@
+package stdjava
+
+import (
+	"testing"
+)
+
+func TestArrayList(t *testing.T) {
+	list := NewArrayList[int32]()
+	list.Add(1)
+	list.Add(3)
+	list.AddAt(1, 2)
+	if list.String() != "[1, 2, 3]" || list.Size() != 3 || list.Get(2) != 3 {
+		t.Errorf("Expected [1, 2, 3], got %s", list)
+	}
+	if list.RemoveAt(0) != 1 || !list.Remove(int32(3)) || list.Remove(3) || list.String() != "[2]" {
+		t.Errorf("Expected the removed elements to be compared like Java, got %s", list)
+	}
+	list.Set(0, 5)
+	if list.IndexOf(int32(5)) != 0 || list.Contains(int32(2)) {
+		t.Errorf("Expected 5 to replace 2, got %s", list)
+	}
+}
+
+func TestArrayListSort(t *testing.T) {
+	list := NewArrayList[string]()
+	for _, word := range []string{"pear", "fig", "apple", "kiwi"} {
+		list.Add(word)
+	}
+	list.Sort(nil)
+	if list.String() != "[apple, fig, kiwi, pear]" {
+		t.Errorf("Expected the words to be sorted, got %s", list)
+	}
+	list.Sort(func(a, b string) int32 { return Length(a) - Length(b) })
+	if list.String() != "[fig, kiwi, pear, apple]" {
+		t.Errorf("Expected a stable sort by length, got %s", list)
+	}
+}
+
+func TestListEquality(t *testing.T) {
+	array, linked := NewArrayList[string](), NewLinkedList[string]()
+	array.Add("a")
+	linked.Add("a")
+	if !array.Equals(linked) || array.HashCode() != linked.HashCode() || array.HashCode() != 128 {
+		t.Errorf("Expected lists with the same elements to be equal")
+	}
+}
+
+func TestFailFastIterator(t *testing.T) {
+	defer func() {
+		if _, ok := recover().(ConcurrentModificationException); !ok {
+			t.Errorf("Expected a ConcurrentModificationException")
+		}
+	}()
+	list := NewArrayList[int32]()
+	list.Add(1)
+	list.Add(2)
+	list.Add(3)
+	for it := list.Iterator(); it.HasNext(); {
+		if it.Next() == 1 {
+			list.Remove(int32(1))
+		}
+	}
+}
+
+func TestIteratorRemove(t *testing.T) {
+	list := NewLinkedList[int32]()
+	for ind := int32(0); ind < 5; ind++ {
+		list.Add(ind)
+	}
+	for it := list.Iterator(); it.HasNext(); {
+		if it.Next()%2 == 0 {
+			it.Remove()
+		}
+	}
+	if list.String() != "[1, 3]" {
+		t.Errorf("Expected the even numbers to be removed, got %s", list)
+	}
+}
+
+func TestDeques(t *testing.T) {
+	for _, deque := range []Deque[string]{NewArrayDeque[string](), NewLinkedList[string]()} {
+		deque.Push("b")
+		deque.Push("a")
+		deque.OfferLast("c")
+		if deque.String() != "[a, b, c]" || deque.PeekLast() != "c" {
+			t.Errorf("Expected [a, b, c], got %s", deque)
+		}
+		if deque.Pop() != "a" || deque.PollLast() != "c" || deque.Poll() != "b" || deque.Poll() != "" {
+			t.Errorf("Expected the elements to be removed from both ends")
+		}
+	}
+}
+
+func TestArrayDequeWrapsAround(t *testing.T) {
+	deque := NewArrayDeque[int32]()
+	for ind := int32(0); ind < 40; ind++ {
+		deque.AddLast(ind)
+		deque.AddFirst(-ind)
+		deque.RemoveLast()
+	}
+	if deque.Size() != 40 || deque.GetFirst() != -39 || deque.GetLast() != 0 {
+		t.Errorf("Expected the deque to hold -39 to 0, got %s", deque)
+	}
+}
+
+func TestEmptyDeque(t *testing.T) {
+	defer func() {
+		if _, ok := recover().(NoSuchElementException); !ok {
+			t.Errorf("Expected a NoSuchElementException")
+		}
+	}()
+	NewArrayDeque[int32]().RemoveFirst()
+}
+
+func TestListIndexOutOfBounds(t *testing.T) {
+	defer func() {
+		if exception, ok := recover().(IndexOutOfBoundsException); !ok || exception.GetMessage() != "Index 2 out of bounds for length 2" {
+			t.Errorf("Expected an IndexOutOfBoundsException, got %v", exception)
+		}
+	}()
+	list := NewArrayList[int32]()
+	list.Add(1)
+	list.Add(2)
+	list.Get(2)
+}
@@ -1,12 +1,16 @@
 package stdjava
 
 import (
-	"math"
+	"unicode"
+	"unicode/utf16"
 
 	"golang.org/x/exp/constraints"
 )
 
 // Ternary represents Java's ternary operator (condition ? result1 : result2)
+//
+// Unlike Java's operator, both results are evaluated before the condition is
+// checked, so translated code lowers ternaries into `if` statements instead
 func Ternary[T any](condition bool, result1, result2 T) T {
 	if condition {
 		return result1
@@ -17,25 +21,38 @@ func Ternary[T any](condition bool, result1, result2 T) T {
 // UnsignedRightShift is an implementation of Java's unsigned right shift
 // operation where a number is shifted over the number of times specified, but
 // the topmost bits are always filled in with zeroes
+//
+// An int64 is shifted as a Java long, and any other type is shifted as a Java
+// int, which smaller types are promoted to before they are shifted. The same
+// as in Java, only the lowest 5 bits of the amount are used for an int, and
+// the lowest 6 bits for a long
 func UnsignedRightShift[V, A constraints.Integer](value V, amount A) V {
-	return V(uint32(value) >> amount)
+	if _, long := any(value).(int64); long {
+		return V(uint64(value) >> (amount & 63))
+	}
+	return V(uint32(value) >> (amount & 31))
 }
 
 // UnsignedRightShiftAssignment represents a right-shift assignment (`>>>=`)
 // where a value is assigned the result of an unsigned right shift
-func UnsignedRightShiftAssignment[A any, V constraints.Integer](assignTo *A, value V) {
-	// TODO: Fix this conversion hack, and change the function to take proper values
-	*assignTo = interface{}(UnsignedRightShift(value, 2)).(A)
+func UnsignedRightShiftAssignment[V, A constraints.Integer](assignTo *V, amount A) {
+	*assignTo = UnsignedRightShift(*assignTo, amount)
 }
 
-// HashCode is an implementation of Java's String `hashCode` method
-func HashCode(s string) int {
-	var total int
-	n := len(s)
-	for ind, char := range s {
-		total += int(char) * int(math.Pow(float64(31), float64(n-(ind+1))))
+// HashCode is an implementation of Java's String `hashCode` method, which
+// hashes the string's UTF-16 code units, and overflows the same way as an int
+func HashCode(s string) int32 {
+	var hash int32
+	for ind := 0; ind < len(s); {
+		char, size := decodeChar(s[ind:])
+		if high, low := utf16.EncodeRune(char); high != unicode.ReplacementChar {
+			hash = 31*hash + high
+			char = low
+		}
+		hash = 31*hash + char
+		ind += size
 	}
-	return total
+	return hash
 }
 
 // MultiDimensionArray constructs an array with two dimensions
@@ -55,3 +72,12 @@ func MultiDimensionArray3[T any](val [][]T, dims ...int) [][][]T {
 	}
 	return arr
 }
+
+// MultiDimensionArray4 constructs an array with four dimensions
+func MultiDimensionArray4[T any](val [][][]T, dims ...int) [][][][]T {
+	arr := make([][][][]T, dims[0])
+	for ind := range arr {
+		arr[ind] = MultiDimensionArray3([][]T{}, dims[1:]...)
+	}
+	return arr
+}
@@ -0,0 +1,58 @@
+package stdjava
+
+import (
+	"fmt"
+	"math"
+
+	"golang.org/x/exp/constraints"
+)
+
+// FloatToInt is an implementation of Java's narrowing conversion from a
+// floating point number to an int, where the value is truncated towards zero,
+// values that are out of range saturate to the minimum or maximum int, and NaN
+// becomes zero
+func FloatToInt[F constraints.Float](value F) int32 {
+	switch {
+	case value != value: // NaN
+		return 0
+	case float64(value) >= math.MaxInt32:
+		return math.MaxInt32
+	case float64(value) <= math.MinInt32:
+		return math.MinInt32
+	}
+	return int32(value)
+}
+
+// FloatToLong is an implementation of Java's narrowing conversion from a
+// floating point number to a long, with the same rules as `FloatToInt`
+func FloatToLong[F constraints.Float](value F) int64 {
+	switch {
+	case value != value: // NaN
+		return 0
+	// The maximum long can't be represented exactly as a float, and rounds up
+	case float64(value) >= math.MaxInt64:
+		return math.MaxInt64
+	case float64(value) <= math.MinInt64:
+		return math.MinInt64
+	}
+	return int64(value)
+}
+
+// Cast is an implementation of Java's reference casts, which converts a value
+// to the given type, and panics with a `ClassCastException` if the value is not
+// an instance of that type
+//
+// In Java, `null` can be cast to any type, so nil values are returned as the
+// zero value of the type
+func Cast[T any](value any) T {
+	if result, ok := value.(T); ok {
+		return result
+	}
+
+	var zero T
+	if isNull(value) {
+		return zero
+	}
+
+	panic(New[ClassCastException](fmt.Sprintf("class %T cannot be cast to class %T", value, zero), nil))
+}
@@ -0,0 +1,70 @@
+package stdjava
+
+import (
+	"math"
+	"testing"
+)
+
+func TestFloatToIntTruncates(t *testing.T) {
+	if FloatToInt(3.9) != 3 {
+		t.Errorf("Expected (int) 3.9 to be 3, got %d", FloatToInt(3.9))
+	}
+	if FloatToInt(-3.9) != -3 {
+		t.Errorf("Expected (int) -3.9 to be -3, got %d", FloatToInt(-3.9))
+	}
+}
+
+func TestFloatToIntSaturates(t *testing.T) {
+	if FloatToInt(1e20) != math.MaxInt32 {
+		t.Errorf("Expected (int) 1e20 to be %d, got %d", math.MaxInt32, FloatToInt(1e20))
+	}
+	if FloatToInt(math.Inf(-1)) != math.MinInt32 {
+		t.Errorf("Expected (int) -Infinity to be %d, got %d", math.MinInt32, FloatToInt(math.Inf(-1)))
+	}
+	if FloatToInt(math.NaN()) != 0 {
+		t.Errorf("Expected (int) NaN to be 0, got %d", FloatToInt(math.NaN()))
+	}
+	if FloatToInt(float32(3e9)) != math.MaxInt32 {
+		t.Errorf("Expected (int) 3e9f to be %d, got %d", math.MaxInt32, FloatToInt(float32(3e9)))
+	}
+}
+
+func TestFloatToLongSaturates(t *testing.T) {
+	if FloatToLong(1e30) != math.MaxInt64 {
+		t.Errorf("Expected (long) 1e30 to be %d, got %d", int64(math.MaxInt64), FloatToLong(1e30))
+	}
+	if FloatToLong(-1e30) != math.MinInt64 {
+		t.Errorf("Expected (long) -1e30 to be %d, got %d", int64(math.MinInt64), FloatToLong(-1e30))
+	}
+	if FloatToLong(math.NaN()) != 0 {
+		t.Errorf("Expected (long) NaN to be 0, got %d", FloatToLong(math.NaN()))
+	}
+}
+
+type castTestShape interface{ area() float64 }
+
+type castTestSquare struct{}
+
+func (castTestSquare) area() float64 { return 1 }
+
+func TestCast(t *testing.T) {
+	var shape castTestShape = castTestSquare{}
+	if _, ok := any(Cast[castTestSquare](shape)).(castTestSquare); !ok {
+		t.Errorf("Expected the shape to be cast to a square")
+	}
+
+	// Casting null always succeeds
+	var nilSquare *castTestSquare
+	if Cast[*castTestShape](nilSquare) != nil {
+		t.Errorf("Expected a null value to be cast to null")
+	}
+}
+
+func TestInvalidCast(t *testing.T) {
+	defer func() {
+		if _, ok := recover().(ClassCastException); !ok {
+			t.Errorf("Expected a ClassCastException")
+		}
+	}()
+	Cast[string](1)
+}
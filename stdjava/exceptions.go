@@ -0,0 +1,345 @@
+package stdjava
+
+import (
+	"reflect"
+	"runtime"
+	"strings"
+)
+
+// Java's exceptions are panicked with as values
+//
+// Go has no inheritance, so each exception embeds its superclass, which mirrors
+// the JDK's hierarchy of exceptions, and is tested with `InstanceOf`
+
+// Thrown is implemented by all of Java's exceptions, and is the type of an
+// exception that has been caught, which may be any subclass of the class that
+// was caught
+type Thrown interface {
+	error
+	GetMessage() string
+	GetCause() error
+}
+
+// Throwable is the superclass of all of Java's exceptions and errors
+type Throwable struct {
+	Message string
+	Cause   error
+}
+
+func (e Throwable) Error() string {
+	return describe("java.lang.Throwable", e.Message)
+}
+
+// GetMessage returns the message that the exception was created with
+func (e Throwable) GetMessage() string {
+	return e.Message
+}
+
+// GetCause returns the exception that caused this exception, or nil if there
+// wasn't one
+func (e Throwable) GetCause() error {
+	return e.Cause
+}
+
+func (e *Throwable) initialize(message string, cause error) {
+	if message == "" && cause != nil {
+		message = cause.Error()
+	}
+	e.Message, e.Cause = message, cause
+}
+
+// describe formats an exception the way that Java prints it, which is the
+// exception's class, followed by its message, if it has one
+func describe(class, message string) string {
+	if message == "" {
+		return class
+	}
+	return class + ": " + message
+}
+
+// Exception is the superclass of the exceptions that a program may want to catch
+type Exception struct {
+	Throwable
+}
+
+func (e Exception) Error() string {
+	return describe("java.lang.Exception", e.Message)
+}
+
+// JavaError is Java's `Error` class, which is the superclass of the serious
+// problems that a program should not try to catch
+//
+// It is renamed, since its field would conflict with the `Error` method of
+// the exceptions that embed it
+type JavaError struct {
+	Throwable
+}
+
+func (e JavaError) Error() string {
+	return describe("java.lang.Error", e.Message)
+}
+
+// AssertionError is thrown when an assertion fails
+type AssertionError struct {
+	JavaError
+}
+
+func (e AssertionError) Error() string {
+	return describe("java.lang.AssertionError", e.Message)
+}
+
+// RuntimeException is the superclass of the exceptions that do not have to be declared
+type RuntimeException struct {
+	Exception
+}
+
+func (e RuntimeException) Error() string {
+	return describe("java.lang.RuntimeException", e.Message)
+}
+
+// ArithmeticException is thrown when an integer is divided by zero
+type ArithmeticException struct {
+	RuntimeException
+}
+
+func (e ArithmeticException) Error() string {
+	return describe("java.lang.ArithmeticException", e.Message)
+}
+
+// ClassCastException is thrown when a value is cast to a type that it is not
+// an instance of
+type ClassCastException struct {
+	RuntimeException
+}
+
+func (e ClassCastException) Error() string {
+	return describe("java.lang.ClassCastException", e.Message)
+}
+
+// IllegalArgumentException is thrown when a method is passed an invalid argument
+type IllegalArgumentException struct {
+	RuntimeException
+}
+
+func (e IllegalArgumentException) Error() string {
+	return describe("java.lang.IllegalArgumentException", e.Message)
+}
+
+// NumberFormatException is thrown when a string cannot be parsed as a number
+type NumberFormatException struct {
+	IllegalArgumentException
+}
+
+func (e NumberFormatException) Error() string {
+	return describe("java.lang.NumberFormatException", e.Message)
+}
+
+// IllegalStateException is thrown when a method is called at the wrong time
+type IllegalStateException struct {
+	RuntimeException
+}
+
+func (e IllegalStateException) Error() string {
+	return describe("java.lang.IllegalStateException", e.Message)
+}
+
+// IndexOutOfBoundsException is thrown when an index is outside of the bounds of a
+// sequence
+type IndexOutOfBoundsException struct {
+	RuntimeException
+}
+
+func (e IndexOutOfBoundsException) Error() string {
+	return describe("java.lang.IndexOutOfBoundsException", e.Message)
+}
+
+// ArrayIndexOutOfBoundsException is thrown when an array is indexed outside
+// of its bounds
+type ArrayIndexOutOfBoundsException struct {
+	IndexOutOfBoundsException
+}
+
+func (e ArrayIndexOutOfBoundsException) Error() string {
+	return describe("java.lang.ArrayIndexOutOfBoundsException", e.Message)
+}
+
+// StringIndexOutOfBoundsException is thrown when a string is indexed outside
+// of its bounds
+type StringIndexOutOfBoundsException struct {
+	IndexOutOfBoundsException
+}
+
+func (e StringIndexOutOfBoundsException) Error() string {
+	return describe("java.lang.StringIndexOutOfBoundsException", e.Message)
+}
+
+// ArrayStoreException is thrown when a value is stored into an array whose
+// elements can't hold it, such as storing an `Integer` into a `String[]` that
+// is used as an `Object[]`
+type ArrayStoreException struct {
+	RuntimeException
+}
+
+func (e ArrayStoreException) Error() string {
+	return describe("java.lang.ArrayStoreException", e.Message)
+}
+
+// NegativeArraySizeException is thrown when an array is created with a negative
+// length
+type NegativeArraySizeException struct {
+	RuntimeException
+}
+
+func (e NegativeArraySizeException) Error() string {
+	return describe("java.lang.NegativeArraySizeException", e.Message)
+}
+
+// NullPointerException is thrown when `null` is used as an object
+type NullPointerException struct {
+	RuntimeException
+}
+
+func (e NullPointerException) Error() string {
+	return describe("java.lang.NullPointerException", e.Message)
+}
+
+// UnsupportedOperationException is thrown when an object does not support a
+// method, such as adding to an unmodifiable list
+type UnsupportedOperationException struct {
+	RuntimeException
+}
+
+func (e UnsupportedOperationException) Error() string {
+	return describe("java.lang.UnsupportedOperationException", e.Message)
+}
+
+// ConcurrentModificationException is thrown when a collection is modified while
+// it is being iterated over
+type ConcurrentModificationException struct {
+	RuntimeException
+}
+
+func (e ConcurrentModificationException) Error() string {
+	return describe("java.util.ConcurrentModificationException", e.Message)
+}
+
+// NoSuchElementException is thrown when an element is requested that does not
+// exist, such as calling `next` on an iterator that has no more elements
+type NoSuchElementException struct {
+	RuntimeException
+}
+
+func (e NoSuchElementException) Error() string {
+	return describe("java.util.NoSuchElementException", e.Message)
+}
+
+// New creates one of Java's exceptions with a message, and the exception that
+// caused it, which may both be empty
+//
+// Like Java's constructors that only take a cause, an exception that only has
+// a cause uses the cause's description as its message
+func New[T any, P interface {
+	*T
+	initialize(string, error)
+}](message string, cause error) T {
+	var exception T
+	P(&exception).initialize(message, cause)
+	return exception
+}
+
+// InstanceOf tests if a value that has been thrown is an instance of the type
+// T, or of one of T's subclasses, which embed their superclass as their first
+// field
+func InstanceOf[T any](value any) bool {
+	if value == nil {
+		return false
+	}
+	target := reflect.TypeOf((*T)(nil)).Elem()
+	current := reflect.TypeOf(value)
+	if target.Kind() == reflect.Interface {
+		return current.Implements(target)
+	}
+	// The program's own exceptions are thrown as pointers to their structs
+	if target.Kind() == reflect.Pointer {
+		target = target.Elem()
+	}
+	if current.Kind() == reflect.Pointer {
+		current = current.Elem()
+	}
+	for current != target {
+		if current.Kind() != reflect.Struct || current.NumField() == 0 || !current.Field(0).Anonymous {
+			return false
+		}
+		current = current.Field(0).Type
+	}
+	return true
+}
+
+// As converts a value that has been thrown into the type T, which is either
+// the value's own type, or the type of one of its superclasses, which is the
+// superclass that the value embeds, so that the value can be caught as T
+//
+// The value must be an instance of T, which is tested with `InstanceOf`
+func As[T any](value any) T {
+	if converted, ok := value.(T); ok {
+		return converted
+	}
+
+	current := reflect.ValueOf(value)
+	if current.Kind() != reflect.Pointer {
+		copied := reflect.New(current.Type())
+		copied.Elem().Set(current)
+		current = copied
+	}
+	// Each superclass is the first field of its subclass, so they all start at
+	// the same address, and the superclass is shared with the value
+	target := reflect.TypeOf((*T)(nil)).Elem()
+	if target.Kind() == reflect.Pointer {
+		return reflect.NewAt(target.Elem(), current.UnsafePointer()).Interface().(T)
+	}
+	return reflect.NewAt(target, current.UnsafePointer()).Elem().Interface().(T)
+}
+
+// Classify converts the runtime errors that Go panics with into the exceptions
+// that Java would have thrown instead, so that they can be caught as those
+// exceptions, such as dividing by zero panicking with an `ArithmeticException`
+//
+// Any other value is returned as it is
+func Classify(value any) any {
+	err, ok := value.(runtime.Error)
+	if !ok {
+		return value
+	}
+	if _, ok := err.(*runtime.TypeAssertionError); ok {
+		return New[ClassCastException](err.Error(), nil)
+	}
+
+	message := strings.TrimPrefix(err.Error(), "runtime error: ")
+	switch {
+	case strings.HasPrefix(message, "index out of range"):
+		return New[ArrayIndexOutOfBoundsException](outOfBounds(message), nil)
+	case strings.HasPrefix(message, "slice bounds out of range"):
+		return New[IndexOutOfBoundsException](message, nil)
+	case message == "integer divide by zero":
+		return New[ArithmeticException]("/ by zero", nil)
+	case strings.Contains(message, "nil pointer dereference"), strings.Contains(message, "nil map"):
+		return New[NullPointerException]("", nil)
+	case strings.HasPrefix(message, "makeslice: len out of range"):
+		return New[NegativeArraySizeException]("", nil)
+	}
+	return value
+}
+
+// outOfBounds converts Go's message for an index that is out of range, such as
+// `index out of range [5] with length 3`, into Java's message
+func outOfBounds(message string) string {
+	start, end := strings.Index(message, "["), strings.Index(message, "]")
+	if start < 0 || end < start {
+		return message
+	}
+	java := "Index " + message[start+1:end] + " out of bounds"
+	if _, length, found := strings.Cut(message, "with length "); found {
+		java += " for length " + length
+	}
+	return java
+}
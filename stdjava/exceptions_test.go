@@ -0,0 +1,116 @@
+package stdjava
+
+import "testing"
+
+// recovered runs a function, and returns what it panicked with, after it has
+// been classified
+func recovered(f func()) (value any) {
+	defer func() {
+		value = Classify(recover())
+	}()
+	f()
+	return nil
+}
+
+func TestInstanceOf(t *testing.T) {
+	thrown := New[ArrayIndexOutOfBoundsException]("", nil)
+	if !InstanceOf[IndexOutOfBoundsException](thrown) || !InstanceOf[RuntimeException](thrown) || !InstanceOf[Throwable](thrown) {
+		t.Errorf("ArrayIndexOutOfBoundsException is not an instance of its superclasses")
+	}
+	if InstanceOf[StringIndexOutOfBoundsException](thrown) || InstanceOf[JavaError](thrown) {
+		t.Errorf("ArrayIndexOutOfBoundsException is an instance of an unrelated class")
+	}
+	if !InstanceOf[Thrown](thrown) {
+		t.Errorf("ArrayIndexOutOfBoundsException does not implement Thrown")
+	}
+	if InstanceOf[Exception]("message") || InstanceOf[Exception](nil) {
+		t.Errorf("A value that is not an exception is an instance of Exception")
+	}
+}
+
+// The program's own exceptions embed the exceptions that they extend, and are
+// thrown as pointers
+type invalidState struct {
+	IllegalStateException
+	code int
+}
+
+type invalidInput struct {
+	invalidState
+}
+
+func TestEmbeddedExceptions(t *testing.T) {
+	thrown := &invalidInput{invalidState{New[IllegalStateException]("invalid", nil), 2}}
+	if !InstanceOf[RuntimeException](thrown) || !InstanceOf[*invalidState](thrown) || !InstanceOf[*invalidInput](thrown) {
+		t.Errorf("An embedded exception is not an instance of its superclasses")
+	}
+	if InstanceOf[IllegalArgumentException](thrown) {
+		t.Errorf("An embedded exception is an instance of an unrelated class")
+	}
+	if message := As[Thrown](thrown).GetMessage(); message != "invalid" {
+		t.Errorf("Got message %q, expected \"invalid\"", message)
+	}
+	caught := As[*invalidState](thrown)
+	caught.code = 3
+	if thrown.code != 3 {
+		t.Errorf("Catching an exception as its superclass copied it")
+	}
+	if As[RuntimeException](thrown).GetMessage() != "invalid" {
+		t.Errorf("Got %q", As[RuntimeException](thrown).GetMessage())
+	}
+}
+
+func TestNew(t *testing.T) {
+	cause := New[IllegalStateException]("broken", nil)
+	if message := New[RuntimeException]("", cause).GetMessage(); message != "java.lang.IllegalStateException: broken" {
+		t.Errorf("Got message %q, expected the description of the cause", message)
+	}
+	thrown := New[NumberFormatException]("For input string: \"x\"", cause)
+	if thrown.GetCause() != cause {
+		t.Errorf("Got cause %v, expected %v", thrown.GetCause(), cause)
+	}
+	if thrown.Error() != "java.lang.NumberFormatException: For input string: \"x\"" {
+		t.Errorf("Got %q", thrown.Error())
+	}
+	if New[NoSuchElementException]("", nil).Error() != "java.util.NoSuchElementException" {
+		t.Errorf("Got %q", New[NoSuchElementException]("", nil).Error())
+	}
+}
+
+func TestClassifyDivideByZero(t *testing.T) {
+	zero := int32(0)
+	thrown := recovered(func() { _ = 1 / zero })
+	if exception, ok := thrown.(ArithmeticException); !ok || exception.GetMessage() != "/ by zero" {
+		t.Errorf("Got %v, expected an ArithmeticException", thrown)
+	}
+}
+
+func TestClassifyIndexOutOfRange(t *testing.T) {
+	values, index := []int32{1, 2, 3}, 5
+	thrown := recovered(func() { _ = values[index] })
+	if exception, ok := thrown.(ArrayIndexOutOfBoundsException); !ok || exception.GetMessage() != "Index 5 out of bounds for length 3" {
+		t.Errorf("Got %v, expected an ArrayIndexOutOfBoundsException", thrown)
+	}
+}
+
+func TestClassifyNilPointer(t *testing.T) {
+	var value *Throwable
+	thrown := recovered(func() { _ = value.Message })
+	if _, ok := thrown.(NullPointerException); !ok {
+		t.Errorf("Got %v, expected a NullPointerException", thrown)
+	}
+}
+
+func TestClassifyTypeAssertion(t *testing.T) {
+	var value any = "string"
+	thrown := recovered(func() { _ = value.(int32) })
+	if _, ok := thrown.(ClassCastException); !ok {
+		t.Errorf("Got %v, expected a ClassCastException", thrown)
+	}
+}
+
+func TestClassifyOtherValues(t *testing.T) {
+	if thrown := Classify("message"); thrown != "message" {
+		t.Errorf("Got %v, expected the value to be unchanged", thrown)
+	}
+}
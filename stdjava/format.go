@@ -0,0 +1,249 @@
+package stdjava
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// FormatDouble is an implementation of Java's `Double.toString`, which is used
+// when a double is concatenated to a string
+//
+// Numbers between 10^-3 and 10^7 are written out as decimals, and anything else
+// is written in scientific notation, such as `1.0E10`, and both forms always
+// have at least one digit after the decimal point
+func FormatDouble(value float64) string {
+	return formatFloat(value, 64)
+}
+
+// FormatFloat is an implementation of Java's `Float.toString`, which follows
+// the same rules as `FormatDouble`, but only uses as many digits as are needed
+// to represent a float
+func FormatFloat(value float32) string {
+	return formatFloat(float64(value), 32)
+}
+
+func formatFloat(value float64, bitSize int) string {
+	switch {
+	case math.IsNaN(value):
+		return "NaN"
+	case math.IsInf(value, 1):
+		return "Infinity"
+	case math.IsInf(value, -1):
+		return "-Infinity"
+	}
+
+	if magnitude := math.Abs(value); magnitude == 0 || (magnitude >= 1e-3 && magnitude < 1e7) {
+		formatted := strconv.FormatFloat(value, 'f', -1, bitSize)
+		if !strings.ContainsRune(formatted, '.') {
+			formatted += ".0"
+		}
+		return formatted
+	}
+
+	// Go formats the exponent with a sign and at least two digits, such as `1E+10`
+	mantissa, exponent, _ := strings.Cut(strconv.FormatFloat(value, 'E', -1, bitSize), "E")
+	if !strings.ContainsRune(mantissa, '.') {
+		mantissa += ".0"
+	}
+	power, _ := strconv.Atoi(exponent)
+	return mantissa + "E" + strconv.Itoa(power)
+}
+
+// ValueOf is an implementation of Java's `String.valueOf`, which converts any
+// value into the string that Java would use for it when it is concatenated to
+// a string
+//
+// Objects are converted with their `String` or `ToString` methods, and `null`
+// values become the string "null"
+func ValueOf(value any) string {
+	switch v := value.(type) {
+	case nil:
+		return "null"
+	case string:
+		return v
+	case bool:
+		return strconv.FormatBool(v)
+	case float64:
+		return FormatDouble(v)
+	case float32:
+		return FormatFloat(v)
+	case int8, int16, int32, int64, int:
+		return fmt.Sprint(v)
+	}
+
+	// Pointers to objects that are nil are still typed, so they have to be
+	// checked for separately
+	if reflected := reflect.ValueOf(value); reflected.Kind() == reflect.Pointer && reflected.IsNil() {
+		return "null"
+	} else if isBox(reflected) {
+		return ValueOf(reflected.Elem().Interface())
+	}
+
+	switch v := value.(type) {
+	case fmt.Stringer:
+		return v.String()
+	case interface{ ToString() string }:
+		return v.ToString()
+	}
+	return fmt.Sprint(value)
+}
+
+// Format is an implementation of Java's `String.format`, which converts each of
+// Java's format specifiers, such as `%d`, `%s` and `%n`, into Go's
+//
+// Values are formatted the way that Java formats them, so `%s` formats values
+// like `String.valueOf`, `%x` formats negative numbers as their two's
+// complement, and `%h` formats the hash codes of values
+func Format(format string, args ...any) string {
+	var formatted strings.Builder
+	next, previous := 0, -1
+	for ind := 0; ind < len(format); ind++ {
+		if format[ind] != '%' || ind+1 == len(format) {
+			formatted.WriteByte(format[ind])
+			continue
+		}
+
+		// A specifier is %[argument$][flags][width][.precision]conversion
+		end := ind + 1
+		for end < len(format) && strings.IndexByte("0123456789$<-#+ ,(.", format[end]) >= 0 {
+			end++
+		}
+		if end == len(format) {
+			formatted.WriteString(format[ind:])
+			break
+		}
+		spec, conversion := format[ind+1:end], format[end]
+		ind = end
+
+		switch conversion {
+		case 'n':
+			formatted.WriteByte('\n')
+			continue
+		case '%':
+			formatted.WriteByte('%')
+			continue
+		}
+
+		// Find which argument is formatted
+		argument := next
+		if dollar := strings.IndexByte(spec, '$'); dollar >= 0 {
+			explicit, _ := strconv.Atoi(spec[:dollar])
+			argument, spec = explicit-1, spec[dollar+1:]
+		} else if strings.HasPrefix(spec, "<") {
+			argument, spec = previous, spec[1:]
+		} else {
+			next++
+		}
+		previous = argument
+		var value any
+		if argument >= 0 && argument < len(args) {
+			value = args[argument]
+		}
+		// Boxed values are formatted as their primitive values
+		if reflected := reflect.ValueOf(value); isBox(reflected) {
+			value = nil
+			if !reflected.IsNil() {
+				value = reflected.Elem().Interface()
+			}
+		}
+
+		formatted.WriteString(formatValue(spec, conversion, value))
+	}
+	return formatted.String()
+}
+
+// formatValue formats a single value with one of Java's format specifiers
+func formatValue(spec string, conversion byte, value any) string {
+	// Go does not have the flags for grouping digits, or for negative numbers in
+	// parentheses, so they are handled separately
+	grouped, parenthesized := strings.Contains(spec, ","), strings.Contains(spec, "(")
+	spec = strings.NewReplacer(",", "", "(", "").Replace(spec)
+
+	upper := 'A' <= conversion && conversion <= 'Z'
+	var result string
+	switch strings.ToLower(string(conversion)) {
+	case "s":
+		result = fmt.Sprintf("%"+spec+"s", ValueOf(value))
+	case "b":
+		boolean, isBool := value.(bool)
+		result = fmt.Sprintf("%"+spec+"t", boolean || (!isBool && value != nil))
+	case "c":
+		if char, ok := value.(string); ok {
+			result = fmt.Sprintf("%"+spec+"s", char)
+		} else {
+			result = fmt.Sprintf("%"+spec+"c", value)
+		}
+	case "d":
+		result = fmt.Sprintf("%"+spec+"d", value)
+		if grouped || parenthesized {
+			result = formatNumber(result, grouped, parenthesized)
+		}
+	case "x", "o":
+		// Java formats negative numbers as their unsigned two's complement
+		switch number := value.(type) {
+		case int8:
+			value = uint8(number)
+		case int16:
+			value = uint16(number)
+		case int32:
+			value = uint32(number)
+		case int:
+			// Untyped constants are Go's `int`, which are Java's `int`
+			value = uint32(number)
+		case int64:
+			value = uint64(number)
+		}
+		result = fmt.Sprintf("%"+spec+string(conversion|0x20), value)
+	case "h":
+		// Values are formatted as the hexadecimal hash codes of their objects
+		hash := "null"
+		if !isNull(value) {
+			hash = strconv.FormatUint(uint64(uint32(HashOf(value))), 16)
+		}
+		result = fmt.Sprintf("%"+spec+"s", hash)
+	case "e", "f", "g":
+		result = fmt.Sprintf("%"+spec+string(conversion|0x20), value)
+		if grouped || parenthesized {
+			result = formatNumber(result, grouped, parenthesized)
+		}
+	default:
+		result = ValueOf(value)
+	}
+
+	if upper {
+		return strings.ToUpper(result)
+	}
+	return result
+}
+
+// formatNumber adds the separators between groups of digits to a number that
+// has already been formatted, or surrounds a negative number with parentheses
+func formatNumber(number string, grouped, parenthesized bool) string {
+	start := strings.IndexAny(number, "0123456789")
+	if start < 0 {
+		return number
+	}
+	end := start
+	for end < len(number) && '0' <= number[end] && number[end] <= '9' {
+		end++
+	}
+
+	digits := number[start:end]
+	if grouped {
+		var groups []string
+		for len(digits) > 3 {
+			groups = append([]string{digits[len(digits)-3:]}, groups...)
+			digits = digits[:len(digits)-3]
+		}
+		digits = strings.Join(append([]string{digits}, groups...), ",")
+	}
+
+	prefix, suffix := number[:start], number[end:]
+	if parenthesized && strings.Contains(prefix, "-") {
+		return strings.Replace(prefix, "-", "(", 1) + digits + suffix + ")"
+	}
+	return prefix + digits + suffix
+}
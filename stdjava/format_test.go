@@ -0,0 +1,83 @@
+package stdjava
+
+import (
+	"math"
+	"testing"
+)
+
+func TestFormatDouble(t *testing.T) {
+	cases := map[float64]string{
+		1:            "1.0",
+		0:            "0.0",
+		-2.5:         "-2.5",
+		0.1:          "0.1",
+		0.001:        "0.001",
+		0.0001:       "1.0E-4",
+		1234567:      "1234567.0",
+		1e7:          "1.0E7",
+		1e10:         "1.0E10",
+		1.5e-10:      "1.5E-10",
+		math.NaN():   "NaN",
+		math.Inf(-1): "-Infinity",
+	}
+	for value, expected := range cases {
+		if FormatDouble(value) != expected {
+			t.Errorf("Expected %v to be formatted as %s, got %s", value, expected, FormatDouble(value))
+		}
+	}
+}
+
+func TestFormatFloat(t *testing.T) {
+	if FormatFloat(0.1) != "0.1" {
+		t.Errorf("Expected 0.1f to be formatted as 0.1, got %s", FormatFloat(0.1))
+	}
+	if FormatFloat(1e10) != "1.0E10" {
+		t.Errorf("Expected 1e10f to be formatted as 1.0E10, got %s", FormatFloat(1e10))
+	}
+}
+
+type stringer struct{}
+
+func (s *stringer) String() string {
+	return "stringer"
+}
+
+func TestValueOf(t *testing.T) {
+	var missing *stringer
+	if ValueOf(missing) != "null" {
+		t.Errorf("Expected a nil object to be null, got %s", ValueOf(missing))
+	}
+	if ValueOf(nil) != "null" {
+		t.Errorf("Expected nil to be null, got %s", ValueOf(nil))
+	}
+	if ValueOf(&stringer{}) != "stringer" {
+		t.Errorf("Expected the object's String method to be used, got %s", ValueOf(&stringer{}))
+	}
+	if ValueOf(int32(3)) != "3" || ValueOf(2.0) != "2.0" || ValueOf(true) != "true" {
+		t.Errorf("Expected primitives to be formatted like Java")
+	}
+}
+
+func TestFormat(t *testing.T) {
+	cases := map[string]string{
+		Format("%d items%n", int32(3)):                  "3 items\n",
+		Format("%s and %s", "a", int32(1)):              "a and 1",
+		Format("%5.2f|%-4s|%04d", 3.14159, "ab", 7):     " 3.14|ab  |0007",
+		Format("%s", 2.5):                               "2.5",
+		Format("%x %X", int32(-1), int64(255)):          "ffffffff FF",
+		Format("%,d", int64(1234567)):                   "1,234,567",
+		Format("%(,d", int32(-1234)):                    "(1,234)",
+		Format("%2$s %1$s %<s", "a", "b"):               "b a a",
+		Format("%c%c", 'h', "i"):                        "hi",
+		Format("%b %b %S", false, "x", "up"):            "false true UP",
+		Format("100%%"):                                 "100%",
+		Format("%s", nil):                               "null",
+		Format("%e", 12345.678):                         "1.234568e+04",
+		Format("%h %H %5h|%h", "hi", int32(-1), 2, nil): "d01 FFFFFFFF     2|null",
+	}
+	for formatted, expected := range cases {
+		if formatted != expected {
+			t.Errorf("Expected %q, got %q", expected, formatted)
+		}
+	}
+}
@@ -8,3 +8,11 @@ func TestBasicStrings(t *testing.T) {
 		t.Errorf("Expected the hash to be 69609650. Got %d", HashCode(in))
 	}
 }
+
+func TestHashCodeOverflows(t *testing.T) {
+	// The hash of a long string overflows an int, and surrogate pairs are hashed
+	// as two chars
+	if HashCode("hello, world") != -640608884 || HashCode("😀") != 1772899 {
+		t.Errorf("Got %d and %d", HashCode("hello, world"), HashCode("😀"))
+	}
+}
@@ -0,0 +1,546 @@
+package stdjava
+
+import (
+	"strings"
+)
+
+// HashMap is an implementation of Java's `HashMap`, which is a hash table
+// whose keys are compared with `Equals` and hashed with `HashOf`
+//
+// The table is laid out the same way as Java's, so the map iterates over its
+// entries in the same order that Java would, as long as its keys have the same
+// hash codes as they do in Java
+type HashMap[K, V any] struct {
+	table []*hashEntry[K, V]
+	size  int32
+	// The size that the table is resized at, which is also the capacity of the
+	// table before it is allocated
+	threshold     int32
+	modifications int
+}
+
+// hashEntry is an entry in a `HashMap`, which is part of a linked list of the
+// entries in the same bucket
+type hashEntry[K, V any] struct {
+	hash  int32
+	key   K
+	value V
+	next  *hashEntry[K, V]
+}
+
+const (
+	defaultCapacity = 16
+	maximumCapacity = 1 << 30
+	loadFactor      = 0.75
+)
+
+// NewHashMap creates an empty map
+func NewHashMap[K, V any]() *HashMap[K, V] {
+	return &HashMap[K, V]{}
+}
+
+// NewHashMapCapacity creates an empty map with room for the given number of
+// entries, which is rounded up to a power of two
+//
+// Panics with an `IllegalArgumentException` if the capacity is negative
+func NewHashMapCapacity[K, V any](capacity int32) *HashMap[K, V] {
+	if capacity < 0 {
+		panic(New[IllegalArgumentException]("Illegal initial capacity: "+ValueOf(capacity), nil))
+	}
+	return &HashMap[K, V]{threshold: tableSizeFor(capacity)}
+}
+
+// NewHashMapFrom creates a map that contains the entries of another map
+func NewHashMapFrom[K, V any](other Map[K, V]) *HashMap[K, V] {
+	m := NewHashMapCapacity[K, V](int32(float32(other.Size())/loadFactor) + 1)
+	m.PutAll(other)
+	return m
+}
+
+// tableSizeFor returns the smallest power of two that fits the given capacity
+func tableSizeFor(capacity int32) int32 {
+	size := int32(1)
+	for size < capacity && size < maximumCapacity {
+		size <<= 1
+	}
+	return size
+}
+
+// spread mixes the high bits of a hash into its low bits, since the table only
+// uses the low bits of each hash
+func spread(key any) int32 {
+	hash := HashOf(key)
+	return hash ^ int32(uint32(hash)>>16)
+}
+
+// resize allocates the table, or doubles its size, where each bucket is split
+// into the two buckets that its entries now belong to, keeping their order
+func (m *HashMap[K, V]) resize() {
+	oldCapacity := int32(len(m.table))
+	var capacity, threshold int32
+	switch {
+	case oldCapacity >= maximumCapacity:
+		m.threshold = 1<<31 - 1
+		return
+	case oldCapacity > 0:
+		capacity = oldCapacity << 1
+		if oldCapacity >= defaultCapacity {
+			threshold = m.threshold << 1
+		}
+	case m.threshold > 0:
+		capacity = m.threshold
+	default:
+		capacity = defaultCapacity
+	}
+	if threshold == 0 {
+		threshold = int32(float32(capacity) * loadFactor)
+	}
+
+	table := make([]*hashEntry[K, V], capacity)
+	for ind, entry := range m.table {
+		var lowHead, lowTail, highHead, highTail *hashEntry[K, V]
+		for ; entry != nil; entry = entry.next {
+			if entry.hash&oldCapacity == 0 {
+				if lowTail == nil {
+					lowHead = entry
+				} else {
+					lowTail.next = entry
+				}
+				lowTail = entry
+			} else {
+				if highTail == nil {
+					highHead = entry
+				} else {
+					highTail.next = entry
+				}
+				highTail = entry
+			}
+		}
+		if lowTail != nil {
+			lowTail.next = nil
+			table[ind] = lowHead
+		}
+		if highTail != nil {
+			highTail.next = nil
+			table[int32(ind)+oldCapacity] = highHead
+		}
+	}
+	m.table, m.threshold = table, threshold
+}
+
+// bucket returns the index of the bucket that a hash belongs in
+func (m *HashMap[K, V]) bucket(hash int32) int32 {
+	return (int32(len(m.table)) - 1) & hash
+}
+
+// find returns the entry for a key, or nil if the map does not contain it
+func (m *HashMap[K, V]) find(key any) *hashEntry[K, V] {
+	if len(m.table) == 0 {
+		return nil
+	}
+	hash := spread(key)
+	for entry := m.table[m.bucket(hash)]; entry != nil; entry = entry.next {
+		if entry.hash == hash && Equals(entry.key, key) {
+			return entry
+		}
+	}
+	return nil
+}
+
+// put sets the value of a key, and returns the key's entry if it was already
+// in the map, which is only replaced if the map should replace existing values
+func (m *HashMap[K, V]) put(key K, value V, replace bool) *hashEntry[K, V] {
+	if existing := m.find(key); existing != nil {
+		if replace {
+			existing.value = value
+		}
+		return existing
+	}
+	if len(m.table) == 0 {
+		m.resize()
+	}
+	hash := spread(key)
+	entry := &hashEntry[K, V]{hash: hash, key: key, value: value}
+	bucket := m.bucket(hash)
+	if m.table[bucket] == nil {
+		m.table[bucket] = entry
+	} else {
+		last := m.table[bucket]
+		for last.next != nil {
+			last = last.next
+		}
+		last.next = entry
+	}
+	m.modifications++
+	m.size++
+	if m.size > m.threshold {
+		m.resize()
+	}
+	return nil
+}
+
+// remove removes the entry for a key, and returns it, or nil if the map does
+// not contain the key
+func (m *HashMap[K, V]) remove(key any) *hashEntry[K, V] {
+	if len(m.table) == 0 {
+		return nil
+	}
+	hash := spread(key)
+	bucket := m.bucket(hash)
+	var previous *hashEntry[K, V]
+	for entry := m.table[bucket]; entry != nil; previous, entry = entry, entry.next {
+		if entry.hash == hash && Equals(entry.key, key) {
+			if previous == nil {
+				m.table[bucket] = entry.next
+			} else {
+				previous.next = entry.next
+			}
+			m.modifications++
+			m.size--
+			return entry
+		}
+	}
+	return nil
+}
+
+func (m *HashMap[K, V]) Size() int32 {
+	return m.size
+}
+
+func (m *HashMap[K, V]) IsEmpty() bool {
+	return m.size == 0
+}
+
+// Get returns the value of a key, or the zero value if the map does not
+// contain the key
+func (m *HashMap[K, V]) Get(key any) V {
+	var zero V
+	return m.GetOrDefault(key, zero)
+}
+
+// GetOrDefault returns the value of a key, or the default value if the map
+// does not contain the key
+func (m *HashMap[K, V]) GetOrDefault(key any, defaultValue V) V {
+	if entry := m.find(key); entry != nil {
+		return entry.value
+	}
+	return defaultValue
+}
+
+func (m *HashMap[K, V]) ContainsKey(key any) bool {
+	return m.find(key) != nil
+}
+
+func (m *HashMap[K, V]) ContainsValue(value any) bool {
+	for it := m.iterator(); it.HasNext(); {
+		if Equals(it.nextEntry().value, value) {
+			return true
+		}
+	}
+	return false
+}
+
+// Put sets the value of a key, and returns the key's previous value, which is
+// the zero value if the map did not contain the key
+func (m *HashMap[K, V]) Put(key K, value V) V {
+	var previous V
+	if entry := m.find(key); entry != nil {
+		previous = entry.value
+	}
+	m.put(key, value, true)
+	return previous
+}
+
+// PutIfAbsent only sets the value of a key if the map does not contain the key,
+// and returns the key's previous value
+func (m *HashMap[K, V]) PutIfAbsent(key K, value V) V {
+	if existing := m.put(key, value, false); existing != nil {
+		return existing.value
+	}
+	var zero V
+	return zero
+}
+
+func (m *HashMap[K, V]) PutAll(other Map[K, V]) {
+	other.ForEach(func(key K, value V) {
+		m.put(key, value, true)
+	})
+}
+
+// Remove removes a key, and returns its value, or the zero value if the map did
+// not contain the key
+func (m *HashMap[K, V]) Remove(key any) V {
+	if entry := m.remove(key); entry != nil {
+		return entry.value
+	}
+	var zero V
+	return zero
+}
+
+func (m *HashMap[K, V]) Clear() {
+	m.modifications++
+	if m.size > 0 {
+		m.table = make([]*hashEntry[K, V], len(m.table))
+		m.size = 0
+	}
+}
+
+func (m *HashMap[K, V]) ComputeIfAbsent(key K, mapping func(K) V) V {
+	if entry := m.find(key); entry != nil {
+		return entry.value
+	}
+	expected := m.modifications
+	value := mapping(key)
+	checkModification(m.modifications, expected)
+	m.put(key, value, true)
+	return value
+}
+
+func (m *HashMap[K, V]) Merge(key K, value V, remapping func(V, V) V) V {
+	if entry := m.find(key); entry != nil {
+		expected := m.modifications
+		merged := remapping(entry.value, value)
+		checkModification(m.modifications, expected)
+		entry.value = merged
+		return merged
+	}
+	m.put(key, value, true)
+	return value
+}
+
+// ForEach runs an action for each of the entries
+//
+// Panics with a `ConcurrentModificationException` if the action modifies the
+// map
+func (m *HashMap[K, V]) ForEach(action func(K, V)) {
+	expected := m.modifications
+	for _, entry := range m.table {
+		for ; entry != nil && m.modifications == expected; entry = entry.next {
+			action(entry.key, entry.value)
+		}
+	}
+	checkModification(m.modifications, expected)
+}
+
+// KeySet returns a view of the map's keys
+func (m *HashMap[K, V]) KeySet() Set[K] {
+	return newMapView(m, func(entry *hashEntry[K, V]) K { return entry.key }, m.ContainsKey, func(key any) bool {
+		return m.remove(key) != nil
+	})
+}
+
+// Values returns a view of the map's values
+func (m *HashMap[K, V]) Values() Collection[V] {
+	return newMapView(m, func(entry *hashEntry[K, V]) V { return entry.value }, m.ContainsValue, nil)
+}
+
+// EntrySet returns a view of the map's entries, where setting the value of an
+// entry sets the value in the map
+func (m *HashMap[K, V]) EntrySet() Set[Entry[K, V]] {
+	containsEntry := func(value any) bool {
+		entry, ok := value.(Entry[K, V])
+		if !ok {
+			return false
+		}
+		found := m.find(entry.GetKey())
+		return found != nil && Equals(found.value, entry.GetValue())
+	}
+	return newMapView(m, func(entry *hashEntry[K, V]) Entry[K, V] { return entry }, containsEntry, func(value any) bool {
+		if !containsEntry(value) {
+			return false
+		}
+		return m.remove(value.(Entry[K, V]).GetKey()) != nil
+	})
+}
+
+// Equals tests if the map is equal to another value, which is when the other
+// value is also a map, with the same keys and values
+func (m *HashMap[K, V]) Equals(other any) bool {
+	if any(m) == other {
+		return true
+	}
+	otherMap, ok := other.(Map[K, V])
+	if !ok || m.size != otherMap.Size() {
+		return false
+	}
+	for it := m.iterator(); it.HasNext(); {
+		entry := it.nextEntry()
+		if !otherMap.ContainsKey(entry.key) || !Equals(entry.value, otherMap.Get(entry.key)) {
+			return false
+		}
+	}
+	return true
+}
+
+// HashCode computes the hash code of the map the way that Java does, which is
+// the sum of the hash codes of its entries
+func (m *HashMap[K, V]) HashCode() int32 {
+	var hash int32
+	for it := m.iterator(); it.HasNext(); {
+		hash += it.nextEntry().HashCode()
+	}
+	return hash
+}
+
+// String formats the map like Java, such as `{a=1, b=2}`
+func (m *HashMap[K, V]) String() string {
+	var builder strings.Builder
+	builder.WriteByte('{')
+	for it := m.iterator(); it.HasNext(); {
+		builder.WriteString(it.nextEntry().String())
+		if it.HasNext() {
+			builder.WriteString(", ")
+		}
+	}
+	builder.WriteByte('}')
+	return builder.String()
+}
+
+func (e *hashEntry[K, V]) GetKey() K {
+	return e.key
+}
+
+func (e *hashEntry[K, V]) GetValue() V {
+	return e.value
+}
+
+func (e *hashEntry[K, V]) SetValue(value V) V {
+	previous := e.value
+	e.value = value
+	return previous
+}
+
+func (e *hashEntry[K, V]) Equals(other any) bool {
+	entry, ok := other.(Entry[K, V])
+	return ok && Equals(e.key, entry.GetKey()) && Equals(e.value, entry.GetValue())
+}
+
+func (e *hashEntry[K, V]) HashCode() int32 {
+	return HashOf(e.key) ^ HashOf(e.value)
+}
+
+func (e *hashEntry[K, V]) String() string {
+	return ValueOf(e.key) + "=" + ValueOf(e.value)
+}
+
+// iterator returns a fail-fast iterator over the map's entries
+func (m *HashMap[K, V]) iterator() *hashIterator[K, V] {
+	it := &hashIterator[K, V]{m: m, expected: m.modifications}
+	it.advance()
+	return it
+}
+
+// hashIterator goes through the entries of a `HashMap`, in the order of the
+// buckets that they are in
+type hashIterator[K, V any] struct {
+	m *HashMap[K, V]
+	// The index of the bucket after the next entry
+	bucket        int
+	next, current *hashEntry[K, V]
+	expected      int
+}
+
+// advance moves on to the next entry that is in the map
+func (it *hashIterator[K, V]) advance() {
+	if it.next != nil {
+		it.next = it.next.next
+	}
+	for it.next == nil && it.bucket < len(it.m.table) {
+		it.next = it.m.table[it.bucket]
+		it.bucket++
+	}
+}
+
+func (it *hashIterator[K, V]) HasNext() bool {
+	return it.next != nil
+}
+
+func (it *hashIterator[K, V]) nextEntry() *hashEntry[K, V] {
+	checkModification(it.m.modifications, it.expected)
+	if it.next == nil {
+		noSuchElement()
+	}
+	it.current = it.next
+	it.advance()
+	return it.current
+}
+
+// Remove panics with an `IllegalStateException` if `Next` has not been called
+// since the iterator was created, or since the last entry was removed
+func (it *hashIterator[K, V]) Remove() {
+	if it.current == nil {
+		panic(New[IllegalStateException]("", nil))
+	}
+	checkModification(it.m.modifications, it.expected)
+	it.m.remove(it.current.key)
+	it.current = nil
+	it.expected = it.m.modifications
+}
+
+// mapIterator iterates over one part of each of a map's entries, such as their
+// keys
+type mapIterator[K, V, E any] struct {
+	*hashIterator[K, V]
+	part func(*hashEntry[K, V]) E
+}
+
+func (it mapIterator[K, V, E]) Next() E {
+	return it.part(it.nextEntry())
+}
+
+// mapView is the set or collection that is a view of one part of each of a
+// map's entries, such as a map's keys
+type mapView[K, V, E any] struct {
+	abstractCollection[E]
+	m        *HashMap[K, V]
+	part     func(*hashEntry[K, V]) E
+	contains func(any) bool
+	// Views that can't remove values by themselves leave this as nil, and
+	// remove them with an iterator instead
+	remove func(any) bool
+}
+
+func newMapView[K, V, E any](m *HashMap[K, V], part func(*hashEntry[K, V]) E, contains func(any) bool, remove func(any) bool) *mapView[K, V, E] {
+	view := &mapView[K, V, E]{m: m, part: part, contains: contains, remove: remove}
+	view.abstractCollection = abstractCollection[E]{iterator: view.Iterator, size: m.Size}
+	return view
+}
+
+func (v *mapView[K, V, E]) Iterator() Iterator[E] {
+	return mapIterator[K, V, E]{hashIterator: v.m.iterator(), part: v.part}
+}
+
+func (v *mapView[K, V, E]) Size() int32 {
+	return v.m.size
+}
+
+func (v *mapView[K, V, E]) Contains(value any) bool {
+	return v.contains(value)
+}
+
+func (v *mapView[K, V, E]) Remove(value any) bool {
+	if v.remove == nil {
+		return v.abstractCollection.Remove(value)
+	}
+	return v.remove(value)
+}
+
+func (v *mapView[K, V, E]) Clear() {
+	v.m.Clear()
+}
+
+// Equals compares the view like a set, since the only view that isn't a set
+// is a map's values, which Java compares by their identity
+func (v *mapView[K, V, E]) Equals(other any) bool {
+	if v.remove == nil {
+		return any(v) == other
+	}
+	return setEquals[E](v, other)
+}
+
+func (v *mapView[K, V, E]) HashCode() int32 {
+	if v.remove == nil {
+		return identityHash(v)
+	}
+	return setHashCode[E](v)
+}
@@ -0,0 +1,110 @@
+package stdjava
+
+import (
+	"testing"
+)
+
+func TestHashOf(t *testing.T) {
+	if HashOf("hello") != 99162322 || HashOf("Aa") != HashOf("BB") {
+		t.Errorf("Expected strings to hash like Java, got %d", HashOf("hello"))
+	}
+	if HashOf(int64(1)<<32) != 1 || HashOf(true) != 1231 || HashOf(1.0) != 1072693248 {
+		t.Errorf("Expected boxed values to hash like Java")
+	}
+}
+
+func TestHashMapOrder(t *testing.T) {
+	m := NewHashMap[int32, string]()
+	m.Put(100, "a")
+	m.Put(3, "b")
+	m.Put(17, "c")
+	m.Put(1, "d")
+	if m.String() != "{17=c, 1=d, 3=b, 100=a}" {
+		t.Errorf("Expected the entries to be in Java's order, got %s", m)
+	}
+}
+
+func TestHashMapResize(t *testing.T) {
+	m := NewHashMap[string, int32]()
+	for ind := int32(0); ind < 100; ind++ {
+		m.Put(ValueOf(ind), ind)
+	}
+	for ind := int32(0); ind < 100; ind++ {
+		if m.Get(ValueOf(ind)) != ind {
+			t.Fatalf("Expected %d to be kept when the map was resized", ind)
+		}
+	}
+	if m.Size() != 100 || len(m.table) != 256 {
+		t.Errorf("Expected 100 entries in 256 buckets, got %d in %d", m.Size(), len(m.table))
+	}
+}
+
+func TestHashMapMethods(t *testing.T) {
+	m := NewHashMap[string, int32]()
+	if m.Put("a", 1) != 0 || m.Put("a", 2) != 1 || m.PutIfAbsent("a", 3) != 2 {
+		t.Errorf("Expected Put to return the previous values")
+	}
+	m.Merge("a", 5, func(a, b int32) int32 { return a + b })
+	m.Merge("b", 5, func(a, b int32) int32 { return a + b })
+	m.ComputeIfAbsent("c", func(key string) int32 { return Length(key) })
+	if m.String() != "{a=7, b=5, c=1}" || m.GetOrDefault("d", -1) != -1 {
+		t.Errorf("Expected {a=7, b=5, c=1}, got %s", m)
+	}
+	if m.Remove("a") != 7 || m.ContainsKey("a") || !m.ContainsValue(int32(5)) {
+		t.Errorf("Expected a to be removed, got %s", m)
+	}
+}
+
+func TestHashMapViews(t *testing.T) {
+	m := NewHashMap[string, int32]()
+	m.Put("a", 1)
+	m.Put("b", 2)
+	m.Put("c", 3)
+	m.KeySet().Remove("a")
+	for it := m.EntrySet().Iterator(); it.HasNext(); {
+		entry := it.Next()
+		entry.SetValue(entry.GetValue() * 10)
+	}
+	if m.String() != "{b=20, c=30}" || m.Values().String() != "[20, 30]" {
+		t.Errorf("Expected the views to modify the map, got %s", m)
+	}
+}
+
+type point struct {
+	x, y int32
+}
+
+func (p *point) Equals(other any) bool {
+	o, ok := other.(*point)
+	return ok && o.x == p.x && o.y == p.y
+}
+
+func (p *point) HashCode() int32 {
+	return 31*p.x + p.y
+}
+
+func TestHashSetObjectKeys(t *testing.T) {
+	set := NewHashSet[*point]()
+	set.Add(&point{1, 2})
+	if set.Add(&point{1, 2}) || !set.Contains(&point{1, 2}) || set.Size() != 1 {
+		t.Errorf("Expected keys to be compared with their Equals method")
+	}
+	other := NewHashSetFrom[*point](set)
+	if !set.Equals(other) || set.HashCode() != 33 {
+		t.Errorf("Expected sets with the same elements to be equal")
+	}
+}
+
+func TestHashMapFailFast(t *testing.T) {
+	defer func() {
+		if _, ok := recover().(ConcurrentModificationException); !ok {
+			t.Errorf("Expected a ConcurrentModificationException")
+		}
+	}()
+	m := NewHashMap[int32, int32]()
+	m.Put(1, 1)
+	m.Put(2, 2)
+	for it := m.KeySet().Iterator(); it.HasNext(); {
+		m.Put(it.Next()+10, 0)
+	}
+}
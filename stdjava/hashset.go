@@ -0,0 +1,77 @@
+package stdjava
+
+// HashSet is an implementation of Java's `HashSet`, which is backed by the keys
+// of a `HashMap`, so it iterates over its elements in the same order as Java
+type HashSet[E any] struct {
+	abstractCollection[E]
+	m *HashMap[E, struct{}]
+}
+
+// NewHashSet creates an empty set
+func NewHashSet[E any]() *HashSet[E] {
+	return newHashSet(NewHashMap[E, struct{}]())
+}
+
+// NewHashSetCapacity creates an empty set with room for the given number of
+// elements
+//
+// Panics with an `IllegalArgumentException` if the capacity is negative
+func NewHashSetCapacity[E any](capacity int32) *HashSet[E] {
+	return newHashSet(NewHashMapCapacity[E, struct{}](capacity))
+}
+
+// NewHashSetFrom creates a set that contains the elements of a collection
+func NewHashSetFrom[E any](values Collection[E]) *HashSet[E] {
+	capacity := int32(float32(values.Size())/loadFactor) + 1
+	if capacity < defaultCapacity {
+		capacity = defaultCapacity
+	}
+	set := NewHashSetCapacity[E](capacity)
+	set.AddAll(values)
+	return set
+}
+
+func newHashSet[E any](m *HashMap[E, struct{}]) *HashSet[E] {
+	set := &HashSet[E]{m: m}
+	set.abstractCollection = abstractCollection[E]{iterator: set.Iterator, size: set.Size, add: set.Add}
+	return set
+}
+
+func (s *HashSet[E]) Size() int32 {
+	return s.m.size
+}
+
+func (s *HashSet[E]) IsEmpty() bool {
+	return s.m.size == 0
+}
+
+func (s *HashSet[E]) Contains(value any) bool {
+	return s.m.ContainsKey(value)
+}
+
+// Add adds an element, and returns whether the set did not already contain it
+func (s *HashSet[E]) Add(value E) bool {
+	return s.m.put(value, struct{}{}, false) == nil
+}
+
+// Remove removes an element, and returns whether the set contained it
+func (s *HashSet[E]) Remove(value any) bool {
+	return s.m.remove(value) != nil
+}
+
+func (s *HashSet[E]) Clear() {
+	s.m.Clear()
+}
+
+// Iterator returns a fail-fast iterator over the elements
+func (s *HashSet[E]) Iterator() Iterator[E] {
+	return mapIterator[E, struct{}, E]{hashIterator: s.m.iterator(), part: func(entry *hashEntry[E, struct{}]) E { return entry.key }}
+}
+
+func (s *HashSet[E]) Equals(other any) bool {
+	return setEquals[E](s, other)
+}
+
+func (s *HashSet[E]) HashCode() int32 {
+	return setHashCode[E](s)
+}
@@ -0,0 +1,355 @@
+package stdjava
+
+import "sort"
+
+// LinkedList is an implementation of Java's `LinkedList`, which is a doubly
+// linked list that is both a list and a deque
+type LinkedList[E any] struct {
+	abstractCollection[E]
+	first, last   *linkedNode[E]
+	size          int32
+	modifications int
+}
+
+// linkedNode is one of the elements of a `LinkedList`
+type linkedNode[E any] struct {
+	value      E
+	prev, next *linkedNode[E]
+}
+
+// NewLinkedList creates an empty list
+func NewLinkedList[E any]() *LinkedList[E] {
+	list := &LinkedList[E]{}
+	list.abstractCollection = abstractCollection[E]{iterator: list.Iterator, size: list.Size, add: list.Add}
+	return list
+}
+
+// NewLinkedListFrom creates a list that contains the elements of a collection
+func NewLinkedListFrom[E any](values Collection[E]) *LinkedList[E] {
+	list := NewLinkedList[E]()
+	list.AddAll(values)
+	return list
+}
+
+func (l *LinkedList[E]) Size() int32 {
+	return l.size
+}
+
+// node returns the node at an index, which is found from whichever end of the
+// list is closer
+func (l *LinkedList[E]) node(index int32) *linkedNode[E] {
+	if index < l.size/2 {
+		current := l.first
+		for ; index > 0; index-- {
+			current = current.next
+		}
+		return current
+	}
+	current := l.last
+	for ind := l.size - 1; ind > index; ind-- {
+		current = current.prev
+	}
+	return current
+}
+
+// linkBefore inserts a value before a node, or at the end of the list if the
+// node is nil
+func (l *LinkedList[E]) linkBefore(value E, successor *linkedNode[E]) {
+	node := &linkedNode[E]{value: value, next: successor}
+	if successor == nil {
+		node.prev = l.last
+		l.last = node
+	} else {
+		node.prev = successor.prev
+		successor.prev = node
+	}
+	if node.prev == nil {
+		l.first = node
+	} else {
+		node.prev.next = node
+	}
+	l.size++
+	l.modifications++
+}
+
+// unlink removes a node from the list, and returns its value
+func (l *LinkedList[E]) unlink(node *linkedNode[E]) E {
+	if node.prev == nil {
+		l.first = node.next
+	} else {
+		node.prev.next = node.next
+	}
+	if node.next == nil {
+		l.last = node.prev
+	} else {
+		node.next.prev = node.prev
+	}
+	l.size--
+	l.modifications++
+	return node.value
+}
+
+// Get panics with an `IndexOutOfBoundsException` if the index is outside of
+// the list, like all of the methods that take an index
+func (l *LinkedList[E]) Get(index int32) E {
+	checkIndex(index, l.size)
+	return l.node(index).value
+}
+
+// Set replaces the element at an index, and returns the element that was
+// replaced
+func (l *LinkedList[E]) Set(index int32, value E) E {
+	checkIndex(index, l.size)
+	node := l.node(index)
+	previous := node.value
+	node.value = value
+	return previous
+}
+
+func (l *LinkedList[E]) Add(value E) bool {
+	l.linkBefore(value, nil)
+	return true
+}
+
+// AddAt inserts an element at an index, which may be the end of the list
+func (l *LinkedList[E]) AddAt(index int32, value E) {
+	checkIndex(index, l.size+1)
+	if index == l.size {
+		l.linkBefore(value, nil)
+		return
+	}
+	l.linkBefore(value, l.node(index))
+}
+
+// RemoveAt removes the element at an index, and returns it
+func (l *LinkedList[E]) RemoveAt(index int32) E {
+	checkIndex(index, l.size)
+	return l.unlink(l.node(index))
+}
+
+func (l *LinkedList[E]) Clear() {
+	l.first, l.last, l.size = nil, nil, 0
+	l.modifications++
+}
+
+// IndexOf returns the index of the first element that is equal to the value,
+// or -1 if there isn't one
+func (l *LinkedList[E]) IndexOf(value any) int32 {
+	var index int32
+	for node := l.first; node != nil; node = node.next {
+		if Equals(node.value, value) {
+			return index
+		}
+		index++
+	}
+	return -1
+}
+
+// LastIndexOf returns the index of the last element that is equal to the
+// value, or -1 if there isn't one
+func (l *LinkedList[E]) LastIndexOf(value any) int32 {
+	index := l.size - 1
+	for node := l.last; node != nil; node = node.prev {
+		if Equals(node.value, value) {
+			return index
+		}
+		index--
+	}
+	return -1
+}
+
+func (l *LinkedList[E]) Contains(value any) bool {
+	return l.IndexOf(value) >= 0
+}
+
+// Sort sorts the list with a stable sort, like Java does
+func (l *LinkedList[E]) Sort(compare func(a, b E) int32) {
+	values := l.ToArray()
+	sort.SliceStable(values, func(i, j int) bool {
+		if compare == nil {
+			return Compare(values[i], values[j]) < 0
+		}
+		return compare(values[i], values[j]) < 0
+	})
+	node := l.first
+	for _, value := range values {
+		node.value = value
+		node = node.next
+	}
+	l.modifications++
+}
+
+// ReplaceAll replaces each element with the result of a function
+func (l *LinkedList[E]) ReplaceAll(operator func(E) E) {
+	for node := l.first; node != nil; node = node.next {
+		node.value = operator(node.value)
+	}
+}
+
+// Iterator returns a fail-fast iterator over the elements
+func (l *LinkedList[E]) Iterator() Iterator[E] {
+	return &linkedListIterator[E]{list: l, next: l.first, expected: l.modifications}
+}
+
+// DescendingIterator returns a fail-fast iterator over the elements, from the
+// last element to the first
+func (l *LinkedList[E]) DescendingIterator() Iterator[E] {
+	return &linkedListIterator[E]{list: l, next: l.last, expected: l.modifications, descending: true}
+}
+
+func (l *LinkedList[E]) Equals(other any) bool {
+	return listEquals[E](l, other)
+}
+
+func (l *LinkedList[E]) HashCode() int32 {
+	return listHashCode[E](l)
+}
+
+func (l *LinkedList[E]) AddFirst(value E) {
+	l.linkBefore(value, l.first)
+}
+
+func (l *LinkedList[E]) AddLast(value E) {
+	l.linkBefore(value, nil)
+}
+
+func (l *LinkedList[E]) OfferFirst(value E) bool {
+	l.AddFirst(value)
+	return true
+}
+
+func (l *LinkedList[E]) OfferLast(value E) bool {
+	l.AddLast(value)
+	return true
+}
+
+func (l *LinkedList[E]) Offer(value E) bool {
+	return l.OfferLast(value)
+}
+
+func (l *LinkedList[E]) Push(value E) {
+	l.AddFirst(value)
+}
+
+// RemoveFirst panics with a `NoSuchElementException` if the list is empty, like
+// all of the methods that remove or get an element from either end of the list
+func (l *LinkedList[E]) RemoveFirst() E {
+	if l.first == nil {
+		noSuchElement()
+	}
+	return l.unlink(l.first)
+}
+
+func (l *LinkedList[E]) RemoveLast() E {
+	if l.last == nil {
+		noSuchElement()
+	}
+	return l.unlink(l.last)
+}
+
+func (l *LinkedList[E]) RemoveHead() E {
+	return l.RemoveFirst()
+}
+
+func (l *LinkedList[E]) Pop() E {
+	return l.RemoveFirst()
+}
+
+func (l *LinkedList[E]) GetFirst() E {
+	if l.first == nil {
+		noSuchElement()
+	}
+	return l.first.value
+}
+
+func (l *LinkedList[E]) GetLast() E {
+	if l.last == nil {
+		noSuchElement()
+	}
+	return l.last.value
+}
+
+func (l *LinkedList[E]) Element() E {
+	return l.GetFirst()
+}
+
+// PollFirst removes the first element, or returns the zero value if the list
+// is empty, like all of the methods that poll or peek
+func (l *LinkedList[E]) PollFirst() E {
+	if l.first == nil {
+		var zero E
+		return zero
+	}
+	return l.unlink(l.first)
+}
+
+func (l *LinkedList[E]) PollLast() E {
+	if l.last == nil {
+		var zero E
+		return zero
+	}
+	return l.unlink(l.last)
+}
+
+func (l *LinkedList[E]) Poll() E {
+	return l.PollFirst()
+}
+
+func (l *LinkedList[E]) PeekFirst() E {
+	if l.first == nil {
+		var zero E
+		return zero
+	}
+	return l.first.value
+}
+
+func (l *LinkedList[E]) PeekLast() E {
+	if l.last == nil {
+		var zero E
+		return zero
+	}
+	return l.last.value
+}
+
+func (l *LinkedList[E]) Peek() E {
+	return l.PeekFirst()
+}
+
+// linkedListIterator is the iterator of a `LinkedList`, which goes in either
+// direction
+type linkedListIterator[E any] struct {
+	list       *LinkedList[E]
+	next, last *linkedNode[E]
+	expected   int
+	descending bool
+}
+
+func (it *linkedListIterator[E]) HasNext() bool {
+	return it.next != nil
+}
+
+func (it *linkedListIterator[E]) Next() E {
+	checkModification(it.list.modifications, it.expected)
+	if it.next == nil {
+		noSuchElement()
+	}
+	it.last = it.next
+	if it.descending {
+		it.next = it.next.prev
+	} else {
+		it.next = it.next.next
+	}
+	return it.last.value
+}
+
+// Remove panics with an `IllegalStateException` if `Next` has not been called
+// since the iterator was created, or since the last element was removed
+func (it *linkedListIterator[E]) Remove() {
+	if it.last == nil {
+		panic(New[IllegalStateException]("", nil))
+	}
+	checkModification(it.list.modifications, it.expected)
+	it.list.unlink(it.last)
+	it.last = nil
+	it.expected = it.list.modifications
+}
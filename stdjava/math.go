@@ -0,0 +1,165 @@
+package stdjava
+
+import (
+	"math"
+	"math/rand"
+	"sync"
+	"time"
+
+	"golang.org/x/exp/constraints"
+)
+
+// Java's `Math` and `StrictMath` methods that behave differently from the
+// functions in Go's `math` package, or that are overloaded for each of Java's
+// numeric types, which are generic functions
+
+// Number is any of the types that Java's numeric types are represented as
+type Number interface {
+	constraints.Signed | constraints.Float
+}
+
+// Max is an implementation of `Math.max`, where the maximum of NaN and any
+// other number is NaN, and positive zero is larger than negative zero
+func Max[T Number](a, b T) T {
+	switch {
+	case a != a:
+		return a
+	case a == 0 && b == 0:
+		// One of the zeroes may be negative, and adding them only gives a negative
+		// zero if both of them are
+		return a + b
+	case a >= b:
+		return a
+	}
+	return b
+}
+
+// Min is an implementation of `Math.min`, with the same rules as `Max`, where
+// negative zero is smaller than positive zero
+func Min[T Number](a, b T) T {
+	switch {
+	case a != a:
+		return a
+	case a == 0 && b == 0:
+		return -(-a - b)
+	case a <= b:
+		return a
+	}
+	return b
+}
+
+// Abs is an implementation of `Math.abs`, where the absolute value of the
+// smallest integer is itself, since it can't be negated, and the absolute value
+// of negative zero is positive zero
+func Abs[T Number](value T) T {
+	if value <= 0 {
+		return 0 - value
+	}
+	return value
+}
+
+// FloorDiv is an implementation of `Math.floorDiv`, which rounds the quotient
+// towards negative infinity, instead of towards zero
+//
+// Panics with an `ArithmeticException` if the divisor is zero
+func FloorDiv[T constraints.Signed](x, y T) T {
+	if y == 0 {
+		panic(New[ArithmeticException]("/ by zero", nil))
+	}
+	quotient := x / y
+	if (x%y != 0) && ((x < 0) != (y < 0)) {
+		quotient--
+	}
+	return quotient
+}
+
+// FloorMod is an implementation of `Math.floorMod`, where the result has the
+// same sign as the divisor, instead of the dividend
+//
+// Panics with an `ArithmeticException` if the divisor is zero
+func FloorMod[T constraints.Signed](x, y T) T {
+	if y == 0 {
+		panic(New[ArithmeticException]("/ by zero", nil))
+	}
+	mod := x % y
+	if mod != 0 && ((mod < 0) != (y < 0)) {
+		mod += y
+	}
+	return mod
+}
+
+// Round is an implementation of `Math.round` for doubles, which rounds to the
+// closest long, with ties rounding up, where NaN becomes zero, and values that
+// are out of range saturate
+func Round(value float64) int64 {
+	return FloatToLong(roundHalfUp(value))
+}
+
+// RoundFloat is an implementation of `Math.round` for floats, which rounds to
+// the closest int, with the same rules as `Round`
+func RoundFloat(value float32) int32 {
+	// Every float is exactly a double, so it is rounded the same way
+	return FloatToInt(roundHalfUp(float64(value)))
+}
+
+func roundHalfUp(value float64) float64 {
+	floor := math.Floor(value)
+	// Subtracting the floor is exact, unlike adding a half to the value
+	if value-floor >= 0.5 {
+		return floor + 1
+	}
+	return floor
+}
+
+// Signum is an implementation of `Math.signum`, which is one with the sign of
+// the value, or the value itself if it is zero or NaN
+func Signum[T constraints.Float](value T) T {
+	switch {
+	case value > 0:
+		return 1
+	case value < 0:
+		return -1
+	}
+	return value
+}
+
+// Pow is an implementation of `Math.pow`, which differs from `math.Pow` when
+// raising one to a power that isn't a number, or is infinite, which are NaN
+func Pow(x, y float64) float64 {
+	if y != y || (math.IsInf(y, 0) && math.Abs(x) == 1) {
+		return math.NaN()
+	}
+	return math.Pow(x, y)
+}
+
+// The factors that convert between degrees and radians, which are computed
+// from the double closest to pi, the same as in Java
+const (
+	degreesToRadians = float64(math.Pi) / 180
+	radiansToDegrees = 180 / float64(math.Pi)
+)
+
+// ToRadians is an implementation of `Math.toRadians`
+func ToRadians(degrees float64) float64 {
+	return degrees * degreesToRadians
+}
+
+// ToDegrees is an implementation of `Math.toDegrees`
+func ToDegrees(radians float64) float64 {
+	return radians * radiansToDegrees
+}
+
+// The generator that `Random` uses, which is seeded differently every time,
+// since Java's `Math.random` is, and is shared by every goroutine
+var (
+	randomLock      sync.Mutex
+	randomGenerator = rand.New(rand.NewSource(time.Now().UnixNano()))
+)
+
+// Random is an implementation of `Math.random`, which returns a random double
+// that is at least zero, and less than one
+func Random() float64 {
+	randomLock.Lock()
+	defer randomLock.Unlock()
+	return randomGenerator.Float64()
+}
@@ -0,0 +1,97 @@
+package stdjava
+
+import (
+	"math"
+	"testing"
+)
+
+func TestMaxMin(t *testing.T) {
+	if Max[int32](3, -4) != 3 || Min[int64](3, -4) != -4 || Max[int8](5, 5) != 5 {
+		t.Errorf("Expected the integers to be compared")
+	}
+	negativeZero := math.Copysign(0, -1)
+	if math.Signbit(Max(negativeZero, 0)) || !math.Signbit(Min(0, negativeZero)) || !math.Signbit(Max(negativeZero, negativeZero)) {
+		t.Errorf("Expected positive zero to be larger than negative zero")
+	}
+	if !math.IsNaN(Max(math.NaN(), 1)) || !math.IsNaN(Min(1, math.NaN())) {
+		t.Errorf("Expected NaN to be the result of comparing NaN")
+	}
+}
+
+func TestAbs(t *testing.T) {
+	if Abs[int32](-5) != 5 || Abs[int64](7) != 7 || Abs[float32](-1.5) != 1.5 {
+		t.Errorf("Expected the absolute values of the numbers")
+	}
+	if Abs(MinInt) != MinInt || Abs(MinLong) != MinLong {
+		t.Errorf("Expected the smallest integers to stay negative")
+	}
+	if math.Signbit(Abs(math.Copysign(0, -1))) || !math.IsNaN(Abs(math.NaN())) {
+		t.Errorf("Expected negative zero to become positive, and NaN to stay NaN")
+	}
+}
+
+func TestFloorDivMod(t *testing.T) {
+	for _, test := range []struct {
+		x, y, div, mod int32
+	}{
+		{7, 2, 3, 1},
+		{-7, 2, -4, 1},
+		{7, -2, -4, -1},
+		{-7, -2, 3, -1},
+		{-6, 3, -2, 0},
+		{MinInt, -1, MinInt, 0},
+	} {
+		if div := FloorDiv(test.x, test.y); div != test.div {
+			t.Errorf("Expected floorDiv(%d, %d) to be %d, got %d", test.x, test.y, test.div, div)
+		}
+		if mod := FloorMod(test.x, test.y); mod != test.mod {
+			t.Errorf("Expected floorMod(%d, %d) to be %d, got %d", test.x, test.y, test.mod, mod)
+		}
+	}
+
+	defer func() {
+		if exception, ok := recover().(ArithmeticException); !ok || exception.GetMessage() != "/ by zero" {
+			t.Errorf("Expected an ArithmeticException, got %v", exception)
+		}
+	}()
+	FloorMod[int64](1, 0)
+}
+
+func TestRound(t *testing.T) {
+	for _, test := range []struct {
+		value   float64
+		rounded int64
+	}{
+		{2.5, 3},
+		{-2.5, -2},
+		{-2.6, -3},
+		{0.49999999999999994, 0},
+		{math.NaN(), 0},
+		{1e20, math.MaxInt64},
+		{math.Inf(-1), math.MinInt64},
+	} {
+		if rounded := Round(test.value); rounded != test.rounded {
+			t.Errorf("Expected %v to be rounded to %d, got %d", test.value, test.rounded, rounded)
+		}
+	}
+	if RoundFloat(-0.5) != 0 || RoundFloat(1.5) != 2 || RoundFloat(1e10) != math.MaxInt32 {
+		t.Errorf("Expected the floats to be rounded to ints")
+	}
+}
+
+func TestMathFunctions(t *testing.T) {
+	if Signum(-3.0) != -1 || Signum[float32](2) != 1 || !math.Signbit(Signum(math.Copysign(0, -1))) {
+		t.Errorf("Expected the signs of the numbers")
+	}
+	if Pow(2, 10) != 1024 || !math.IsNaN(Pow(1, math.NaN())) || !math.IsNaN(Pow(-1, math.Inf(1))) {
+		t.Errorf("Expected powers to be computed like Math.pow")
+	}
+	if ToRadians(180) != math.Pi || ToDegrees(math.Pi/2) != 90 {
+		t.Errorf("Expected the angles to be converted")
+	}
+	for i := 0; i < 100; i++ {
+		if random := Random(); random < 0 || random >= 1 {
+			t.Errorf("Expected a random number from zero to one, got %v", random)
+		}
+	}
+}
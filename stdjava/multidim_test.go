@@ -24,3 +24,10 @@ func TestAdvancedGrid(t *testing.T) {
 		t.Errorf("Got %d third, expected %d", len(arr), 3)
 	}
 }
+
+func TestFourDimensions(t *testing.T) {
+	arr := MultiDimensionArray4([][][]int{}, 1, 2, 3, 4)
+	if len(arr[0][1][2]) != 4 {
+		t.Errorf("Got %d fourth, expected %d", len(arr[0][1][2]), 4)
+	}
+}
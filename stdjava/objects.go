@@ -0,0 +1,219 @@
+package stdjava
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+)
+
+// Equals is an implementation of Java's `Objects.equals`, which is how the
+// collections compare their elements
+//
+// Values with an `Equals` method, such as classes that override Java's
+// `equals`, are compared with it, and any other values are compared the way
+// that Java compares them, where boxes are compared by their values, numbers of
+// different types, such as an `Integer` and a `Long`, are never equal, and
+// references are equal if they are the same object
+func Equals(a, b any) bool {
+	if isNull(a) || isNull(b) {
+		return isNull(a) && isNull(b)
+	}
+	a, b = unboxed(a), unboxed(b)
+	if equaler, ok := a.(interface{ Equals(any) bool }); ok {
+		return equaler.Equals(b)
+	}
+
+	switch a := a.(type) {
+	case float64:
+		// Doubles are compared by their bits, so NaN is equal to itself, but zero
+		// is not equal to negative zero
+		b, ok := b.(float64)
+		return ok && doubleBits(a) == doubleBits(b)
+	case float32:
+		b, ok := b.(float32)
+		return ok && floatBits(a) == floatBits(b)
+	}
+
+	aType, bType := reflect.TypeOf(a), reflect.TypeOf(b)
+	if aType != bType {
+		return false
+	}
+	// Arrays are equal if they are the same array
+	if aType.Kind() == reflect.Slice {
+		aValue, bValue := reflect.ValueOf(a), reflect.ValueOf(b)
+		return aValue.Pointer() == bValue.Pointer() && aValue.Len() == bValue.Len()
+	}
+	if !aType.Comparable() {
+		return false
+	}
+	return a == b
+}
+
+// HashOf is an implementation of Java's `Objects.hashCode`, which computes the
+// same hash codes that Java does for strings and boxed values, so that hash
+// tables of them have the same order as Java's
+//
+// Values with a `HashCode` method, such as classes that override Java's
+// `hashCode`, are hashed with it, and other references are hashed by their
+// identity
+func HashOf(value any) int32 {
+	if isNull(value) {
+		return 0
+	}
+	value = unboxed(value)
+	if hasher, ok := value.(interface{ HashCode() int32 }); ok {
+		return hasher.HashCode()
+	}
+
+	switch v := value.(type) {
+	case string:
+		return HashCode(v)
+	case bool:
+		if v {
+			return 1231
+		}
+		return 1237
+	case int8:
+		return int32(v)
+	case int16:
+		return int32(v)
+	case int32:
+		return v
+	case uint16:
+		return int32(v)
+	case int:
+		return int32(v)
+	case int64:
+		return int32(v ^ int64(uint64(v)>>32))
+	case float64:
+		bits := doubleBits(v)
+		return int32(bits ^ bits>>32)
+	case float32:
+		return int32(floatBits(v))
+	}
+
+	switch reflect.ValueOf(value).Kind() {
+	case reflect.Pointer, reflect.Slice, reflect.Map, reflect.Chan, reflect.Func:
+		return identityHash(value)
+	}
+	return HashOf(fmt.Sprintf("%#v", value))
+}
+
+// identityHash hashes a reference by its address, like Java's
+// `System.identityHashCode`
+func identityHash(reference any) int32 {
+	address := uint64(reflect.ValueOf(reference).Pointer())
+	return int32(address ^ address>>32)
+}
+
+// doubleBits returns the bits of a double, where all NaNs have the same bits,
+// like Java's `Double.doubleToLongBits`
+func doubleBits(value float64) uint64 {
+	if math.IsNaN(value) {
+		return 0x7ff8000000000000
+	}
+	return math.Float64bits(value)
+}
+
+// floatBits returns the bits of a float, where all NaNs have the same bits,
+// like Java's `Float.floatToIntBits`
+func floatBits(value float32) uint32 {
+	if value != value {
+		return 0x7fc00000
+	}
+	return math.Float32bits(value)
+}
+
+// isNull tests if a value is Java's null, which is either nil, or a nil
+// reference that still has a type
+func isNull(value any) bool {
+	if value == nil {
+		return true
+	}
+	switch reflected := reflect.ValueOf(value); reflected.Kind() {
+	case reflect.Pointer, reflect.Interface, reflect.Map, reflect.Slice, reflect.Func, reflect.Chan:
+		return reflected.IsNil()
+	}
+	return false
+}
+
+// Compare compares two values by their natural order, like Java's
+// `Comparable`, and returns a negative number, zero, or a positive number if
+// the first value is less than, equal to, or greater than the second
+//
+// Values with a `CompareTo` method, such as classes that implement Java's
+// `Comparable`, are compared with it
+//
+// Panics with a `ClassCastException` if the values can't be compared
+func Compare(a, b any) int32 {
+	if isNull(a) || isNull(b) {
+		panic(New[NullPointerException]("", nil))
+	}
+	a, b = unboxed(a), unboxed(b)
+	if method := reflect.ValueOf(a).MethodByName("CompareTo"); method.IsValid() && method.Type().NumIn() == 1 {
+		if argument := reflect.ValueOf(b); argument.Type().AssignableTo(method.Type().In(0)) {
+			return int32(method.Call([]reflect.Value{argument})[0].Int())
+		}
+	}
+
+	switch a := a.(type) {
+	case string:
+		if b, ok := b.(string); ok {
+			return CompareTo(a, b)
+		}
+	case bool:
+		if b, ok := b.(bool); ok {
+			return compareOrdered(boolNumber(a), boolNumber(b))
+		}
+	case float64:
+		if b, ok := b.(float64); ok {
+			return compareFloat(a, b)
+		}
+	case float32:
+		if b, ok := b.(float32); ok {
+			return compareFloat(float64(a), float64(b))
+		}
+	}
+
+	aValue, bValue := reflect.ValueOf(a), reflect.ValueOf(b)
+	if aValue.Type() == bValue.Type() {
+		switch {
+		case aValue.CanInt():
+			return compareOrdered(aValue.Int(), bValue.Int())
+		case aValue.CanUint():
+			return compareOrdered(aValue.Uint(), bValue.Uint())
+		}
+	}
+	panic(New[ClassCastException](fmt.Sprintf("%T cannot be compared to %T", a, b), nil))
+}
+
+// compareOrdered compares two numbers
+func compareOrdered[T int64 | uint64](a, b T) int32 {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+// compareFloat compares two floating point numbers like Java's `Double.compare`,
+// where negative zero is less than zero, and NaN is greater than any other value
+func compareFloat(a, b float64) int32 {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return compareOrdered(int64(doubleBits(a)), int64(doubleBits(b)))
+}
+
+// boolNumber converts a boolean into a number, where false comes before true
+func boolNumber(value bool) int64 {
+	if value {
+		return 1
+	}
+	return 0
+}
@@ -1,11 +1,256 @@
 package stdjava
 
-// Option formally represents a value that can be nil
+// Optional is an implementation of Java's `Optional`, which either contains a
+// value, or is empty
+//
+// Go's methods can't have type parameters of their own, so `map` and `flatMap`
+// are the `MapOptional` and `FlatMapOptional` functions instead
 type Optional[T any] struct {
+	// value points to the contained value, and is nil if the optional is empty
 	value *T
 }
 
-// Some returns true if a value is present
-func (o Optional[T]) Some() bool {
+// OptionalOf creates an optional that contains a value, which is what
+// `Optional.of` does
+//
+// Panics with a `NullPointerException` if the value is null
+func OptionalOf[T any](value T) Optional[T] {
+	if isNull(value) {
+		panic(New[NullPointerException]("", nil))
+	}
+	return Optional[T]{value: &value}
+}
+
+// OptionalOfNullable creates an optional that contains a value, or an empty
+// optional if the value is null, which is what `Optional.ofNullable` does
+func OptionalOfNullable[T any](value T) Optional[T] {
+	if isNull(value) {
+		return Optional[T]{}
+	}
+	return Optional[T]{value: &value}
+}
+
+// OptionalEmpty creates an empty optional, which is what `Optional.empty` does
+func OptionalEmpty[T any]() Optional[T] {
+	return Optional[T]{}
+}
+
+// IsPresent returns true if the optional contains a value
+func (o Optional[T]) IsPresent() bool {
 	return o.value != nil
 }
+
+// IsEmpty returns true if the optional doesn't contain a value
+func (o Optional[T]) IsEmpty() bool {
+	return o.value == nil
+}
+
+// Get returns the optional's value
+//
+// Panics with a `NoSuchElementException` if the optional is empty
+func (o Optional[T]) Get() T {
+	if o.value == nil {
+		panic(New[NoSuchElementException]("No value present", nil))
+	}
+	return *o.value
+}
+
+// OrElseThrow returns the optional's value, which is the same as `Get`
+func (o Optional[T]) OrElseThrow() T {
+	return o.Get()
+}
+
+// OrElseThrowWith returns the optional's value, or panics with the exception
+// that the supplier creates if the optional is empty, which is what Java's
+// `orElseThrow` does when it is given a supplier
+func (o Optional[T]) OrElseThrowWith(exception func() any) T {
+	if o.value == nil {
+		panic(exception())
+	}
+	return *o.value
+}
+
+// OrElse returns the optional's value, or another value if the optional is
+// empty
+func (o Optional[T]) OrElse(other T) T {
+	if o.value == nil {
+		return other
+	}
+	return *o.value
+}
+
+// OrElseGet returns the optional's value, or the value that a supplier returns
+// if the optional is empty, where the supplier is only called if it is needed
+func (o Optional[T]) OrElseGet(supplier func() T) T {
+	if o.value == nil {
+		return supplier()
+	}
+	return *o.value
+}
+
+// IfPresent runs an action with the optional's value, if it has one
+func (o Optional[T]) IfPresent(action func(T)) {
+	if o.value != nil {
+		action(*o.value)
+	}
+}
+
+// IfPresentOrElse runs an action with the optional's value, or another action
+// if the optional is empty
+func (o Optional[T]) IfPresentOrElse(action func(T), emptyAction func()) {
+	if o.value != nil {
+		action(*o.value)
+	} else {
+		emptyAction()
+	}
+}
+
+// Filter keeps the optional's value if it matches a predicate, and is empty
+// otherwise
+func (o Optional[T]) Filter(predicate func(T) bool) Optional[T] {
+	if o.value == nil || !predicate(*o.value) {
+		return Optional[T]{}
+	}
+	return o
+}
+
+// Equals tests if another value is an optional with an equal value, or if both
+// optionals are empty
+func (o Optional[T]) Equals(other any) bool {
+	optional, ok := other.(Optional[T])
+	if !ok || o.IsPresent() != optional.IsPresent() {
+		return false
+	}
+	return o.value == nil || Equals(*o.value, *optional.value)
+}
+
+// HashCode returns the hash code of the optional's value, or zero if it is
+// empty
+func (o Optional[T]) HashCode() int32 {
+	if o.value == nil {
+		return 0
+	}
+	return HashOf(*o.value)
+}
+
+// String formats the optional the way that Java's `toString` does, such as
+// `Optional[value]` or `Optional.empty`
+func (o Optional[T]) String() string {
+	return o.describe("Optional")
+}
+
+func (o Optional[T]) describe(class string) string {
+	if o.value == nil {
+		return class + ".empty"
+	}
+	return class + "[" + ValueOf(*o.value) + "]"
+}
+
+// MapOptional is an implementation of the optional's `map` method, which
+// transforms its value, where a null result is an empty optional
+func MapOptional[T, R any](o Optional[T], mapper func(T) R) Optional[R] {
+	if o.value == nil {
+		return Optional[R]{}
+	}
+	return OptionalOfNullable(mapper(*o.value))
+}
+
+// FlatMapOptional is an implementation of the optional's `flatMap` method,
+// which replaces its value with the optional that the value is mapped to
+func FlatMapOptional[T, R any](o Optional[T], mapper func(T) Optional[R]) Optional[R] {
+	if o.value == nil {
+		return Optional[R]{}
+	}
+	return mapper(*o.value)
+}
+
+// The primitive optionals are optionals of their primitive types, which only
+// differ from them in the names of their `get` methods, and the way that they
+// are formatted
+
+// OptionalInt is an implementation of Java's `OptionalInt`
+type OptionalInt struct {
+	Optional[int32]
+}
+
+// OptionalIntOf creates an optional that contains an int
+func OptionalIntOf(value int32) OptionalInt {
+	return OptionalInt{Optional[int32]{value: &value}}
+}
+
+// OptionalIntEmpty creates an empty optional int
+func OptionalIntEmpty() OptionalInt {
+	return OptionalInt{}
+}
+
+// GetAsInt returns the optional's value, which is the same as `Get`
+func (o OptionalInt) GetAsInt() int32 {
+	return o.Get()
+}
+
+func (o OptionalInt) Equals(other any) bool {
+	optional, ok := other.(OptionalInt)
+	return ok && o.Optional.Equals(optional.Optional)
+}
+
+func (o OptionalInt) String() string {
+	return o.describe("OptionalInt")
+}
+
+// OptionalLong is an implementation of Java's `OptionalLong`
+type OptionalLong struct {
+	Optional[int64]
+}
+
+// OptionalLongOf creates an optional that contains a long
+func OptionalLongOf(value int64) OptionalLong {
+	return OptionalLong{Optional[int64]{value: &value}}
+}
+
+// OptionalLongEmpty creates an empty optional long
+func OptionalLongEmpty() OptionalLong {
+	return OptionalLong{}
+}
+
+// GetAsLong returns the optional's value, which is the same as `Get`
+func (o OptionalLong) GetAsLong() int64 {
+	return o.Get()
+}
+
+func (o OptionalLong) Equals(other any) bool {
+	optional, ok := other.(OptionalLong)
+	return ok && o.Optional.Equals(optional.Optional)
+}
+
+func (o OptionalLong) String() string {
+	return o.describe("OptionalLong")
+}
+
+// OptionalDouble is an implementation of Java's `OptionalDouble`
+type OptionalDouble struct {
+	Optional[float64]
+}
+
+// OptionalDoubleOf creates an optional that contains a double
+func OptionalDoubleOf(value float64) OptionalDouble {
+	return OptionalDouble{Optional[float64]{value: &value}}
+}
+
+// OptionalDoubleEmpty creates an empty optional double
+func OptionalDoubleEmpty() OptionalDouble {
+	return OptionalDouble{}
+}
+
+// GetAsDouble returns the optional's value, which is the same as `Get`
+func (o OptionalDouble) GetAsDouble() float64 {
+	return o.Get()
+}
+
+func (o OptionalDouble) Equals(other any) bool {
+	optional, ok := other.(OptionalDouble)
+	return ok && o.Optional.Equals(optional.Optional)
+}
+
+func (o OptionalDouble) String() string {
+	return o.describe("OptionalDouble")
+}
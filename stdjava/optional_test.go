@@ -0,0 +1,60 @@
+package stdjava
+
+import (
+	"testing"
+)
+
+func TestOptional(t *testing.T) {
+	present, empty := OptionalOf("value"), OptionalOfNullable[*StringBuilder](nil)
+	if !present.IsPresent() || present.IsEmpty() || present.Get() != "value" {
+		t.Errorf("Expected the optional to contain a value, got %s", present)
+	}
+	if empty.IsPresent() || empty.OrElse(nil) != nil || empty.String() != "Optional.empty" {
+		t.Errorf("Expected a null value to be empty, got %s", empty)
+	}
+
+	length := MapOptional(present.Filter(func(s string) bool { return Length(s) > 3 }), Length)
+	if length.String() != "Optional[5]" || !length.Equals(OptionalOf[int32](5)) || length.Equals(OptionalOf[int64](5)) {
+		t.Errorf("Expected the length to be Optional[5], got %s", length)
+	}
+	if filtered := present.Filter(func(s string) bool { return s == "" }); filtered.OrElseGet(func() string { return "other" }) != "other" {
+		t.Errorf("Expected the filtered optional to be empty, got %s", filtered)
+	}
+
+	var supplied bool
+	present.OrElseGet(func() string { supplied = true; return "" })
+	if supplied {
+		t.Errorf("Expected the supplier to only be called for an empty optional")
+	}
+}
+
+func TestEmptyOptional(t *testing.T) {
+	defer func() {
+		if !InstanceOf[NoSuchElementException](recover()) {
+			t.Errorf("Expected a NoSuchElementException")
+		}
+	}()
+	OptionalEmpty[string]().Get()
+}
+
+func TestOptionalOfNull(t *testing.T) {
+	defer func() {
+		if !InstanceOf[NullPointerException](recover()) {
+			t.Errorf("Expected a NullPointerException")
+		}
+	}()
+	OptionalOf[*StringBuilder](nil)
+}
+
+func TestPrimitiveOptionals(t *testing.T) {
+	value := OptionalIntOf(0)
+	if !value.IsPresent() || value.GetAsInt() != 0 || value.String() != "OptionalInt[0]" {
+		t.Errorf("Expected the optional to contain 0, got %s", value)
+	}
+	if average := OptionalDoubleOf(2.5); average.String() != "OptionalDouble[2.5]" || average.Equals(OptionalOf(2.5)) {
+		t.Errorf("Expected OptionalDouble[2.5], got %s", average)
+	}
+	if empty := OptionalLongEmpty(); empty.OrElse(7) != 7 || empty.String() != "OptionalLong.empty" {
+		t.Errorf("Expected an empty optional, got %s", empty)
+	}
+}
@@ -15,3 +15,27 @@ func TestRightShiftNegative(t *testing.T) {
 		t.Errorf("Shifted -9 >>> 2. Expected 1073741821 but got %d", UnsignedRightShift(-9, 2))
 	}
 }
+
+func TestRightShiftLong(t *testing.T) {
+	if shifted := UnsignedRightShift(int64(-9), 2); shifted != 4611686018427387901 {
+		t.Errorf("Shifted -9L >>> 2. Expected 4611686018427387901 but got %d", shifted)
+	}
+}
+
+func TestRightShiftMasksAmount(t *testing.T) {
+	if shifted := UnsignedRightShift(int32(-1), 60); shifted != 15 {
+		t.Errorf("Shifted -1 >>> 60. Expected 15 but got %d", shifted)
+	}
+	if shifted := UnsignedRightShift(int64(-1), -1); shifted != 1 {
+		t.Errorf("Shifted -1L >>> -1. Expected 1 but got %d", shifted)
+	}
+}
+
+func TestRightShiftAssignment(t *testing.T) {
+	value := int8(-16)
+	UnsignedRightShiftAssignment(&value, 2)
+	// The byte is promoted to an int before it is shifted, and then narrowed
+	if value != -4 {
+		t.Errorf("Shifted (byte) -16 >>>= 2. Expected -4 but got %d", value)
+	}
+}
@@ -0,0 +1,105 @@
+package stream
+
+import (
+	"strings"
+
+	"github.com/NickyBoy89/java2go/stdjava"
+)
+
+// Collector is an implementation of Java's `Collector`, which collects the
+// elements of a stream into a container, and then turns the container into
+// its result
+type Collector[T, A, R any] struct {
+	// Supplier creates a new container
+	Supplier func() A
+	// Accumulator adds an element to a container
+	Accumulator func(A, T)
+	// Finisher turns a container into the collector's result
+	Finisher func(A) R
+}
+
+// ToList collects the elements into a list, which is what
+// `Collectors.toList` does
+func ToList[T any]() Collector[T, *stdjava.ArrayList[T], stdjava.List[T]] {
+	return Collector[T, *stdjava.ArrayList[T], stdjava.List[T]]{
+		Supplier:    stdjava.NewArrayList[T],
+		Accumulator: func(list *stdjava.ArrayList[T], value T) { list.Add(value) },
+		Finisher:    func(list *stdjava.ArrayList[T]) stdjava.List[T] { return list },
+	}
+}
+
+// ToSet collects the elements into a set, which is what `Collectors.toSet`
+// does
+func ToSet[T any]() Collector[T, *stdjava.HashSet[T], stdjava.Set[T]] {
+	return Collector[T, *stdjava.HashSet[T], stdjava.Set[T]]{
+		Supplier:    stdjava.NewHashSet[T],
+		Accumulator: func(set *stdjava.HashSet[T], value T) { set.Add(value) },
+		Finisher:    func(set *stdjava.HashSet[T]) stdjava.Set[T] { return set },
+	}
+}
+
+// ToMap collects the elements into a map, with the keys and values that each
+// element is mapped to, which is what `Collectors.toMap` does
+//
+// Panics with an `IllegalStateException` if two elements have the same key
+func ToMap[T, K, V any](keyMapper func(T) K, valueMapper func(T) V) Collector[T, *stdjava.HashMap[K, V], stdjava.Map[K, V]] {
+	return Collector[T, *stdjava.HashMap[K, V], stdjava.Map[K, V]]{
+		Supplier: stdjava.NewHashMap[K, V],
+		Accumulator: func(m *stdjava.HashMap[K, V], value T) {
+			key := keyMapper(value)
+			if m.ContainsKey(key) {
+				panic(stdjava.New[stdjava.IllegalStateException]("Duplicate key "+stdjava.ValueOf(key)+" (attempted merging values "+stdjava.ValueOf(m.Get(key))+" and "+stdjava.ValueOf(valueMapper(value))+")", nil))
+			}
+			m.Put(key, valueMapper(value))
+		},
+		Finisher: func(m *stdjava.HashMap[K, V]) stdjava.Map[K, V] { return m },
+	}
+}
+
+// GroupingBy collects the elements into lists of the elements with the same
+// key, which is what `Collectors.groupingBy` does
+func GroupingBy[T, K any](classifier func(T) K) Collector[T, *stdjava.HashMap[K, *stdjava.ArrayList[T]], stdjava.Map[K, stdjava.List[T]]] {
+	return GroupingByWith(classifier, ToList[T]())
+}
+
+// GroupingByWith collects the elements with the same key with another
+// collector, which is what `Collectors.groupingBy` does with a downstream
+// collector
+func GroupingByWith[T, K, A, D any](classifier func(T) K, downstream Collector[T, A, D]) Collector[T, *stdjava.HashMap[K, A], stdjava.Map[K, D]] {
+	return Collector[T, *stdjava.HashMap[K, A], stdjava.Map[K, D]]{
+		Supplier: stdjava.NewHashMap[K, A],
+		Accumulator: func(groups *stdjava.HashMap[K, A], value T) {
+			container := groups.ComputeIfAbsent(classifier(value), func(K) A { return downstream.Supplier() })
+			downstream.Accumulator(container, value)
+		},
+		Finisher: func(groups *stdjava.HashMap[K, A]) stdjava.Map[K, D] {
+			result := stdjava.NewHashMap[K, D]()
+			for it := groups.EntrySet().Iterator(); it.HasNext(); {
+				entry := it.Next()
+				result.Put(entry.GetKey(), downstream.Finisher(entry.GetValue()))
+			}
+			return result
+		},
+	}
+}
+
+// Joining concatenates the elements, which is what `Collectors.joining` does,
+// where Java's version without a delimiter, prefix or suffix uses empty strings
+func Joining(delimiter, prefix, suffix string) Collector[string, *[]string, string] {
+	return Collector[string, *[]string, string]{
+		Supplier:    func() *[]string { return &[]string{} },
+		Accumulator: func(values *[]string, value string) { *values = append(*values, value) },
+		Finisher: func(values *[]string) string {
+			return prefix + strings.Join(*values, delimiter) + suffix
+		},
+	}
+}
+
+// Counting counts the elements, which is what `Collectors.counting` does
+func Counting[T any]() Collector[T, *int64, int64] {
+	return Collector[T, *int64, int64]{
+		Supplier:    func() *int64 { return new(int64) },
+		Accumulator: func(count *int64, _ T) { *count++ },
+		Finisher:    func(count *int64) int64 { return *count },
+	}
+}
@@ -0,0 +1,175 @@
+package stream
+
+import "github.com/NickyBoy89/java2go/stdjava"
+
+// IntStream is an implementation of Java's `IntStream`, which is a stream of
+// ints that has methods for arithmetic, such as `Sum`
+type IntStream struct {
+	s *Stream[int32]
+}
+
+// Ints creates a stream of the given ints, which is what `IntStream.of` does
+func Ints(values ...int32) IntStream {
+	return IntStream{OfSlice(values)}
+}
+
+// IntsOfSlice creates a stream of the elements of an array, which is what
+// `Arrays.stream` does with an `int[]`
+func IntsOfSlice(values []int32) IntStream {
+	return IntStream{OfSlice(values)}
+}
+
+// Range creates a stream of the ints from the start, up to, but not including,
+// the end
+func Range(start, end int32) IntStream {
+	return IntStream{newStream(func() (int32, bool) {
+		if start >= end {
+			return 0, false
+		}
+		start++
+		return start - 1, true
+	})}
+}
+
+// RangeClosed creates a stream of the ints from the start to the end,
+// including the end
+func RangeClosed(start, end int32) IntStream {
+	// The end can't be incremented, since it may be the largest int
+	done := start > end
+	return IntStream{newStream(func() (int32, bool) {
+		if done {
+			return 0, false
+		}
+		done = start == end
+		start++
+		return start - 1, true
+	})}
+}
+
+// MapToInt transforms each element of a stream into an int
+func MapToInt[T any](s *Stream[T], mapper func(T) int32) IntStream {
+	return IntStream{Map(s, mapper)}
+}
+
+// MapToObj transforms each int into an object
+func MapToObj[R any](s IntStream, mapper func(int32) R) *Stream[R] {
+	return Map(s.s, mapper)
+}
+
+// Boxed returns a stream of the same ints, which aren't boxed in Go
+func (s IntStream) Boxed() *Stream[int32] {
+	return s.s
+}
+
+func (s IntStream) Filter(predicate func(int32) bool) IntStream {
+	return IntStream{s.s.Filter(predicate)}
+}
+
+func (s IntStream) Map(mapper func(int32) int32) IntStream {
+	return IntStream{Map(s.s, mapper)}
+}
+
+func (s IntStream) Peek(action func(int32)) IntStream {
+	return IntStream{s.s.Peek(action)}
+}
+
+func (s IntStream) Distinct() IntStream {
+	return IntStream{s.s.Distinct()}
+}
+
+func (s IntStream) Sorted() IntStream {
+	return IntStream{s.s.Sorted(nil)}
+}
+
+func (s IntStream) Limit(size int64) IntStream {
+	return IntStream{s.s.Limit(size)}
+}
+
+func (s IntStream) Skip(count int64) IntStream {
+	return IntStream{s.s.Skip(count)}
+}
+
+func (s IntStream) ForEach(action func(int32)) {
+	s.s.ForEach(action)
+}
+
+func (s IntStream) Count() int64 {
+	return s.s.Count()
+}
+
+// Sum adds the ints together, which overflows the same way as Java's ints
+func (s IntStream) Sum() int32 {
+	return s.s.Reduce(0, func(a, b int32) int32 { return a + b })
+}
+
+func (s IntStream) AnyMatch(predicate func(int32) bool) bool {
+	return s.s.AnyMatch(predicate)
+}
+
+func (s IntStream) AllMatch(predicate func(int32) bool) bool {
+	return s.s.AllMatch(predicate)
+}
+
+func (s IntStream) NoneMatch(predicate func(int32) bool) bool {
+	return s.s.NoneMatch(predicate)
+}
+
+func (s IntStream) Reduce(identity int32, operator func(a, b int32) int32) int32 {
+	return s.s.Reduce(identity, operator)
+}
+
+func (s IntStream) ReduceOptional(operator func(a, b int32) int32) stdjava.OptionalInt {
+	return optionalInt(s.s.ReduceOptional(operator))
+}
+
+func (s IntStream) FindFirst() stdjava.OptionalInt {
+	return optionalInt(s.s.FindFirst())
+}
+
+func (s IntStream) FindAny() stdjava.OptionalInt {
+	return optionalInt(s.s.FindAny())
+}
+
+func (s IntStream) Min() stdjava.OptionalInt {
+	return s.ReduceOptional(func(a, b int32) int32 {
+		if b < a {
+			return b
+		}
+		return a
+	})
+}
+
+func (s IntStream) Max() stdjava.OptionalInt {
+	return s.ReduceOptional(func(a, b int32) int32 {
+		if b > a {
+			return b
+		}
+		return a
+	})
+}
+
+// Average returns the average of the ints, which are added together as longs,
+// so the sum doesn't overflow, or an empty optional if there aren't any ints
+func (s IntStream) Average() stdjava.OptionalDouble {
+	var sum, count int64
+	s.s.ForEach(func(value int32) {
+		sum += int64(value)
+		count++
+	})
+	if count == 0 {
+		return stdjava.OptionalDoubleEmpty()
+	}
+	return stdjava.OptionalDoubleOf(float64(sum) / float64(count))
+}
+
+// optionalInt converts an optional of an int into an `OptionalInt`
+func optionalInt(optional stdjava.Optional[int32]) stdjava.OptionalInt {
+	if optional.IsEmpty() {
+		return stdjava.OptionalIntEmpty()
+	}
+	return stdjava.OptionalIntOf(optional.Get())
+}
+
+func (s IntStream) ToArray() []int32 {
+	return s.s.ToArray()
+}
@@ -0,0 +1,361 @@
+// Package stream is an implementation of Java's streams from
+// `java.util.stream`, which are lazy, so each element goes through the whole
+// pipeline before the next one is taken from the source
+//
+// Go's methods can't have type parameters of their own, so the operations that
+// change the type of the elements, such as `map`, are functions instead
+package stream
+
+import (
+	"sort"
+
+	"github.com/NickyBoy89/java2go/stdjava"
+)
+
+// Stream is an implementation of Java's `Stream`, which can only be used once
+type Stream[T any] struct {
+	// next returns the next element, and false once there are no more elements
+	next func() (T, bool)
+	used bool
+}
+
+func newStream[T any](next func() (T, bool)) *Stream[T] {
+	return &Stream[T]{next: next}
+}
+
+// Of creates a stream of the given values, which is what `Stream.of` does
+func Of[T any](values ...T) *Stream[T] {
+	return OfSlice(values)
+}
+
+// OfSlice creates a stream of the elements of an array, which is what
+// `Arrays.stream` does
+func OfSlice[T any](values []T) *Stream[T] {
+	var ind int
+	return newStream(func() (T, bool) {
+		if ind >= len(values) {
+			var zero T
+			return zero, false
+		}
+		ind++
+		return values[ind-1], true
+	})
+}
+
+// OfIterable creates a stream of the elements of a collection, which is what
+// the `stream` method of Java's collections does
+func OfIterable[T any](values stdjava.Iterable[T]) *Stream[T] {
+	var it stdjava.Iterator[T]
+	return newStream(func() (T, bool) {
+		// The iterator is created lazily, when the stream is used
+		if it == nil {
+			it = values.Iterator()
+		}
+		if !it.HasNext() {
+			var zero T
+			return zero, false
+		}
+		return it.Next(), true
+	})
+}
+
+// Empty creates a stream without any elements
+func Empty[T any]() *Stream[T] {
+	return newStream(func() (T, bool) {
+		var zero T
+		return zero, false
+	})
+}
+
+// pull marks the stream as used, and returns the function that returns its
+// elements
+//
+// Panics with an `IllegalStateException` if the stream has already been used,
+// like Java does
+func (s *Stream[T]) pull() func() (T, bool) {
+	if s.used {
+		panic(stdjava.New[stdjava.IllegalStateException]("stream has already been operated upon or closed", nil))
+	}
+	s.used = true
+	return s.next
+}
+
+// Filter keeps the elements that match a predicate
+func (s *Stream[T]) Filter(predicate func(T) bool) *Stream[T] {
+	next := s.pull()
+	return newStream(func() (T, bool) {
+		for {
+			value, ok := next()
+			if !ok || predicate(value) {
+				return value, ok
+			}
+		}
+	})
+}
+
+// Map is an implementation of the stream's `map` method, which transforms each
+// element
+func Map[T, R any](s *Stream[T], mapper func(T) R) *Stream[R] {
+	next := s.pull()
+	return newStream(func() (R, bool) {
+		value, ok := next()
+		if !ok {
+			var zero R
+			return zero, false
+		}
+		return mapper(value), true
+	})
+}
+
+// FlatMap replaces each element with the elements of the stream that it is
+// mapped to
+func FlatMap[T, R any](s *Stream[T], mapper func(T) *Stream[R]) *Stream[R] {
+	next := s.pull()
+	var current func() (R, bool)
+	return newStream(func() (R, bool) {
+		for {
+			if current != nil {
+				if value, ok := current(); ok {
+					return value, true
+				}
+			}
+			value, ok := next()
+			if !ok {
+				var zero R
+				return zero, false
+			}
+			current = mapper(value).pull()
+		}
+	})
+}
+
+// Peek runs an action on each element as it goes through the stream
+func (s *Stream[T]) Peek(action func(T)) *Stream[T] {
+	next := s.pull()
+	return newStream(func() (T, bool) {
+		value, ok := next()
+		if ok {
+			action(value)
+		}
+		return value, ok
+	})
+}
+
+// Distinct removes any elements that are equal to an earlier element, which
+// are compared with their `Equals` and `HashCode` methods, like Java does
+func (s *Stream[T]) Distinct() *Stream[T] {
+	next := s.pull()
+	seen := stdjava.NewHashSet[T]()
+	return newStream(func() (T, bool) {
+		for {
+			value, ok := next()
+			if !ok || seen.Add(value) {
+				return value, ok
+			}
+		}
+	})
+}
+
+// Sorted sorts the elements with a comparator, or by their natural order if
+// the comparator is nil
+//
+// The sort is stable, and only happens once the first element is needed
+func (s *Stream[T]) Sorted(comparator func(a, b T) int32) *Stream[T] {
+	if comparator == nil {
+		comparator = func(a, b T) int32 {
+			return stdjava.Compare(a, b)
+		}
+	}
+	next := s.pull()
+	var sorted []T
+	var ind int
+	return newStream(func() (T, bool) {
+		if sorted == nil {
+			sorted = collect(next)
+			sort.SliceStable(sorted, func(i, j int) bool {
+				return comparator(sorted[i], sorted[j]) < 0
+			})
+		}
+		if ind >= len(sorted) {
+			var zero T
+			return zero, false
+		}
+		ind++
+		return sorted[ind-1], true
+	})
+}
+
+// Limit keeps the first elements of the stream
+//
+// Panics with an `IllegalArgumentException` if the limit is negative
+func (s *Stream[T]) Limit(size int64) *Stream[T] {
+	checkSize(size)
+	next := s.pull()
+	var taken int64
+	return newStream(func() (T, bool) {
+		if taken >= size {
+			var zero T
+			return zero, false
+		}
+		taken++
+		return next()
+	})
+}
+
+// Skip leaves out the first elements of the stream
+//
+// Panics with an `IllegalArgumentException` if the number is negative
+func (s *Stream[T]) Skip(count int64) *Stream[T] {
+	checkSize(count)
+	next := s.pull()
+	return newStream(func() (T, bool) {
+		for ; count > 0; count-- {
+			if _, ok := next(); !ok {
+				break
+			}
+		}
+		return next()
+	})
+}
+
+func checkSize(size int64) {
+	if size < 0 {
+		panic(stdjava.New[stdjava.IllegalArgumentException](stdjava.ValueOf(size), nil))
+	}
+}
+
+// collect takes every remaining element
+func collect[T any](next func() (T, bool)) []T {
+	values := []T{}
+	for value, ok := next(); ok; value, ok = next() {
+		values = append(values, value)
+	}
+	return values
+}
+
+// ForEach runs an action on each element
+func (s *Stream[T]) ForEach(action func(T)) {
+	next := s.pull()
+	for value, ok := next(); ok; value, ok = next() {
+		action(value)
+	}
+}
+
+// Count returns the number of elements
+func (s *Stream[T]) Count() int64 {
+	next := s.pull()
+	var count int64
+	for _, ok := next(); ok; _, ok = next() {
+		count++
+	}
+	return count
+}
+
+// AnyMatch tests if any of the elements match a predicate, and stops at the
+// first one that does
+func (s *Stream[T]) AnyMatch(predicate func(T) bool) bool {
+	next := s.pull()
+	for value, ok := next(); ok; value, ok = next() {
+		if predicate(value) {
+			return true
+		}
+	}
+	return false
+}
+
+// AllMatch tests if all of the elements match a predicate, which is true if
+// there aren't any elements
+func (s *Stream[T]) AllMatch(predicate func(T) bool) bool {
+	return !s.AnyMatch(func(value T) bool { return !predicate(value) })
+}
+
+// NoneMatch tests if none of the elements match a predicate
+func (s *Stream[T]) NoneMatch(predicate func(T) bool) bool {
+	return !s.AnyMatch(predicate)
+}
+
+// Reduce combines the elements, starting with an identity value
+func (s *Stream[T]) Reduce(identity T, accumulator func(a, b T) T) T {
+	next := s.pull()
+	result := identity
+	for value, ok := next(); ok; value, ok = next() {
+		result = accumulator(result, value)
+	}
+	return result
+}
+
+// ReduceOptional combines the elements, starting with the first one, which is
+// what Java's `reduce` does without an identity value, and is empty if there
+// aren't any elements
+//
+// Panics with a `NullPointerException` if the result is null
+func (s *Stream[T]) ReduceOptional(accumulator func(a, b T) T) stdjava.Optional[T] {
+	next := s.pull()
+	result, ok := next()
+	if !ok {
+		return stdjava.OptionalEmpty[T]()
+	}
+	for value, ok := next(); ok; value, ok = next() {
+		result = accumulator(result, value)
+	}
+	return stdjava.OptionalOf(result)
+}
+
+// FindFirst returns the first element, or an empty optional if there aren't
+// any elements
+//
+// Panics with a `NullPointerException` if the first element is null
+func (s *Stream[T]) FindFirst() stdjava.Optional[T] {
+	if value, ok := s.pull()(); ok {
+		return stdjava.OptionalOf(value)
+	}
+	return stdjava.OptionalEmpty[T]()
+}
+
+// FindAny returns any of the elements, which is always the first one, since
+// streams aren't parallel
+func (s *Stream[T]) FindAny() stdjava.Optional[T] {
+	return s.FindFirst()
+}
+
+// Min returns the smallest element according to a comparator, where the first
+// of several equal elements is returned
+func (s *Stream[T]) Min(comparator func(a, b T) int32) stdjava.Optional[T] {
+	return s.ReduceOptional(func(a, b T) T {
+		if comparator(a, b) <= 0 {
+			return a
+		}
+		return b
+	})
+}
+
+// Max returns the largest element according to a comparator, where the first
+// of several equal elements is returned
+func (s *Stream[T]) Max(comparator func(a, b T) int32) stdjava.Optional[T] {
+	return s.ReduceOptional(func(a, b T) T {
+		if comparator(a, b) >= 0 {
+			return a
+		}
+		return b
+	})
+}
+
+// ToArray returns the elements in an array
+func (s *Stream[T]) ToArray() []T {
+	return collect(s.pull())
+}
+
+// ToList returns the elements in a list, which is what `Stream.toList` does
+func (s *Stream[T]) ToList() stdjava.List[T] {
+	list := stdjava.NewArrayList[T]()
+	s.ForEach(func(value T) { list.Add(value) })
+	return list
+}
+
+// Collect collects the elements with a collector, such as one of the
+// `Collectors`
+func Collect[T, A, R any](s *Stream[T], collector Collector[T, A, R]) R {
+	container := collector.Supplier()
+	s.ForEach(func(value T) { collector.Accumulator(container, value) })
+	return collector.Finisher(container)
+}
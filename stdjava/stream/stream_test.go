@@ -0,0 +1,100 @@
+package stream
+
+import (
+	"testing"
+
+	"github.com/NickyBoy89/java2go/stdjava"
+)
+
+func TestLaziness(t *testing.T) {
+	var seen []string
+	result := Map(Of("a", "bb", "ccc", "dddd").Peek(func(s string) { seen = append(seen, s) }).Filter(func(s string) bool {
+		return len(s) > 1
+	}), func(s string) int32 { return int32(len(s)) }).Limit(2).ToArray()
+
+	if len(result) != 2 || result[0] != 2 || result[1] != 3 {
+		t.Errorf("Expected [2 3], got %v", result)
+	}
+	// Only the elements up to the limit are taken from the source
+	if len(seen) != 3 {
+		t.Errorf("Expected 3 elements to be looked at, got %v", seen)
+	}
+}
+
+func TestReuse(t *testing.T) {
+	defer func() {
+		if !stdjava.InstanceOf[stdjava.IllegalStateException](recover()) {
+			t.Errorf("Expected an IllegalStateException")
+		}
+	}()
+	s := Of(1, 2, 3)
+	s.Count()
+	s.Count()
+}
+
+func TestCollectors(t *testing.T) {
+	words := stdjava.NewArrayList[string]()
+	for _, word := range []string{"apple", "avocado", "banana", "cherry", "blueberry", "apple"} {
+		words.Add(word)
+	}
+
+	groups := Collect(OfIterable[string](words), GroupingBy(func(word string) string { return word[:1] }))
+	if groups.String() != "{a=[apple, avocado, apple], b=[banana, blueberry], c=[cherry]}" {
+		t.Errorf("Got %s", groups)
+	}
+	counts := Collect(OfIterable[string](words), GroupingByWith(func(word string) int32 { return int32(len(word)) }, Counting[string]()))
+	if counts.String() != "{5=2, 6=2, 7=1, 9=1}" {
+		t.Errorf("Got %s", counts)
+	}
+	if joined := Collect(OfIterable[string](words).Distinct().Sorted(nil), Joining(", ", "[", "]")); joined != "[apple, avocado, banana, blueberry, cherry]" {
+		t.Errorf("Got %s", joined)
+	}
+	if set := Collect(OfIterable[string](words), ToSet[string]()); set.Size() != 5 {
+		t.Errorf("Expected 5 distinct words, got %s", set)
+	}
+}
+
+func TestToMapDuplicates(t *testing.T) {
+	defer func() {
+		if !stdjava.InstanceOf[stdjava.IllegalStateException](recover()) {
+			t.Errorf("Expected an IllegalStateException")
+		}
+	}()
+	Collect(Of("a", "b", "a"), ToMap(func(s string) string { return s }, func(s string) int32 { return 1 }))
+}
+
+func TestIntStream(t *testing.T) {
+	if sum := Range(1, 5).Sum(); sum != 10 {
+		t.Errorf("Expected 10, got %d", sum)
+	}
+	if count := RangeClosed(2147483646, 2147483647).Count(); count != 2 {
+		t.Errorf("Expected a closed range to include the largest int, got %d elements", count)
+	}
+	squares := MapToObj(Range(0, 4).Filter(func(i int32) bool { return i%2 == 1 }), func(i int32) string {
+		return stdjava.ValueOf(i * i)
+	}).ToList()
+	if squares.String() != "[1, 9]" {
+		t.Errorf("Expected [1, 9], got %s", squares)
+	}
+}
+
+func TestOptionalResults(t *testing.T) {
+	shortest := Of("pear", "fig", "kiwi").Min(func(a, b string) int32 { return stdjava.Length(a) - stdjava.Length(b) })
+	if shortest.String() != "Optional[fig]" {
+		t.Errorf("Expected Optional[fig], got %s", shortest)
+	}
+	// The first of several equal elements is the largest
+	longest := Of("pear", "fig", "kiwi").Max(func(a, b string) int32 { return stdjava.Length(a) - stdjava.Length(b) })
+	if longest.String() != "Optional[pear]" {
+		t.Errorf("Expected Optional[pear], got %s", longest)
+	}
+	if first := Empty[string]().FindFirst(); first.IsPresent() {
+		t.Errorf("Expected an empty stream to have no first element, got %s", first)
+	}
+	if average := Ints(1, 2).Average(); average.String() != "OptionalDouble[1.5]" {
+		t.Errorf("Expected OptionalDouble[1.5], got %s", average)
+	}
+	if max := Range(0, 0).Max(); max.IsPresent() {
+		t.Errorf("Expected an empty range to have no maximum, got %s", max)
+	}
+}
@@ -0,0 +1,267 @@
+package stdjava
+
+import (
+	"strconv"
+	"strings"
+	"unicode/utf16"
+)
+
+// StringBuilder is an implementation of Java's `StringBuilder` and
+// `StringBuffer` classes, which build up a string by appending to it
+//
+// Java overloads `append` for each of its types, which become separate methods,
+// such as `AppendInt` and `AppendChar`, and each of the methods that modify the
+// builder return it, so that calls can be chained
+//
+// The zero value is an empty builder that is ready to use, which is not safe to
+// use from multiple goroutines, unlike Java's `StringBuffer`
+type StringBuilder struct {
+	builder strings.Builder
+	// A high surrogate that was appended on its own, which is kept until the low
+	// surrogate that completes its character is appended
+	surrogate rune
+}
+
+// NewStringBuilder creates a builder that starts with the given string
+func NewStringBuilder(s string) *StringBuilder {
+	sb := &StringBuilder{}
+	sb.builder.WriteString(s)
+	return sb
+}
+
+// NewStringBuilderCapacity creates an empty builder with room for the given
+// number of characters
+//
+// Panics with a `NegativeArraySizeException` if the capacity is negative
+func NewStringBuilderCapacity(capacity int32) *StringBuilder {
+	if capacity < 0 {
+		panic(New[NegativeArraySizeException](ValueOf(capacity), nil))
+	}
+	sb := &StringBuilder{}
+	sb.builder.Grow(int(capacity))
+	return sb
+}
+
+// flush writes a high surrogate that was never completed, which is not valid
+// on its own, so it becomes the replacement character
+func (sb *StringBuilder) flush() {
+	if sb.surrogate != 0 {
+		sb.builder.WriteRune(sb.surrogate)
+		sb.surrogate = 0
+	}
+}
+
+// set replaces the contents of the builder
+func (sb *StringBuilder) set(s string) {
+	sb.builder.Reset()
+	sb.builder.WriteString(s)
+}
+
+// Append appends a string
+func (sb *StringBuilder) Append(s string) *StringBuilder {
+	sb.flush()
+	sb.builder.WriteString(s)
+	return sb
+}
+
+// AppendBool appends "true" or "false"
+func (sb *StringBuilder) AppendBool(b bool) *StringBuilder {
+	return sb.Append(strconv.FormatBool(b))
+}
+
+// AppendChar appends a single character
+//
+// Characters outside of the Basic Multilingual Plane can be appended as the
+// two halves of their surrogate pair, such as the ones returned by `CharAt`
+func (sb *StringBuilder) AppendChar(c rune) *StringBuilder {
+	switch {
+	case utf16.IsSurrogate(c) && c < 0xdc00:
+		sb.flush()
+		sb.surrogate = c
+	case utf16.IsSurrogate(c) && sb.surrogate != 0:
+		sb.builder.WriteRune(utf16.DecodeRune(sb.surrogate, c))
+		sb.surrogate = 0
+	default:
+		sb.flush()
+		sb.builder.WriteRune(c)
+	}
+	return sb
+}
+
+// AppendInt appends an `int`, which also appends Java's `byte` and `short`
+func (sb *StringBuilder) AppendInt(i int32) *StringBuilder {
+	return sb.Append(strconv.FormatInt(int64(i), 10))
+}
+
+// AppendLong appends a `long`
+func (sb *StringBuilder) AppendLong(l int64) *StringBuilder {
+	return sb.Append(strconv.FormatInt(l, 10))
+}
+
+// AppendFloat appends a `float` the way that Java formats it
+func (sb *StringBuilder) AppendFloat(f float32) *StringBuilder {
+	return sb.Append(FormatFloat(f))
+}
+
+// AppendDouble appends a `double` the way that Java formats it
+func (sb *StringBuilder) AppendDouble(d float64) *StringBuilder {
+	return sb.Append(FormatDouble(d))
+}
+
+// AppendObject appends any other value, which is converted with `ValueOf`
+func (sb *StringBuilder) AppendObject(value any) *StringBuilder {
+	return sb.Append(ValueOf(value))
+}
+
+// Insert inserts a string before the character at the given offset
+//
+// Panics with a `StringIndexOutOfBoundsException` if the offset is outside of
+// the builder
+func (sb *StringBuilder) Insert(at int32, s string) *StringBuilder {
+	sb.flush()
+	current := sb.builder.String()
+	position, ok := offset(current, at)
+	if !ok {
+		panic(New[StringIndexOutOfBoundsException]("offset "+ValueOf(at)+", length "+ValueOf(Length(current)), nil))
+	}
+	sb.set(current[:position] + s + current[position:])
+	return sb
+}
+
+// Reverse reverses the order of the characters, where surrogate pairs are kept
+// in their original order, like Java does
+func (sb *StringBuilder) Reverse() *StringBuilder {
+	sb.flush()
+	chars := []rune(sb.builder.String())
+	for start, end := 0, len(chars)-1; start < end; start, end = start+1, end-1 {
+		chars[start], chars[end] = chars[end], chars[start]
+	}
+	sb.set(string(chars))
+	return sb
+}
+
+// SetLength either truncates the builder to the given number of characters, or
+// pads it with null characters
+//
+// Panics with a `StringIndexOutOfBoundsException` if the length is negative
+func (sb *StringBuilder) SetLength(length int32) {
+	sb.flush()
+	current := sb.builder.String()
+	if length < 0 {
+		panic(New[StringIndexOutOfBoundsException]("String index out of range: "+ValueOf(length), nil))
+	}
+	if currentLength := Length(current); length > currentLength {
+		sb.builder.WriteString(strings.Repeat("\x00", int(length-currentLength)))
+		return
+	}
+	sb.set(Substring(current, 0, length))
+}
+
+// Length returns the number of UTF-16 code units in the builder, like Java's
+// `length`
+func (sb *StringBuilder) Length() int32 {
+	sb.flush()
+	return Length(sb.builder.String())
+}
+
+// IsEmpty tests if the builder does not contain any characters
+func (sb *StringBuilder) IsEmpty() bool {
+	return sb.builder.Len() == 0 && sb.surrogate == 0
+}
+
+// CharAt returns the UTF-16 code unit at the given index, like `CharAt` does
+// for strings
+func (sb *StringBuilder) CharAt(index int32) rune {
+	sb.flush()
+	return CharAt[rune](sb.builder.String(), index)
+}
+
+// SetCharAt replaces the character at the given index
+//
+// Panics with a `StringIndexOutOfBoundsException` if the index is outside of
+// the builder
+func (sb *StringBuilder) SetCharAt(index int32, c rune) {
+	sb.flush()
+	current := sb.builder.String()
+	if index < 0 || index >= Length(current) {
+		panic(New[StringIndexOutOfBoundsException]("index "+ValueOf(index)+",length "+ValueOf(Length(current)), nil))
+	}
+	sb.set(Substring(current, 0, index) + string(c) + SubstringFrom(current, index+1))
+}
+
+// DeleteCharAt removes the character at the given index
+//
+// Panics with a `StringIndexOutOfBoundsException` if the index is outside of
+// the builder
+func (sb *StringBuilder) DeleteCharAt(index int32) *StringBuilder {
+	sb.flush()
+	current := sb.builder.String()
+	if index < 0 || index >= Length(current) {
+		panic(New[StringIndexOutOfBoundsException]("index "+ValueOf(index)+",length "+ValueOf(Length(current)), nil))
+	}
+	sb.set(Substring(current, 0, index) + SubstringFrom(current, index+1))
+	return sb
+}
+
+// Delete removes the characters between the start index and the end index,
+// where an end past the end of the builder removes the rest of the characters
+//
+// Panics with a `StringIndexOutOfBoundsException` if the start is outside of
+// the builder, or after the end
+func (sb *StringBuilder) Delete(start, end int32) *StringBuilder {
+	return sb.Replace(start, end, "")
+}
+
+// Replace replaces the characters between the start index and the end index
+// with a string, where an end past the end of the builder replaces the rest of
+// the characters
+//
+// Panics with a `StringIndexOutOfBoundsException` if the start is outside of
+// the builder, or after the end
+func (sb *StringBuilder) Replace(start, end int32, s string) *StringBuilder {
+	sb.flush()
+	current := sb.builder.String()
+	length := Length(current)
+	if end > length {
+		end = length
+	}
+	if start < 0 || start > end {
+		panic(New[StringIndexOutOfBoundsException]("start "+ValueOf(start)+", end "+ValueOf(end)+", length "+ValueOf(length), nil))
+	}
+	sb.set(Substring(current, 0, start) + s + SubstringFrom(current, end))
+	return sb
+}
+
+// IndexOf returns the index of the first occurrence of a string, starting at an
+// index, like `IndexOf` does for strings
+func (sb *StringBuilder) IndexOf(s string, from int32) int32 {
+	sb.flush()
+	return IndexOf(sb.builder.String(), s, from)
+}
+
+// LastIndexOf returns the index of the last occurrence of a string, starting at
+// an index, like `LastIndexOf` does for strings
+func (sb *StringBuilder) LastIndexOf(s string, from int32) int32 {
+	sb.flush()
+	return LastIndexOf(sb.builder.String(), s, from)
+}
+
+// Substring returns the characters between the begin index and the end index,
+// like `Substring` does for strings
+func (sb *StringBuilder) Substring(begin, end int32) string {
+	sb.flush()
+	return Substring(sb.builder.String(), begin, end)
+}
+
+// SubstringFrom returns the characters after the begin index, like
+// `SubstringFrom` does for strings
+func (sb *StringBuilder) SubstringFrom(begin int32) string {
+	sb.flush()
+	return SubstringFrom(sb.builder.String(), begin)
+}
+
+// String returns the contents of the builder, which is Java's `toString`
+func (sb *StringBuilder) String() string {
+	sb.flush()
+	return sb.builder.String()
+}
@@ -0,0 +1,58 @@
+package stdjava
+
+import "testing"
+
+func TestStringBuilderAppend(t *testing.T) {
+	sb := &StringBuilder{}
+	sb.Append("a").AppendInt(-1).AppendChar('c').AppendBool(true).AppendLong(1 << 40).AppendDouble(1).AppendFloat(0.1).AppendObject(nil)
+	if sb.String() != "a-1ctrue10995116277761.00.1null" {
+		t.Errorf("Expected every type to be appended like Java, got %s", sb.String())
+	}
+}
+
+func TestStringBuilderSurrogatePairs(t *testing.T) {
+	sb := &StringBuilder{}
+	s := "a😀b"
+	for ind := int32(0); ind < Length(s); ind++ {
+		sb.AppendChar(CharAt[rune](s, ind))
+	}
+	if sb.String() != s {
+		t.Errorf("Expected the chars to be appended back into %s, got %s", s, sb.String())
+	}
+	if sb.Reverse().String() != "b😀a" {
+		t.Errorf("Expected the surrogate pair to stay together when reversed, got %s", sb.String())
+	}
+}
+
+func TestStringBuilderEditing(t *testing.T) {
+	sb := NewStringBuilder("hello")
+	sb.Insert(0, ">").Insert(6, "!").DeleteCharAt(1)
+	if sb.String() != ">ello!" {
+		t.Errorf("Expected >ello!, got %s", sb.String())
+	}
+	sb.SetCharAt(0, 'j')
+	if sb.String() != "jello!" || sb.CharAt(1) != 'e' {
+		t.Errorf("Expected jello!, got %s", sb.String())
+	}
+	sb.Replace(1, 3, "EL").Delete(4, 100)
+	if sb.String() != "jELl" {
+		t.Errorf("Expected jELl, got %s", sb.String())
+	}
+	sb.SetLength(2)
+	if sb.String() != "jE" {
+		t.Errorf("Expected the builder to be truncated to jE, got %s", sb.String())
+	}
+	sb.SetLength(3)
+	if sb.Length() != 3 || sb.CharAt(2) != 0 {
+		t.Errorf("Expected the builder to be padded with a null char, got %q", sb.String())
+	}
+}
+
+func TestStringBuilderOutOfBounds(t *testing.T) {
+	defer func() {
+		if _, ok := recover().(StringIndexOutOfBoundsException); !ok {
+			t.Errorf("Expected a StringIndexOutOfBoundsException")
+		}
+	}()
+	NewStringBuilder("abc").DeleteCharAt(3)
+}
@@ -0,0 +1,450 @@
+package stdjava
+
+import (
+	"regexp"
+	"strings"
+	"sync"
+	"unicode"
+	"unicode/utf16"
+	"unicode/utf8"
+	"unsafe"
+)
+
+// Char is any of the types that Java's `char` type can be represented as
+type Char interface {
+	~rune | ~uint16
+}
+
+// Length is an implementation of Java's String `length` method, which counts
+// the number of UTF-16 code units in a string, so characters outside of the
+// Basic Multilingual Plane count as two characters
+func Length(s string) int32 {
+	encoded := encodingOf(s)
+	if encoded.ascii {
+		return int32(len(s))
+	}
+	return int32(len(encoded.units))
+}
+
+// CharAt is an implementation of Java's String `charAt` method, which returns
+// the UTF-16 code unit at the given index, so characters outside of the Basic
+// Multilingual Plane are returned as two halves of a surrogate pair
+//
+// Panics with a `StringIndexOutOfBoundsException` if the index is out of range
+func CharAt[C Char](s string, index int32) C {
+	encoded := encodingOf(s)
+	switch {
+	case index < 0:
+	case encoded.ascii && int(index) < len(s):
+		return C(s[index])
+	case !encoded.ascii && int(index) < len(encoded.units):
+		return C(encoded.units[index])
+	}
+	panic(New[StringIndexOutOfBoundsException]("index "+ValueOf(index)+", length "+ValueOf(Length(s)), nil))
+}
+
+// isASCII tests if a string only contains ASCII characters, where each byte is
+// a single character
+func isASCII(s string) bool {
+	for ind := 0; ind < len(s); ind++ {
+		if s[ind] >= utf8.RuneSelf {
+			return false
+		}
+	}
+	return true
+}
+
+// An encoding is a string's UTF-16 code units, which is how Java indexes into
+// strings, along with the offset of each code unit in the string's bytes
+type encoding struct {
+	s string
+	// ascii is true if every byte of the string is a code unit, in which case
+	// the units and their offsets are left out
+	ascii   bool
+	units   []uint16
+	offsets []int
+}
+
+// encodings caches the encodings of the strings that were indexed most
+// recently, since methods such as `charAt` are usually called in a loop over
+// the same string, and encoding it on every call would make the loop quadratic
+var encodings struct {
+	sync.Mutex
+	recent [4]*encoding
+	next   int
+}
+
+// encodingOf returns the encoding of a string, which is cached by the address
+// of the string's bytes, since strings can't be changed
+func encodingOf(s string) *encoding {
+	encodings.Lock()
+	defer encodings.Unlock()
+
+	for _, cached := range encodings.recent {
+		if cached != nil && len(cached.s) == len(s) && unsafe.StringData(cached.s) == unsafe.StringData(s) {
+			return cached
+		}
+	}
+
+	encoded := &encoding{s: s, ascii: isASCII(s)}
+	if !encoded.ascii {
+		for ind := 0; ind < len(s); {
+			char, size := decodeChar(s[ind:])
+			if high, low := utf16.EncodeRune(char); high != unicode.ReplacementChar {
+				encoded.units = append(encoded.units, uint16(high), uint16(low))
+				// The second half of a pair is at the character after the pair
+				encoded.offsets = append(encoded.offsets, ind, ind+size)
+			} else {
+				encoded.units = append(encoded.units, uint16(char))
+				encoded.offsets = append(encoded.offsets, ind)
+			}
+			ind += size
+		}
+		encoded.offsets = append(encoded.offsets, len(s))
+	}
+
+	encodings.recent[encodings.next] = encoded
+	encodings.next = (encodings.next + 1) % len(encodings.recent)
+	return encoded
+}
+
+// offset converts an index into a string's UTF-16 code units, which is how
+// Java indexes strings, into an index into the string's bytes
+//
+// Returns false if the index is outside of the string, where the index after
+// the last character is inside of the string
+func offset(s string, index int32) (int, bool) {
+	encoded := encodingOf(s)
+	switch {
+	case index < 0:
+		return 0, false
+	case encoded.ascii && int(index) <= len(s):
+		return int(index), true
+	case !encoded.ascii && int(index) < len(encoded.offsets):
+		return encoded.offsets[index], true
+	}
+	return len(s), false
+}
+
+// CharString converts a char into a string, which is how chars are concatenated
+// to strings
+//
+// Go's strings can't hold half of a surrogate pair, which `string` replaces
+// with the replacement character, so the halves are encoded the same way as
+// any other character below U+10000, and are joined back into a single
+// character by `Concat`
+func CharString[C Char](c C) string {
+	char := rune(c)
+	if !utf16.IsSurrogate(char) {
+		return string(char)
+	}
+	return string([]byte{0xE0 | byte(char>>12), 0x80 | byte(char>>6)&0x3F, 0x80 | byte(char)&0x3F})
+}
+
+// Concat concatenates two strings, where half of a surrogate pair at the end
+// of the first string is joined with the other half at the start of the
+// second, such as when concatenating the chars of a string one at a time
+func Concat(a, b string) string {
+	high, highOk := surrogateAt(a, len(a)-3)
+	low, lowOk := surrogateAt(b, 0)
+	if highOk && lowOk {
+		if joined := utf16.DecodeRune(high, low); joined != unicode.ReplacementChar {
+			return a[:len(a)-3] + string(joined) + b[3:]
+		}
+	}
+	return a + b
+}
+
+// surrogateAt decodes half of a surrogate pair that `CharString` encoded at the
+// given index of a string
+func surrogateAt(s string, ind int) (rune, bool) {
+	if ind < 0 || ind+3 > len(s) || s[ind] != 0xED || s[ind+1] < 0xA0 || s[ind+1] > 0xBF || s[ind+2]&0xC0 != 0x80 {
+		return 0, false
+	}
+	return rune(s[ind]&0x0F)<<12 | rune(s[ind+1]&0x3F)<<6 | rune(s[ind+2]&0x3F), true
+}
+
+// decodeChar decodes the first character of a string, which may be half of a
+// surrogate pair that is encoded on its own
+func decodeChar(s string) (rune, int) {
+	if surrogate, ok := surrogateAt(s, 0); ok {
+		return surrogate, 3
+	}
+	return utf8.DecodeRuneInString(s)
+}
+
+// index converts an index into a string's bytes into an index into its UTF-16
+// code units, and keeps -1, which means that something was not found
+func index(s string, offset int) int32 {
+	if offset < 0 {
+		return -1
+	}
+	return Length(s[:offset])
+}
+
+// Substring is an implementation of Java's String `substring` method, which
+// returns the characters between the begin index and the end index
+//
+// Panics with a `StringIndexOutOfBoundsException` if either of the indexes are
+// out of range
+func Substring(s string, begin, end int32) string {
+	start, startOk := offset(s, begin)
+	stop, stopOk := offset(s, end)
+	if !startOk || !stopOk || start > stop {
+		panic(New[StringIndexOutOfBoundsException]("begin "+ValueOf(begin)+", end "+ValueOf(end)+", length "+ValueOf(Length(s)), nil))
+	}
+	return s[start:stop]
+}
+
+// SubstringFrom is an implementation of Java's String `substring` method with
+// a single index, which returns the characters after the index
+func SubstringFrom(s string, begin int32) string {
+	return Substring(s, begin, Length(s))
+}
+
+// IndexOf is an implementation of Java's String `indexOf` method, which returns
+// the index of the first occurrence of a substring, starting at an index
+func IndexOf(s, substring string, from int32) int32 {
+	start, ok := offset(s, from)
+	if from < 0 {
+		start, ok = 0, true
+	}
+	if !ok {
+		return -1
+	}
+	found := strings.Index(s[start:], substring)
+	if found < 0 {
+		return -1
+	}
+	return index(s, start+found)
+}
+
+// LastIndexOf is an implementation of Java's String `lastIndexOf` method, which
+// returns the index of the last occurrence of a substring that starts at or
+// before an index
+func LastIndexOf(s, substring string, from int32) int32 {
+	if from < 0 {
+		return -1
+	}
+	end, ok := offset(s, from)
+	if !ok {
+		end = len(s)
+	}
+	end += len(substring)
+	if end > len(s) {
+		end = len(s)
+	}
+	return index(s, strings.LastIndex(s[:end], substring))
+}
+
+// CompareTo is an implementation of Java's String `compareTo` method, which
+// compares the UTF-16 code units of two strings, and returns the difference
+// between the first code units that are different, or between the lengths of
+// the strings
+func CompareTo(a, b string) int32 {
+	if isASCII(a) && isASCII(b) {
+		for ind := 0; ind < len(a) && ind < len(b); ind++ {
+			if a[ind] != b[ind] {
+				return int32(a[ind]) - int32(b[ind])
+			}
+		}
+		return int32(len(a)) - int32(len(b))
+	}
+
+	first, second := utf16.Encode([]rune(a)), utf16.Encode([]rune(b))
+	for ind := 0; ind < len(first) && ind < len(second); ind++ {
+		if first[ind] != second[ind] {
+			return int32(first[ind]) - int32(second[ind])
+		}
+	}
+	return int32(len(first)) - int32(len(second))
+}
+
+// CompareToIgnoreCase is an implementation of Java's String
+// `compareToIgnoreCase` method, which compares two strings after changing the
+// case of each of their characters
+func CompareToIgnoreCase(a, b string) int32 {
+	return CompareTo(strings.ToLower(strings.ToUpper(a)), strings.ToLower(strings.ToUpper(b)))
+}
+
+// Trim is an implementation of Java's String `trim` method, which removes all
+// of the spaces and control characters from both ends of a string
+func Trim(s string) string {
+	return strings.TrimFunc(s, func(char rune) bool {
+		return char <= ' '
+	})
+}
+
+// Join is an implementation of `String.join` for one of Java's collections,
+// which joins each of the collection's elements with a delimiter
+//
+// Elements that aren't strings, such as string builders, and `null` elements,
+// are converted the same way as `String.valueOf`
+func Join[E any](delimiter string, elements Iterable[E]) string {
+	var joined strings.Builder
+	for it, first := elements.Iterator(), true; it.HasNext(); first = false {
+		if !first {
+			joined.WriteString(delimiter)
+		}
+		joined.WriteString(ValueOf(it.Next()))
+	}
+	return joined.String()
+}
+
+// FromChars converts an array of chars into a string, which is what
+// `String.valueOf` does with a `char[]`
+//
+// Surrogate pairs are joined back together, even if the chars are runes
+func FromChars[C Char](chars []C) string {
+	var builder strings.Builder
+	for ind := 0; ind < len(chars); ind++ {
+		char := rune(chars[ind])
+		if utf16.IsSurrogate(char) && ind+1 < len(chars) {
+			if joined := utf16.DecodeRune(char, rune(chars[ind+1])); joined != unicode.ReplacementChar {
+				builder.WriteRune(joined)
+				ind++
+				continue
+			}
+		}
+		builder.WriteString(CharString(chars[ind]))
+	}
+	return builder.String()
+}
+
+// ToCharArray is an implementation of Java's String `toCharArray` method,
+// which returns the string's UTF-16 code units, like `CharAt`
+func ToCharArray[C Char](s string) []C {
+	chars := make([]C, Length(s))
+	for ind := range chars {
+		chars[ind] = CharAt[C](s, int32(ind))
+	}
+	return chars
+}
+
+// patterns caches the regular expressions that have been compiled, since
+// methods such as `split` are often called in loops with the same expression
+var patterns sync.Map
+
+// compile compiles one of Java's regular expressions, which panics with an
+// `IllegalArgumentException` if it is invalid, or uses a feature that Go's
+// regular expressions do not support, such as backreferences
+func compile(expression string) *regexp.Regexp {
+	if pattern, ok := patterns.Load(expression); ok {
+		return pattern.(*regexp.Regexp)
+	}
+	pattern, err := regexp.Compile(expression)
+	if err != nil {
+		panic(New[IllegalArgumentException](err.Error(), nil))
+	}
+	patterns.Store(expression, pattern)
+	return pattern
+}
+
+// literalSeparator returns the string that a regular expression matches, if
+// it is a single character that is not special, or one that is escaped,
+// which is the same shortcut that Java takes
+func literalSeparator(expression string) (string, bool) {
+	switch {
+	case len(expression) == 1 && !strings.Contains(".$|()[{^?*+\\", expression):
+		return expression, true
+	case len(expression) == 2 && expression[0] == '\\' && expression[1] < utf8.RuneSelf &&
+		!('0' <= expression[1] && expression[1] <= '9' || 'a' <= expression[1] && expression[1] <= 'z' || 'A' <= expression[1] && expression[1] <= 'Z'):
+		return expression[1:], true
+	}
+	return "", false
+}
+
+// Split is an implementation of Java's String `split` method, which splits a
+// string around the matches of a regular expression
+//
+// If the limit is positive, then there are at most that many parts, and the
+// last part contains the rest of the string. If the limit is zero, then any
+// empty strings at the end are removed
+func Split(s, expression string, limit int32) []string {
+	n := int(limit)
+	if limit <= 0 {
+		n = -1
+	}
+
+	var parts []string
+	if separator, ok := literalSeparator(expression); ok {
+		parts = strings.SplitN(s, separator, n)
+	} else {
+		parts = compile(expression).Split(s, n)
+		// A match of no characters at the start of the string does not create
+		// an empty string at the start, unlike a match of any characters
+		if len(parts) > 1 && parts[0] == "" {
+			if match := compile(expression).FindStringIndex(s); match != nil && match[1] == 0 {
+				parts = parts[1:]
+			}
+		}
+	}
+
+	// The string is returned by itself if there weren't any matches
+	if len(parts) <= 1 {
+		return []string{s}
+	}
+	if limit == 0 {
+		for len(parts) > 0 && parts[len(parts)-1] == "" {
+			parts = parts[:len(parts)-1]
+		}
+	}
+	return parts
+}
+
+// replacement converts the replacement of Java's `replaceAll` method, which
+// refers to groups as `$1`, and escapes characters with backslashes, into a
+// replacement for Go's regular expressions
+func replacement(javaReplacement string) string {
+	var converted strings.Builder
+	for ind := 0; ind < len(javaReplacement); ind++ {
+		switch char := javaReplacement[ind]; {
+		case char == '\\' && ind+1 < len(javaReplacement):
+			ind++
+			if javaReplacement[ind] == '$' {
+				converted.WriteString("$$")
+			} else {
+				converted.WriteByte(javaReplacement[ind])
+			}
+		case char == '$':
+			// Go reads as many letters as it can as the name of the group, so the
+			// number of the group is surrounded with brackets
+			end := ind + 1
+			for end < len(javaReplacement) && '0' <= javaReplacement[end] && javaReplacement[end] <= '9' {
+				end++
+			}
+			if end == ind+1 {
+				converted.WriteByte('$')
+				continue
+			}
+			converted.WriteString("${" + javaReplacement[ind+1:end] + "}")
+			ind = end - 1
+		default:
+			converted.WriteByte(char)
+		}
+	}
+	return converted.String()
+}
+
+// ReplaceAll is an implementation of Java's String `replaceAll` method, which
+// replaces every match of a regular expression
+func ReplaceAll(s, expression, javaReplacement string) string {
+	return compile(expression).ReplaceAllString(s, replacement(javaReplacement))
+}
+
+// ReplaceFirst is an implementation of Java's String `replaceFirst` method,
+// which replaces the first match of a regular expression
+func ReplaceFirst(s, expression, javaReplacement string) string {
+	pattern := compile(expression)
+	match := pattern.FindStringSubmatchIndex(s)
+	if match == nil {
+		return s
+	}
+	return s[:match[0]] + string(pattern.ExpandString(nil, replacement(javaReplacement), s, match)) + s[match[1]:]
+}
+
+// Matches is an implementation of Java's String `matches` method, which tests
+// if an entire string matches a regular expression
+func Matches(s, expression string) bool {
+	return compile(`^(?:` + expression + `)$`).MatchString(s)
+}
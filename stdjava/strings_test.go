@@ -0,0 +1,187 @@
+package stdjava
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestLengthCountsSurrogatePairs(t *testing.T) {
+	if Length("hello") != 5 {
+		t.Errorf("Expected the length of hello to be 5, got %d", Length("hello"))
+	}
+	if Length("a😀b") != 4 {
+		t.Errorf("Expected an emoji to count as two chars, got a length of %d", Length("a😀b"))
+	}
+}
+
+func TestCharAtSurrogatePairs(t *testing.T) {
+	s := "a😀b"
+	if CharAt[uint16](s, 0) != 'a' {
+		t.Errorf("Expected the first char to be a, got %c", CharAt[rune](s, 0))
+	}
+	if CharAt[uint16](s, 1) != 0xD83D || CharAt[uint16](s, 2) != 0xDE00 {
+		t.Errorf("Expected the emoji to be split into a surrogate pair, got %x and %x", CharAt[uint16](s, 1), CharAt[uint16](s, 2))
+	}
+	if CharAt[rune](s, 3) != 'b' {
+		t.Errorf("Expected the last char to be b, got %c", CharAt[rune](s, 3))
+	}
+}
+
+func TestConcatSurrogateHalves(t *testing.T) {
+	s := "a😀"
+	for _, chars := range []func(int32) string{
+		func(ind int32) string { return CharString(CharAt[rune](s, ind)) },
+		func(ind int32) string { return CharString(CharAt[uint16](s, ind)) },
+	} {
+		high := Concat("", chars(1))
+		if Length(high) != 1 || CharAt[uint16](high, 0) != 0xD83D {
+			t.Errorf("Expected half of a surrogate pair to be a single char, got %q", high)
+		}
+		if joined := Concat(high, chars(2)); joined != "😀" {
+			t.Errorf("Expected the halves of the pair to be joined, got %q", joined)
+		}
+	}
+	if Concat("a", "b") != "ab" {
+		t.Errorf("Expected strings without surrogates to be concatenated, got %q", Concat("a", "b"))
+	}
+}
+
+func TestCharAtOutOfBounds(t *testing.T) {
+	defer func() {
+		if _, ok := recover().(StringIndexOutOfBoundsException); !ok {
+			t.Errorf("Expected a StringIndexOutOfBoundsException")
+		}
+	}()
+	CharAt[rune]("abc", 3)
+}
+
+func TestSubstring(t *testing.T) {
+	if Substring("hello", 1, 3) != "el" || SubstringFrom("hello", 2) != "llo" || Substring("hello", 5, 5) != "" {
+		t.Errorf("Got %q, %q and %q", Substring("hello", 1, 3), SubstringFrom("hello", 2), Substring("hello", 5, 5))
+	}
+	if Substring("a😀b", 1, 3) != "😀" || SubstringFrom("a😀b", 3) != "b" {
+		t.Errorf("Expected the indexes to count UTF-16 chars, got %q and %q", Substring("a😀b", 1, 3), SubstringFrom("a😀b", 3))
+	}
+}
+
+func TestSubstringOutOfBounds(t *testing.T) {
+	defer func() {
+		if _, ok := recover().(StringIndexOutOfBoundsException); !ok {
+			t.Errorf("Expected a StringIndexOutOfBoundsException")
+		}
+	}()
+	Substring("abc", 2, 1)
+}
+
+func TestIndexOf(t *testing.T) {
+	cases := []struct {
+		found, expected int32
+	}{
+		{IndexOf("banana", "an", 0), 1},
+		{IndexOf("banana", "an", 2), 3},
+		{IndexOf("banana", "x", 0), -1},
+		{IndexOf("banana", "a", -5), 1},
+		{IndexOf("banana", "a", 10), -1},
+		{IndexOf("😀an", "an", 0), 2},
+		{LastIndexOf("banana", "an", 6), 3},
+		{LastIndexOf("banana", "an", 2), 1},
+		{LastIndexOf("banana", "an", -1), -1},
+		{LastIndexOf("😀an😀an", "an", 10), 6},
+	}
+	for ind, c := range cases {
+		if c.found != c.expected {
+			t.Errorf("Case %d: expected %d, got %d", ind, c.expected, c.found)
+		}
+	}
+}
+
+func TestCompareTo(t *testing.T) {
+	if CompareTo("apple", "banana") != -1 || CompareTo("b", "a") != 1 || CompareTo("ab", "abcd") != -2 || CompareTo("same", "same") != 0 {
+		t.Errorf("Got %d, %d, %d and %d", CompareTo("apple", "banana"), CompareTo("b", "a"), CompareTo("ab", "abcd"), CompareTo("same", "same"))
+	}
+	if CompareToIgnoreCase("Hello", "hello") != 0 {
+		t.Errorf("Expected strings with different cases to be equal")
+	}
+}
+
+func TestTrim(t *testing.T) {
+	if Trim(" \t\x01hello world\n ") != "hello world" {
+		t.Errorf("Got %q", Trim(" \t\x01hello world\n "))
+	}
+	if Trim(" x") != " x" {
+		t.Errorf("Expected a non-breaking space to not be trimmed")
+	}
+}
+
+func TestJoin(t *testing.T) {
+	words := NewArrayList[string]()
+	if Join[string](", ", words) != "" {
+		t.Errorf("Expected an empty list to be joined into an empty string")
+	}
+	words.Add("")
+	words.Add("a")
+	words.Add("b")
+	if Join[string](",", words) != ",a,b" {
+		t.Errorf("Got %q", Join[string](",", words))
+	}
+	builders := NewArrayList[*StringBuilder]()
+	builders.Add(nil)
+	if Join[*StringBuilder]("-", builders) != "null" {
+		t.Errorf("Got %q", Join[*StringBuilder]("-", builders))
+	}
+}
+
+func TestSplit(t *testing.T) {
+	cases := []struct {
+		s, expression string
+		limit         int32
+		expected      []string
+	}{
+		{"a,b,,c,,", ",", 0, []string{"a", "b", "", "c"}},
+		{"a,b,,c,,", ",", -1, []string{"a", "b", "", "c", "", ""}},
+		{"a,b,c", ",", 2, []string{"a", "b,c"}},
+		{"", ",", 0, []string{""}},
+		{",", ",", 0, []string{}},
+		{" a b", " ", 0, []string{"", "a", "b"}},
+		{"a1b22c", "\\d+", 0, []string{"a", "b", "c"}},
+		{"a.b", "\\.", 0, []string{"a", "b"}},
+		{"abc", "", 0, []string{"a", "b", "c"}},
+		{"one  two", "\\s+", 0, []string{"one", "two"}},
+	}
+	for _, c := range cases {
+		if parts := Split(c.s, c.expression, c.limit); !reflect.DeepEqual(parts, c.expected) {
+			t.Errorf("Splitting %q by %q with limit %d: expected %q, got %q", c.s, c.expression, c.limit, c.expected, parts)
+		}
+	}
+}
+
+func TestReplace(t *testing.T) {
+	if ReplaceAll("a1b22", "(\\d+)", "<$1>") != "a<1>b<22>" {
+		t.Errorf("Got %q", ReplaceAll("a1b22", "(\\d+)", "<$1>"))
+	}
+	if ReplaceAll("a1", "(\\d)", "$1x\\$") != "a1x$" {
+		t.Errorf("Got %q", ReplaceAll("a1", "(\\d)", "$1x\\$"))
+	}
+	if ReplaceFirst("a1b2", "\\d", "#") != "a#b2" {
+		t.Errorf("Got %q", ReplaceFirst("a1b2", "\\d", "#"))
+	}
+	if !Matches("abc123", "[a-z]+\\d+") || Matches("abc123x", "[a-z]+\\d+") || !Matches("b", "a|b") {
+		t.Errorf("Expected matches to match the entire string")
+	}
+}
+
+func TestFromChars(t *testing.T) {
+	if FromChars([]rune{'h', 'i'}) != "hi" || FromChars([]uint16{0xD83D, 0xDE00}) != "😀" {
+		t.Errorf("Got %q and %q", FromChars([]rune{'h', 'i'}), FromChars([]uint16{0xD83D, 0xDE00}))
+	}
+}
+
+func TestToCharArray(t *testing.T) {
+	chars := ToCharArray[rune]("a😀")
+	if len(chars) != 3 || chars[0] != 'a' || chars[1] != 0xD83D || chars[2] != 0xDE00 {
+		t.Errorf("Expected the UTF-16 code units of the string, got %x", chars)
+	}
+	if FromChars(chars) != "a😀" || FromChars(ToCharArray[uint16]("a😀")) != "a😀" {
+		t.Errorf("Expected the chars to be converted back into the same string, got %q", FromChars(chars))
+	}
+}
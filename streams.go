@@ -0,0 +1,483 @@
+package main
+
+import (
+	"go/ast"
+	"go/token"
+	"regexp"
+	"strings"
+
+	"github.com/NickyBoy89/java2go/nodeutil"
+	"github.com/NickyBoy89/java2go/symbol"
+	sitter "github.com/smacker/go-tree-sitter"
+)
+
+// Java's streams from `java.util.stream` are translated into the `stream`
+// package in `stdjava`, where the operations that change the type of the
+// elements, such as `map`, are functions instead of methods, since Go's
+// methods can't have type parameters of their own
+
+// streamOperation is one of the operations of Java's streams
+type streamOperation struct {
+	// The name of the operation in the `stream` package
+	name string
+	// Whether the operation is a function that takes the stream as its first
+	// argument, instead of a method
+	function bool
+	// The Java types of the operation's parameters, in terms of the type of the
+	// stream's elements, `T`, and the type that they are mapped to, `R`
+	parameters []string
+	// The Java type that the operation returns
+	result string
+	// Whether the operation's arguments are left out, since Java only uses them
+	// for their type, such as the generator that `toArray` takes
+	typeOnly bool
+}
+
+// The operations of each of the streams, which are looked up by their Java
+// names, where the elements of an `IntStream` are `int`s
+var streamOperations = map[string]map[string][]streamOperation{
+	"Stream": {
+		"filter":    {{name: "Filter", parameters: []string{"Predicate<T>"}, result: "Stream<T>"}},
+		"map":       {{name: "Map", function: true, parameters: []string{"Function<T, R>"}, result: "Stream<R>"}},
+		"flatMap":   {{name: "FlatMap", function: true, parameters: []string{"Function<T, Stream<R>>"}, result: "Stream<R>"}},
+		"mapToInt":  {{name: "MapToInt", function: true, parameters: []string{"ToIntFunction<T>"}, result: "IntStream"}},
+		"peek":      {{name: "Peek", parameters: []string{"Consumer<T>"}, result: "Stream<T>"}},
+		"distinct":  {{name: "Distinct", result: "Stream<T>"}},
+		"limit":     {{name: "Limit", parameters: []string{"long"}, result: "Stream<T>"}},
+		"skip":      {{name: "Skip", parameters: []string{"long"}, result: "Stream<T>"}},
+		"forEach":   {{name: "ForEach", parameters: []string{"Consumer<T>"}, result: "void"}},
+		"count":     {{name: "Count", result: "long"}},
+		"anyMatch":  {{name: "AnyMatch", parameters: []string{"Predicate<T>"}, result: "boolean"}},
+		"allMatch":  {{name: "AllMatch", parameters: []string{"Predicate<T>"}, result: "boolean"}},
+		"noneMatch": {{name: "NoneMatch", parameters: []string{"Predicate<T>"}, result: "boolean"}},
+		"findFirst": {{name: "FindFirst", result: "Optional<T>"}},
+		"findAny":   {{name: "FindAny", result: "Optional<T>"}},
+		"min":       {{name: "Min", parameters: []string{"Comparator<T>"}, result: "Optional<T>"}},
+		"max":       {{name: "Max", parameters: []string{"Comparator<T>"}, result: "Optional<T>"}},
+		"toList":    {{name: "ToList", result: "List<T>"}},
+		"collect":   {{name: "Collect", function: true, parameters: []string{"Collector<T, ?, R>"}, result: "R"}},
+		"reduce": {
+			{name: "Reduce", parameters: []string{"T", "BinaryOperator<T>"}, result: "T"},
+			{name: "ReduceOptional", parameters: []string{"BinaryOperator<T>"}, result: "Optional<T>"},
+		},
+		"sorted": {
+			{name: "Sorted", result: "Stream<T>"},
+			{name: "Sorted", parameters: []string{"Comparator<T>"}, result: "Stream<T>"},
+		},
+		"toArray": {
+			{name: "ToArray", result: "T[]"},
+			{name: "ToArray", parameters: []string{"IntFunction<T[]>"}, result: "T[]", typeOnly: true},
+		},
+	},
+	"IntStream": {
+		"filter":    {{name: "Filter", parameters: []string{"IntPredicate"}, result: "IntStream"}},
+		"map":       {{name: "Map", parameters: []string{"IntUnaryOperator"}, result: "IntStream"}},
+		"mapToObj":  {{name: "MapToObj", function: true, parameters: []string{"IntFunction<R>"}, result: "Stream<R>"}},
+		"boxed":     {{name: "Boxed", result: "Stream<Integer>"}},
+		"peek":      {{name: "Peek", parameters: []string{"IntConsumer"}, result: "IntStream"}},
+		"distinct":  {{name: "Distinct", result: "IntStream"}},
+		"sorted":    {{name: "Sorted", result: "IntStream"}},
+		"limit":     {{name: "Limit", parameters: []string{"long"}, result: "IntStream"}},
+		"skip":      {{name: "Skip", parameters: []string{"long"}, result: "IntStream"}},
+		"forEach":   {{name: "ForEach", parameters: []string{"IntConsumer"}, result: "void"}},
+		"count":     {{name: "Count", result: "long"}},
+		"sum":       {{name: "Sum", result: "int"}},
+		"anyMatch":  {{name: "AnyMatch", parameters: []string{"IntPredicate"}, result: "boolean"}},
+		"allMatch":  {{name: "AllMatch", parameters: []string{"IntPredicate"}, result: "boolean"}},
+		"noneMatch": {{name: "NoneMatch", parameters: []string{"IntPredicate"}, result: "boolean"}},
+		"findFirst": {{name: "FindFirst", result: "OptionalInt"}},
+		"findAny":   {{name: "FindAny", result: "OptionalInt"}},
+		"min":       {{name: "Min", result: "OptionalInt"}},
+		"max":       {{name: "Max", result: "OptionalInt"}},
+		"average":   {{name: "Average", result: "OptionalDouble"}},
+		"toArray":   {{name: "ToArray", result: "int[]"}},
+		"reduce": {
+			{name: "Reduce", parameters: []string{"int", "IntBinaryOperator"}, result: "int"},
+			{name: "ReduceOptional", parameters: []string{"IntBinaryOperator"}, result: "OptionalInt"},
+		},
+	},
+}
+
+// streamTypeParameter matches the type parameters in the types of the
+// streams' operations
+var streamTypeParameter = regexp.MustCompile(`\b[TR]\b`)
+
+// instantiateStream substitutes the type of a stream's elements, and the type
+// that they are mapped to, into a type from one of its operations
+func instantiateStream(javaType, element, mapped string) string {
+	return streamTypeParameter.ReplaceAllStringFunc(javaType, func(parameter string) string {
+		if parameter == "T" {
+			return element
+		}
+		return mapped
+	})
+}
+
+// streamPackage refers to a function in the `stream` package, which is given
+// explicit type arguments when Go can't infer them
+func streamPackage(name string, typeArguments ...string) ast.Expr {
+	var function ast.Expr = &ast.SelectorExpr{X: &ast.Ident{Name: "stream"}, Sel: &ast.Ident{Name: name}}
+	switch len(typeArguments) {
+	case 0:
+		return function
+	case 1:
+		return &ast.IndexExpr{X: function, Index: &ast.Ident{Name: typeArguments[0]}}
+	}
+	indices := make([]ast.Expr, len(typeArguments))
+	for ind, typeArgument := range typeArguments {
+		indices[ind] = &ast.Ident{Name: typeArgument}
+	}
+	return &ast.IndexListExpr{X: function, Indices: indices}
+}
+
+// isStandardReference tests if an expression names one of the classes from
+// Java's packages, such as the `Arrays` in `Arrays.stream`
+func isStandardReference(node *sitter.Node, className, pkg string, source []byte, ctx Ctx) bool {
+	if node == nil || ctx.currentFile == nil {
+		return false
+	}
+	switch node.Type() {
+	case "identifier":
+		if findVariable(node, source, ctx) != nil {
+			return false
+		}
+	case "field_access":
+	default:
+		return false
+	}
+	return ctx.currentFile.StandardClass(node.Content(source), pkg) == className
+}
+
+// streamSource finds the function that creates the stream that a method
+// invocation returns, such as `list.stream()` or `IntStream.range(0, 10)`,
+// along with the Java type of the stream
+//
+// Both are empty if the method invocation doesn't create a stream
+func streamSource(node *sitter.Node, source []byte, ctx Ctx) (string, string) {
+	object := node.ChildByFieldName("object")
+	if object == nil || ctx.currentFile == nil {
+		return "", ""
+	}
+	name := node.ChildByFieldName("name").Content(source)
+	argumentNodes := nodeutil.NamedChildrenOf(node.ChildByFieldName("arguments"))
+
+	switch {
+	case isStandardReference(object, "Arrays", "java.util", source, ctx):
+		if name != "stream" || (len(argumentNodes) != 1 && len(argumentNodes) != 3) {
+			return "", ""
+		}
+		arrayType := ExpressionType(argumentNodes[0], source, ctx)
+		element := strings.TrimSuffix(arrayType, "[]")
+		switch {
+		case arrayType == "int[]":
+			return "IntsOfSlice", "IntStream"
+		case isArrayType(arrayType) && !isPrimitive(element):
+			return "OfSlice", "Stream<" + element + ">"
+		}
+	case isStandardReference(object, "Stream", "java.util.stream", source, ctx):
+		if name != "of" || len(argumentNodes) == 0 {
+			return "", ""
+		}
+		// A single array is passed as the variable arguments themselves
+		if arrayType := ExpressionType(argumentNodes[0], source, ctx); len(argumentNodes) == 1 && isArrayType(arrayType) && !isPrimitive(strings.TrimSuffix(arrayType, "[]")) {
+			return "OfSlice", "Stream<" + strings.TrimSuffix(arrayType, "[]") + ">"
+		}
+		return "Of", "Stream<" + commonElementType(argumentTypes(node.ChildByFieldName("arguments"), source, ctx)) + ">"
+	case isStandardReference(object, "IntStream", "java.util.stream", source, ctx):
+		switch {
+		case name == "range" && len(argumentNodes) == 2:
+			return "Range", "IntStream"
+		case name == "rangeClosed" && len(argumentNodes) == 2:
+			return "RangeClosed", "IntStream"
+		case name == "of":
+			return "Ints", "IntStream"
+		}
+	case name == "stream" && len(argumentNodes) == 0:
+		// Every one of Java's collections can be streamed
+		objectType := ExpressionType(object, source, ctx)
+		collection := ctx.currentFile.JavaCollection(objectType)
+		for _, kind := range collectionHierarchy[collection] {
+			if kind == "Collection" {
+				_, typeArguments := symbol.SplitTypeArguments(objectType)
+				return "OfIterable", "Stream<" + instantiateCollection(collection, typeArguments, "E") + ">"
+			}
+		}
+	}
+	return "", ""
+}
+
+// commonElementType returns the type of the elements of a stream that is
+// created from values of the given types, which is an `Object` unless they
+// all have the same type
+func commonElementType(javaTypes []string) string {
+	element := symbol.BoxedType(javaTypes[0])
+	for _, javaType := range javaTypes {
+		if javaType == "" || symbol.BoxedType(javaType) != element {
+			return "Object"
+		}
+	}
+	return element
+}
+
+// NewStream translates a method invocation that creates a stream, such as
+// `list.stream()`, into the function from the `stream` package that creates
+// the same stream
+func NewStream(node *sitter.Node, source []byte, ctx Ctx, function, javaType string) ast.Expr {
+	ev := ctx.evaluation()
+	argumentNodes := nodeutil.NamedChildrenOf(node.ChildByFieldName("arguments"))
+
+	switch function {
+	case "OfIterable":
+		collection := ev.parse(node.ChildByFieldName("object"), source, identity)
+		return &ast.CallExpr{Fun: streamPackage(function, ctx.currentFile.GoType(streamElement(javaType))), Args: []ast.Expr{*collection}}
+	case "OfSlice", "IntsOfSlice":
+		arguments := parseArguments(node.ChildByFieldName("arguments"), source, ev)
+		if len(arguments) == 3 {
+			// Only part of the array is streamed
+			arguments = []ast.Expr{&ast.SliceExpr{X: arguments[0], Low: arguments[1], High: arguments[2]}}
+		}
+		return &ast.CallExpr{Fun: streamPackage(function), Args: arguments}
+	case "Of":
+		element := streamElement(javaType)
+		var arguments []*ast.Expr
+		for _, argument := range argumentNodes {
+			arguments = append(arguments, ev.parse(argument, source, func(value ast.Expr) ast.Expr {
+				// Values are stored with their types, the same as for a collection
+				if element == "Object" {
+					return ObjectConversion(value, argument, source, ctx)
+				}
+				return AssignmentConversion(value, argument, source, ctx, element)
+			}))
+		}
+		return &ast.CallExpr{Fun: streamPackage(function, ctx.currentFile.GoType(element)), Args: evaluated(arguments)}
+	}
+
+	// The rest of the functions create streams of ints
+	var arguments []*ast.Expr
+	for _, argument := range argumentNodes {
+		arguments = append(arguments, parseStreamArgument(ev, argument, source, "int"))
+	}
+	return &ast.CallExpr{Fun: streamPackage(function), Args: evaluated(arguments)}
+}
+
+// streamElement returns the Java type of the elements of a stream
+func streamElement(streamType string) string {
+	if stream, typeArguments := symbol.SplitTypeArguments(streamType); strings.HasSuffix(stream, "IntStream") {
+		return "int"
+	} else if len(typeArguments) > 0 {
+		return typeArguments[0]
+	}
+	return "Object"
+}
+
+// parseStreamArgument parses an argument of a stream operation as the Java
+// type of its parameter, where lambdas are given the types of the parameter's
+// functional interface
+func parseStreamArgument(ev *evaluation, node *sitter.Node, source []byte, to string) *ast.Expr {
+	if function := FunctionalConversion(node, source, ev.ctx, to); function != nil {
+		operand := new(ast.Expr)
+		*operand = function
+		ev.add(operand)
+		return operand
+	}
+	return ev.parse(node, source, func(value ast.Expr) ast.Expr {
+		return AssignmentConversion(value, node, source, ev.ctx, to)
+	})
+}
+
+// streamCall finds the stream that a method invocation calls an operation of,
+// the Java type of the stream's elements, and the operation that it calls
+//
+// The operation is nil if the method invocation doesn't call one of the
+// operations of a stream
+func streamCall(node *sitter.Node, source []byte, ctx Ctx) (string, string, *streamOperation) {
+	object := node.ChildByFieldName("object")
+	if object == nil || ctx.currentFile == nil {
+		return "", "", nil
+	}
+	objectType := ExpressionType(object, source, ctx)
+	stream := ctx.currentFile.JavaStream(objectType)
+	if stream == "" {
+		return "", "", nil
+	}
+
+	arguments := int(node.ChildByFieldName("arguments").NamedChildCount())
+	for _, operation := range streamOperations[stream][node.ChildByFieldName("name").Content(source)] {
+		if len(operation.parameters) == arguments {
+			return stream, streamElement(objectType), &operation
+		}
+	}
+	return "", "", nil
+}
+
+// mappedType returns the Java type that an operation maps the elements of a
+// stream to, which Java infers from the function that the operation is given,
+// such as the lambda that `map` takes
+func mappedType(node *sitter.Node, source []byte, ctx Ctx, element string, operation *streamOperation) string {
+	argumentNodes := nodeutil.NamedChildrenOf(node.ChildByFieldName("arguments"))
+	for ind, parameter := range operation.parameters {
+		signature := ctx.currentFile.FunctionalSignatureOf(instantiateStream(parameter, element, "R"))
+		if signature == nil || !streamTypeParameter.MatchString(signature.Result) {
+			continue
+		}
+		result := functionResult(argumentNodes[ind], source, ctx, signature.Parameters)
+		// Functions that map each element to a stream of values, such as the one
+		// that `flatMap` takes, map them to the type of that stream's elements,
+		// which is also the type of the value in an optional
+		if signature.Result != "R" {
+			result = streamElement(result)
+		}
+		return result
+	}
+	return "Object"
+}
+
+// functionResult returns the Java type that a function returns when it is
+// called with arguments of the given types, which is boxed, since it is used
+// as a type argument, or an `Object` if the type isn't known
+func functionResult(node *sitter.Node, source []byte, ctx Ctx, parameters []string) string {
+	if result := functionResultType(node, source, ctx, parameters); result != "" && result != "void" {
+		return symbol.BoxedType(result)
+	}
+	return "Object"
+}
+
+// streamMethodType returns the Java type that a method invocation that either
+// creates a stream, or calls one of the operations of a stream returns, or an
+// empty string if it does neither
+func streamMethodType(node *sitter.Node, source []byte, ctx Ctx) string {
+	if _, streamType := streamSource(node, source, ctx); streamType != "" {
+		return streamType
+	}
+	_, element, operation := streamCall(node, source, ctx)
+	if operation == nil {
+		return ""
+	}
+	if operation.name == "Collect" {
+		_, result := collector(node.ChildByFieldName("arguments").NamedChild(0), source, ctx, nil, element)
+		return result
+	}
+	return instantiateStream(operation.result, element, mappedType(node, source, ctx, element, operation))
+}
+
+// StreamMethod translates a method invocation that either creates a stream, or
+// calls one of the operations of a stream
+//
+// Returns nil if the method invocation does neither, or if it collects the
+// stream with a collector that can't be translated
+func StreamMethod(node *sitter.Node, source []byte, ctx Ctx) ast.Expr {
+	if function, streamType := streamSource(node, source, ctx); function != "" {
+		return NewStream(node, source, ctx, function, streamType)
+	}
+	stream, element, operation := streamCall(node, source, ctx)
+	if operation == nil {
+		return nil
+	}
+	argumentNodes := nodeutil.NamedChildrenOf(node.ChildByFieldName("arguments"))
+
+	// The collector is checked before anything is parsed, so that the call can
+	// still be translated as it is written
+	var mapped string
+	if operation.name == "Collect" {
+		if _, result := collector(argumentNodes[0], source, ctx, nil, element); result == "" {
+			return nil
+		}
+	} else {
+		mapped = mappedType(node, source, ctx, element, operation)
+	}
+
+	ev := ctx.evaluation()
+	object := ev.parse(node.ChildByFieldName("object"), source, identity)
+	var arguments []ast.Expr
+	switch {
+	case operation.name == "Collect":
+		collected, _ := collector(argumentNodes[0], source, ctx, ev, element)
+		arguments = append(arguments, collected)
+	case !operation.typeOnly:
+		var operands []*ast.Expr
+		for ind, parameter := range operation.parameters {
+			operands = append(operands, parseStreamArgument(ev, argumentNodes[ind], source, instantiateStream(parameter, element, mapped)))
+		}
+		arguments = evaluated(operands)
+	}
+
+	// Streams without a comparator are sorted by their natural order
+	if operation.name == "Sorted" && stream == "Stream" && len(arguments) == 0 {
+		arguments = append(arguments, &ast.Ident{Name: "nil"})
+	}
+
+	if operation.function {
+		return &ast.CallExpr{Fun: streamPackage(operation.name), Args: append([]ast.Expr{*object}, arguments...)}
+	}
+	return callMethod(*object, operation.name, arguments...)
+}
+
+// collector translates one of the `Collectors` that the elements of a stream
+// are collected with, and returns the Java type of the collector's result
+//
+// If the evaluation is nil, then only the type of the result is returned,
+// which is empty if the collector isn't one that can be translated
+func collector(node *sitter.Node, source []byte, ctx Ctx, ev *evaluation, element string) (ast.Expr, string) {
+	for node.Type() == "parenthesized_expression" {
+		node = node.NamedChild(0)
+	}
+	if node.Type() != "method_invocation" || !isStandardReference(node.ChildByFieldName("object"), "Collectors", "java.util.stream", source, ctx) {
+		return nil, ""
+	}
+	argumentNodes := nodeutil.NamedChildrenOf(node.ChildByFieldName("arguments"))
+	goElement := ctx.currentFile.GoType(element)
+
+	// parse parses the collector's arguments as the given Java types, and
+	// creates the collector with them
+	parse := func(function ast.Expr, parameters ...string) ast.Expr {
+		if ev == nil {
+			return nil
+		}
+		var operands []*ast.Expr
+		for ind, parameter := range parameters {
+			operands = append(operands, parseStreamArgument(ev, argumentNodes[ind], source, parameter))
+		}
+		return &ast.CallExpr{Fun: function, Args: evaluated(operands)}
+	}
+
+	switch name := node.ChildByFieldName("name").Content(source); {
+	case name == "toList" && len(argumentNodes) == 0:
+		return parse(streamPackage("ToList", goElement)), "List<" + element + ">"
+	case name == "toSet" && len(argumentNodes) == 0:
+		return parse(streamPackage("ToSet", goElement)), "Set<" + element + ">"
+	case name == "counting" && len(argumentNodes) == 0:
+		return parse(streamPackage("Counting", goElement)), "Long"
+	case name == "joining" && len(argumentNodes) != 2 && len(argumentNodes) <= 3:
+		if ev == nil {
+			return nil, "String"
+		}
+		joined := parse(streamPackage("Joining"), []string{"String", "String", "String"}[:len(argumentNodes)]...).(*ast.CallExpr)
+		// Java's versions without a delimiter, prefix, or suffix leave them empty
+		for len(joined.Args) < 3 {
+			joined.Args = append(joined.Args, &ast.BasicLit{Kind: token.STRING, Value: `""`})
+		}
+		return joined, "String"
+	case name == "toMap" && len(argumentNodes) == 2:
+		key := functionResult(argumentNodes[0], source, ctx, []string{element})
+		value := functionResult(argumentNodes[1], source, ctx, []string{element})
+		return parse(streamPackage("ToMap"), "Function<"+element+", "+key+">", "Function<"+element+", "+value+">"), "Map<" + key + ", " + value + ">"
+	case name == "groupingBy" && len(argumentNodes) == 1:
+		key := functionResult(argumentNodes[0], source, ctx, []string{element})
+		return parse(streamPackage("GroupingBy"), "Function<"+element+", "+key+">"), "Map<" + key + ", List<" + element + ">>"
+	case name == "groupingBy" && len(argumentNodes) == 2:
+		// The elements of each group are collected with another collector
+		_, downstreamResult := collector(argumentNodes[1], source, ctx, nil, element)
+		if downstreamResult == "" {
+			return nil, ""
+		}
+		key := functionResult(argumentNodes[0], source, ctx, []string{element})
+		resultType := "Map<" + key + ", " + downstreamResult + ">"
+		if ev == nil {
+			return nil, resultType
+		}
+		classifier := parseStreamArgument(ev, argumentNodes[0], source, "Function<"+element+", "+key+">")
+		downstream, _ := collector(argumentNodes[1], source, ctx, ev, element)
+		return &ast.CallExpr{Fun: streamPackage("GroupingByWith"), Args: []ast.Expr{*classifier, downstream}}, resultType
+	}
+	return nil, ""
+}
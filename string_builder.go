@@ -0,0 +1,203 @@
+package main
+
+import (
+	"go/ast"
+	"go/token"
+
+	sitter "github.com/smacker/go-tree-sitter"
+)
+
+// Java's `StringBuilder` and `StringBuffer` are both translated into
+// `stdjava.StringBuilder`
+
+// The Java return types of the methods on string builders that are
+// translated, where the methods that return the builder itself are left empty
+var stringBuilderMethodTypes = map[string]string{
+	"append":       "",
+	"insert":       "",
+	"reverse":      "",
+	"delete":       "",
+	"deleteCharAt": "",
+	"replace":      "",
+	"setLength":    "void",
+	"setCharAt":    "void",
+	"charAt":       "char",
+	"length":       "int",
+	"isEmpty":      "boolean",
+	"indexOf":      "int",
+	"lastIndexOf":  "int",
+	"substring":    "String",
+	"toString":     "String",
+}
+
+// The methods that append each of Java's types to a string builder, which are
+// separate methods in Go, since Go does not have overloading
+var stringBuilderAppends = map[string]string{
+	"String":    "Append",
+	"boolean":   "AppendBool",
+	"Boolean":   "AppendBool",
+	"char":      "AppendChar",
+	"Character": "AppendChar",
+	"byte":      "AppendInt",
+	"Byte":      "AppendInt",
+	"short":     "AppendInt",
+	"Short":     "AppendInt",
+	"int":       "AppendInt",
+	"Integer":   "AppendInt",
+	"long":      "AppendLong",
+	"Long":      "AppendLong",
+	"float":     "AppendFloat",
+	"Float":     "AppendFloat",
+	"double":    "AppendDouble",
+	"Double":    "AppendDouble",
+}
+
+// isStringBuilderType tests if a Java type is one of Java's string builders
+func isStringBuilderType(javaType string) bool {
+	return javaType == "StringBuilder" || javaType == "StringBuffer"
+}
+
+// stringBuilderMethodType returns the Java type that a method on a string
+// builder of the given type returns
+func stringBuilderMethodType(name, builderType string) string {
+	if methodType := stringBuilderMethodTypes[name]; methodType != "" {
+		return methodType
+	}
+	return builderType
+}
+
+// callMethod generates a call to a method of an object
+func callMethod(object ast.Expr, name string, args ...ast.Expr) *ast.CallExpr {
+	return &ast.CallExpr{
+		Fun:  &ast.SelectorExpr{X: object, Sel: &ast.Ident{Name: name}},
+		Args: args,
+	}
+}
+
+// charArgument converts a char into a rune, which is what the methods of
+// `stdjava.StringBuilder` take
+func charArgument(value ast.Expr) ast.Expr {
+	if primitiveTypes["char"] != "rune" {
+		return callFunc("rune", value)
+	}
+	return value
+}
+
+// NewStringBuilder creates a new string builder, which either starts with the
+// contents of a string, or is given a capacity
+//
+// Like Java, a char is a capacity, not the builder's first character
+func NewStringBuilder(arguments []ast.Expr, argumentTypes []string) ast.Expr {
+	if len(arguments) == 0 {
+		return &ast.UnaryExpr{Op: token.AND, X: &ast.CompositeLit{Type: stdjavaType("StringBuilder")}}
+	}
+	switch argumentTypes[0] {
+	case "String", "CharSequence":
+		return callStdjava("NewStringBuilder", arguments[0])
+	case "int", "short", "byte", "char":
+		if argumentTypes[0] != "int" {
+			arguments[0] = callFunc(primitiveTypes["int"], arguments[0])
+		}
+		return callStdjava("NewStringBuilderCapacity", arguments[0])
+	}
+	return callStdjava("NewStringBuilder", StringConversion(arguments[0], argumentTypes[0]))
+}
+
+// StringBuilderMethod translates a call to one of the methods of Java's string
+// builders into the methods of `stdjava.StringBuilder`
+//
+// Returns nil if the method is not one of the methods that can be translated
+func StringBuilderMethod(object ast.Expr, name string, arguments []ast.Expr, argumentTypes []string) ast.Expr {
+	argumentType := func(ind int) string {
+		if ind < len(argumentTypes) {
+			return argumentTypes[ind]
+		}
+		return ""
+	}
+
+	switch len(arguments) {
+	case 0:
+		switch name {
+		case "reverse":
+			return callMethod(object, "Reverse")
+		case "length":
+			return callMethod(object, "Length")
+		case "isEmpty":
+			return callMethod(object, "IsEmpty")
+		case "toString":
+			return callMethod(object, "String")
+		}
+	case 1:
+		switch name {
+		case "append":
+			if argumentType(0) == "char[]" {
+				return callMethod(object, "Append", callStdjava("FromChars", arguments[0]))
+			}
+			method, known := stringBuilderAppends[argumentType(0)]
+			if !known {
+				return callMethod(object, "AppendObject", arguments[0])
+			}
+			switch method {
+			case "AppendChar":
+				arguments[0] = charArgument(arguments[0])
+			case "AppendInt":
+				if argumentType(0) != "int" && argumentType(0) != "Integer" {
+					arguments[0] = callFunc(primitiveTypes["int"], arguments[0])
+				}
+			}
+			return callMethod(object, method, arguments[0])
+		case "charAt":
+			if primitiveTypes["char"] != "rune" {
+				return callFunc(primitiveTypes["char"], callMethod(object, "CharAt", arguments[0]))
+			}
+			return callMethod(object, "CharAt", arguments[0])
+		case "setLength":
+			return callMethod(object, "SetLength", arguments[0])
+		case "deleteCharAt":
+			return callMethod(object, "DeleteCharAt", arguments[0])
+		case "indexOf":
+			return callMethod(object, "IndexOf", arguments[0], &ast.BasicLit{Kind: token.INT, Value: "0"})
+		case "lastIndexOf":
+			return callMethod(object, "LastIndexOf", arguments[0], &ast.SelectorExpr{X: &ast.Ident{Name: "math"}, Sel: &ast.Ident{Name: "MaxInt32"}})
+		case "substring":
+			return callMethod(object, "SubstringFrom", arguments[0])
+		}
+	case 2:
+		switch name {
+		case "insert":
+			value := arguments[1]
+			if argumentType(1) == "char[]" {
+				value = callStdjava("FromChars", value)
+			} else {
+				value = StringConversion(value, argumentType(1))
+			}
+			return callMethod(object, "Insert", arguments[0], value)
+		case "setCharAt":
+			return callMethod(object, "SetCharAt", arguments[0], charArgument(arguments[1]))
+		case "delete":
+			return callMethod(object, "Delete", arguments[0], arguments[1])
+		case "indexOf":
+			return callMethod(object, "IndexOf", arguments[0], arguments[1])
+		case "lastIndexOf":
+			return callMethod(object, "LastIndexOf", arguments[0], arguments[1])
+		case "substring":
+			return callMethod(object, "Substring", arguments[0], arguments[1])
+		}
+	case 3:
+		if name == "replace" {
+			return callMethod(object, "Replace", arguments[0], arguments[1], arguments[2])
+		}
+	}
+	return nil
+}
+
+// isStringBuilderMethod tests if a method invocation calls a method on one of
+// Java's string builders
+func isStringBuilderMethod(node *sitter.Node, source []byte, ctx Ctx) bool {
+	object := node.ChildByFieldName("object")
+	if object == nil || !isStringBuilderType(ExpressionType(object, source, ctx)) {
+		return false
+	}
+	_, known := stringBuilderMethodTypes[node.ChildByFieldName("name").Content(source)]
+	return known
+}
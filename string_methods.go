@@ -0,0 +1,265 @@
+package main
+
+import (
+	"go/ast"
+	"go/token"
+
+	log "github.com/sirupsen/logrus"
+	sitter "github.com/smacker/go-tree-sitter"
+)
+
+// The Java return types of the methods on strings that are translated
+var stringMethodTypes = map[string]string{
+	"charAt":              "char",
+	"length":              "int",
+	"isEmpty":             "boolean",
+	"isBlank":             "boolean",
+	"substring":           "String",
+	"indexOf":             "int",
+	"lastIndexOf":         "int",
+	"contains":            "boolean",
+	"startsWith":          "boolean",
+	"endsWith":            "boolean",
+	"equals":              "boolean",
+	"equalsIgnoreCase":    "boolean",
+	"hashCode":            "int",
+	"compareTo":           "int",
+	"compareToIgnoreCase": "int",
+	"toUpperCase":         "String",
+	"toLowerCase":         "String",
+	"trim":                "String",
+	"strip":               "String",
+	"split":               "String[]",
+	"replace":             "String",
+	"replaceAll":          "String",
+	"replaceFirst":        "String",
+	"matches":             "boolean",
+	"concat":              "String",
+	"repeat":              "String",
+	"intern":              "String",
+	"toString":            "String",
+	"formatted":           "String",
+	"toCharArray":         "char[]",
+}
+
+// The Java return types of the static methods of the `String` class that are
+// translated
+var stringStaticTypes = map[string]string{
+	"valueOf": "String",
+	"format":  "String",
+	"join":    "String",
+}
+
+// callStrings generates a call to a function in Go's `strings` package
+func callStrings(name string, args ...ast.Expr) *ast.CallExpr {
+	return &ast.CallExpr{
+		Fun:  &ast.SelectorExpr{X: &ast.Ident{Name: "strings"}, Sel: &ast.Ident{Name: name}},
+		Args: args,
+	}
+}
+
+// stringArgument converts an argument that Java allows to be either a string
+// or a char, such as the argument of `indexOf`, into a string
+//
+// Any other numbers are the code points of characters
+func stringArgument(value ast.Expr, javaType string) ast.Expr {
+	switch javaType {
+	case "String", "CharSequence":
+		return value
+	case "char", "Character":
+		return StringConversion(value, "char")
+	}
+	return callFunc("string", callFunc("rune", value))
+}
+
+// StringMethod translates a call to one of the methods on a Java string, since
+// strings in Go don't have any methods
+//
+// The methods become functions from Go's `strings` package, or from `stdjava`
+// where the two behave differently, such as indexes that count UTF-16 chars
+//
+// Returns nil if the method is not one of the methods that can be translated
+func StringMethod(object ast.Expr, name string, arguments []ast.Expr, argumentTypes []string) ast.Expr {
+	argumentType := func(ind int) string {
+		if ind < len(argumentTypes) {
+			return argumentTypes[ind]
+		}
+		return ""
+	}
+
+	switch len(arguments) {
+	case 0:
+		switch name {
+		case "length":
+			return callStdjava("Length", object)
+		case "isEmpty":
+			return &ast.BinaryExpr{X: callFunc("len", object), Op: token.EQL, Y: &ast.BasicLit{Kind: token.INT, Value: "0"}}
+		case "isBlank":
+			return &ast.BinaryExpr{X: callStrings("TrimSpace", object), Op: token.EQL, Y: &ast.BasicLit{Kind: token.STRING, Value: `""`}}
+		case "toUpperCase":
+			return callStrings("ToUpper", object)
+		case "toLowerCase":
+			return callStrings("ToLower", object)
+		case "trim":
+			return callStdjava("Trim", object)
+		case "strip":
+			return callStrings("TrimSpace", object)
+		case "intern", "toString":
+			return object
+		case "hashCode":
+			return callStdjava("HashCode", object)
+		case "toCharArray":
+			return &ast.CallExpr{
+				Fun:  &ast.IndexExpr{X: stdjavaType("ToCharArray"), Index: &ast.Ident{Name: primitiveTypes["char"]}},
+				Args: []ast.Expr{object},
+			}
+		}
+	case 1:
+		switch name {
+		case "charAt":
+			// Indexes into the string's UTF-16 code units, like Java does
+			return &ast.CallExpr{
+				Fun: &ast.IndexExpr{
+					X:     &ast.SelectorExpr{X: &ast.Ident{Name: "stdjava"}, Sel: &ast.Ident{Name: "CharAt"}},
+					Index: &ast.Ident{Name: primitiveTypes["char"]},
+				},
+				Args: append([]ast.Expr{object}, arguments...),
+			}
+		case "substring":
+			return callStdjava("SubstringFrom", object, arguments[0])
+		case "indexOf":
+			return callStdjava("IndexOf", object, stringArgument(arguments[0], argumentType(0)), &ast.BasicLit{Kind: token.INT, Value: "0"})
+		case "lastIndexOf":
+			return callStdjava("LastIndexOf", object, stringArgument(arguments[0], argumentType(0)), &ast.SelectorExpr{X: &ast.Ident{Name: "math"}, Sel: &ast.Ident{Name: "MaxInt32"}})
+		case "contains":
+			return callStrings("Contains", object, arguments[0])
+		case "startsWith":
+			return callStrings("HasPrefix", object, arguments[0])
+		case "endsWith":
+			return callStrings("HasSuffix", object, arguments[0])
+		case "equals":
+			return &ast.BinaryExpr{X: object, Op: token.EQL, Y: arguments[0]}
+		case "equalsIgnoreCase":
+			return callStrings("EqualFold", object, arguments[0])
+		case "compareTo":
+			return callStdjava("CompareTo", object, arguments[0])
+		case "compareToIgnoreCase":
+			return callStdjava("CompareToIgnoreCase", object, arguments[0])
+		case "split":
+			return callStdjava("Split", object, arguments[0], &ast.BasicLit{Kind: token.INT, Value: "0"})
+		case "matches":
+			return callStdjava("Matches", object, arguments[0])
+		case "concat":
+			return &ast.BinaryExpr{X: object, Op: token.ADD, Y: arguments[0]}
+		case "repeat":
+			return callStrings("Repeat", object, callFunc("int", arguments[0]))
+		}
+	case 2:
+		switch name {
+		case "substring":
+			return callStdjava("Substring", object, arguments[0], arguments[1])
+		case "indexOf":
+			return callStdjava("IndexOf", object, stringArgument(arguments[0], argumentType(0)), arguments[1])
+		case "lastIndexOf":
+			return callStdjava("LastIndexOf", object, stringArgument(arguments[0], argumentType(0)), arguments[1])
+		case "split":
+			return callStdjava("Split", object, arguments[0], arguments[1])
+		case "replace":
+			return callStrings("ReplaceAll", object, stringArgument(arguments[0], argumentType(0)), stringArgument(arguments[1], argumentType(1)))
+		case "replaceAll":
+			return callStdjava("ReplaceAll", object, arguments[0], arguments[1])
+		case "replaceFirst":
+			return callStdjava("ReplaceFirst", object, arguments[0], arguments[1])
+		}
+	}
+
+	if name == "formatted" {
+		return callStdjava("Format", append([]ast.Expr{object}, formatArguments(arguments, argumentTypes)...)...)
+	}
+	return nil
+}
+
+// StringStaticMethod translates a call to one of the static methods of the
+// `String` class, such as `String.valueOf`
+//
+// Returns nil if the method is not one of the methods that can be translated
+func StringStaticMethod(name string, arguments []ast.Expr, argumentTypes []string, ctx Ctx) ast.Expr {
+	switch name {
+	case "valueOf", "copyValueOf":
+		if len(arguments) != 1 {
+			return nil
+		}
+		if argumentTypes[0] == "char[]" {
+			return callStdjava("FromChars", arguments[0])
+		}
+		return StringConversion(arguments[0], argumentTypes[0])
+	case "format":
+		if len(arguments) == 0 {
+			return nil
+		}
+		return callStdjava("Format", append([]ast.Expr{arguments[0]}, formatArguments(arguments[1:], argumentTypes[1:])...)...)
+	case "join":
+		if len(arguments) < 2 {
+			return nil
+		}
+		// The strings are either given as separate arguments, as an array, or as
+		// one of Java's collections
+		if len(arguments) == 2 && argumentTypes[1] == "String[]" {
+			return callStrings("Join", arguments[1], arguments[0])
+		}
+		if len(arguments) == 2 && ctx.currentFile.JavaCollection(argumentTypes[1]) != "" {
+			// Maps are collections, but they aren't iterable
+			if elementType, _ := iteratedElement(argumentTypes[1], ctx); elementType != "" {
+				return &ast.CallExpr{
+					Fun:  &ast.IndexExpr{X: stdjavaType("Join"), Index: &ast.Ident{Name: ctx.currentFile.GoType(elementType)}},
+					Args: []ast.Expr{arguments[0], arguments[1]},
+				}
+			}
+		}
+		for _, argumentType := range argumentTypes[1:] {
+			if argumentType != "String" {
+				log.WithFields(log.Fields{
+					"elements":  argumentType,
+					"className": ctx.className,
+				}).Warn("String.join is only translated for strings, arrays of strings, and Java's collections")
+				return nil
+			}
+		}
+		return callStrings("Join", &ast.CompositeLit{Type: &ast.Ident{Name: "[]string"}, Elts: arguments[1:]}, arguments[0])
+	}
+	return nil
+}
+
+// formatArguments converts the arguments of `String.format`, where chars are
+// passed as strings, since they would otherwise be formatted as numbers
+func formatArguments(arguments []ast.Expr, argumentTypes []string) []ast.Expr {
+	converted := make([]ast.Expr, len(arguments))
+	for ind, argument := range arguments {
+		converted[ind] = argument
+		if ind < len(argumentTypes) && (argumentTypes[ind] == "char" || argumentTypes[ind] == "Character") {
+			converted[ind] = StringConversion(argument, "char")
+		}
+	}
+	return converted
+}
+
+// isStringMethod tests if a method invocation calls a method on a string
+func isStringMethod(node *sitter.Node, source []byte, ctx Ctx) bool {
+	object := node.ChildByFieldName("object")
+	if object == nil || ExpressionType(object, source, ctx) != "String" {
+		return false
+	}
+	_, known := stringMethodTypes[node.ChildByFieldName("name").Content(source)]
+	return known
+}
+
+// isStringStaticMethod tests if a method invocation calls one of the static
+// methods of the `String` class
+func isStringStaticMethod(node *sitter.Node, source []byte, ctx Ctx) bool {
+	object := node.ChildByFieldName("object")
+	if object == nil || object.Content(source) != "String" || findVariable(object, source, ctx) != nil {
+		return false
+	}
+	_, known := stringStaticTypes[node.ChildByFieldName("name").Content(source)]
+	return known
+}
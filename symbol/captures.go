@@ -0,0 +1,119 @@
+package symbol
+
+import (
+	"github.com/NickyBoy89/java2go/nodeutil"
+	sitter "github.com/smacker/go-tree-sitter"
+)
+
+// analyzeCaptures finds the local variables of a method that are captured by
+// the lambdas and anonymous classes within its body, and the single-element
+// arrays that are used to get around Java's restriction that captured
+// variables must be effectively final
+func analyzeCaptures(method *Definition, body *sitter.Node, source []byte) {
+	analysis := captureAnalysis{
+		method:      method,
+		source:      source,
+		otherUses:   make(map[*Definition]bool),
+		boxElements: make(map[*Definition]bool),
+	}
+	analysis.visit(body, nil)
+
+	for def := range analysis.boxElements {
+		def.CaptureBox = def.Captured && !analysis.otherUses[def]
+	}
+}
+
+type captureAnalysis struct {
+	method *Definition
+	source []byte
+	// Variables that are used as something other than a single-element array
+	otherUses map[*Definition]bool
+	// Variables that are declared as single-element arrays
+	boxElements map[*Definition]bool
+}
+
+// visit looks at every variable referenced within a node, where the
+// enclosing node is the innermost lambda or anonymous class that contains it
+func (ca captureAnalysis) visit(node, enclosing *sitter.Node) {
+	switch node.Type() {
+	case "lambda_expression", "class_body":
+		enclosing = node
+	case "variable_declarator":
+		name := node.ChildByFieldName("name")
+		if def := ca.method.FindVariableAt(name.Content(ca.source), name.StartByte()); def != nil && isSingleElementArray(node, ca.source) {
+			ca.boxElements[def] = true
+		}
+	case "identifier":
+		ca.visitIdentifier(node, enclosing)
+		return
+	}
+
+	for _, child := range nodeutil.NamedChildrenOf(node) {
+		ca.visit(child, enclosing)
+	}
+}
+
+// isVariableReference tests if an identifier can refer to a variable, instead
+// of being the name of a field, a method, or a variable that is being declared
+func isVariableReference(node *sitter.Node) bool {
+	parent := node.Parent()
+	switch parent.Type() {
+	case "field_access":
+		// Only the object of a field access can be a variable
+		return parent.ChildByFieldName("object").Equal(node)
+	case "method_invocation", "variable_declarator":
+		return !parent.ChildByFieldName("name").Equal(node)
+	case "method_reference":
+		return parent.NamedChild(0).Equal(node)
+	}
+	return true
+}
+
+func (ca captureAnalysis) visitIdentifier(node, enclosing *sitter.Node) {
+	if !isVariableReference(node) {
+		return
+	}
+	parent := node.Parent()
+
+	def := ca.method.FindVariableAt(node.Content(ca.source), node.StartByte())
+	if def == nil {
+		return
+	}
+
+	// A variable that is declared outside of a lambda is captured by it
+	if enclosing != nil && (def.StartByte < enclosing.StartByte() || def.StartByte >= enclosing.EndByte()) {
+		def.Captured = true
+	}
+
+	// Boxed variables are only ever used through their first element
+	if parent.Type() != "array_access" || !parent.NamedChild(0).Equal(node) || parent.NamedChild(1).Content(ca.source) != "0" {
+		ca.otherUses[def] = true
+	}
+}
+
+// isSingleElementArray tests if a variable declarator declares a
+// one-dimensional array with a single element, such as `int[] count = {0}`,
+// or `int[] count = new int[1]`
+func isSingleElementArray(declarator *sitter.Node, source []byte) bool {
+	value := declarator.ChildByFieldName("value")
+	if value == nil || declarator.ChildByFieldName("dimensions") != nil {
+		return false
+	}
+
+	switch value.Type() {
+	case "array_initializer":
+		return value.NamedChildCount() == 1 && value.NamedChild(0).Type() != "array_initializer"
+	case "array_creation_expression":
+		var dimensions []*sitter.Node
+		for _, child := range nodeutil.NamedChildrenOf(value) {
+			switch child.Type() {
+			case "dimensions_expr":
+				dimensions = append(dimensions, child)
+			case "dimensions", "array_initializer":
+				return false
+			}
+		}
+		return len(dimensions) == 1 && dimensions[0].NamedChild(0).Content(source) == "1"
+	}
+	return false
+}
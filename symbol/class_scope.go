@@ -10,6 +10,14 @@ type ClassScope struct {
 	Fields []*Definition
 	// Methods and constructors
 	Methods []*Definition
+	// The names of the class's generic type parameters
+	TypeParameters []string
+	// Whether the class is an interface with a single abstract method, which
+	// is translated into a function type
+	Functional bool
+	// The Java type of the class that the class extends, which is empty if it
+	// doesn't extend one
+	Superclass string
 }
 
 // FindMethod searches through the immediate class's methods find a specific method
@@ -86,6 +94,47 @@ func (cs *ClassScope) FindMethodByDisplayName(name string, ignoredParameterTypes
 	return cs.findMethodWithComparison(func(method *Definition) bool { return method.Name == name }, ignoredParameterTypes)
 }
 
+// FindMethodByArguments searches for a method by its original name, as well as
+// the types of the arguments that it is called with, to pick the correct one
+// out of any overloaded methods
+// Argument types that could not be determined are left empty, and match any type
+func (cs *ClassScope) FindMethodByArguments(name string, argumentTypes []string) *Definition {
+	var candidates []*Definition
+	for _, method := range cs.Methods {
+		if method.OriginalName == name && len(method.Parameters) == len(argumentTypes) {
+			candidates = append(candidates, method)
+		}
+	}
+
+	for _, method := range candidates {
+		matches := true
+		for index, parameter := range method.Parameters {
+			if argumentTypes[index] != "" && parameter.OriginalType != argumentTypes[index] {
+				matches = false
+				break
+			}
+		}
+		if matches {
+			return method
+		}
+	}
+
+	// If none of the types match exactly, then the arguments might have been
+	// widened or boxed, so pick the first method that can take them
+	if len(candidates) > 0 {
+		return candidates[0]
+	}
+
+	// The method might take a variable number of arguments
+	for _, method := range cs.Methods {
+		if method.OriginalName == name {
+			return method
+		}
+	}
+
+	return nil
+}
+
 func (cs *ClassScope) findMethodWithComparison(comparison func(method *Definition) bool, ignoredParameterTypes []string) *Definition {
 	for _, method := range cs.Methods {
 		if comparison(method) {
@@ -124,6 +173,20 @@ func (cs *ClassScope) FindClass(name string) *Definition {
 	return nil
 }
 
+// FindClassScope searches through a class and its subclasses for the scope of
+// a class with the given original name, or nil if none was found
+func (cs *ClassScope) FindClassScope(name string) *ClassScope {
+	if cs.Class.OriginalName == name {
+		return cs
+	}
+	for _, subclass := range cs.Subclasses {
+		if scope := subclass.FindClassScope(name); scope != nil {
+			return scope
+		}
+	}
+	return nil
+}
+
 // FindFieldByName searches for a field by its original name, and returns its definition
 // or nil if none was found
 func (cs *ClassScope) FindFieldByName(name string) *Definition {
@@ -0,0 +1,60 @@
+package symbol
+
+import "strings"
+
+// The collections in `java.util`, which are implemented by `stdjava`, mapped
+// to their Go types, where the interfaces are Go interfaces, and the classes
+// are pointers to structs
+var javaCollections = map[string]string{
+	"Iterator":   "stdjava.Iterator",
+	"Iterable":   "stdjava.Iterable",
+	"Collection": "stdjava.Collection",
+	"List":       "stdjava.List",
+	"Set":        "stdjava.Set",
+	"Queue":      "stdjava.Queue",
+	"Deque":      "stdjava.Deque",
+	"Map":        "stdjava.Map",
+	"Map.Entry":  "stdjava.Entry",
+	"Entry":      "stdjava.Entry",
+	"ArrayList":  "*stdjava.ArrayList",
+	"LinkedList": "*stdjava.LinkedList",
+	"ArrayDeque": "*stdjava.ArrayDeque",
+	"HashSet":    "*stdjava.HashSet",
+	"HashMap":    "*stdjava.HashMap",
+}
+
+// CollectionTypeParameters returns the type parameters of one of the
+// collections in `java.util`, which are the keys and values of maps, and the
+// elements of any other collection
+func CollectionTypeParameters(collection string) []string {
+	switch collection {
+	case "Map", "Map.Entry", "Entry", "HashMap":
+		return []string{"K", "V"}
+	}
+	return []string{"E"}
+}
+
+// JavaCollection returns the name of the collection from `java.util` that a
+// Java type refers to, without its type arguments, or an empty string if it
+// isn't one of them
+func (fs *FileScope) JavaCollection(javaType string) string {
+	className, _ := SplitTypeArguments(strings.TrimSpace(javaType))
+	className = fs.StandardClass(className, "java.util")
+	if _, known := javaCollections[className]; !known {
+		return ""
+	}
+	return className
+}
+
+// collectionGoType converts one of the collections from `java.util` into its
+// generic Go type, where raw types have `Object`s as their type arguments
+func (fs *FileScope) collectionGoType(collection string, arguments []string, typeParameters []string) string {
+	goArguments := make([]string, len(CollectionTypeParameters(collection)))
+	for ind := range goArguments {
+		goArguments[ind] = "any"
+		if ind < len(arguments) {
+			goArguments[ind] = fs.goType(arguments[ind], typeParameters)
+		}
+	}
+	return javaCollections[collection] + "[" + strings.Join(goArguments, ", ") + "]"
+}
@@ -15,10 +15,31 @@ type Definition struct {
 	// This is used so that the definition handles its special naming and
 	// type rules correctly
 	Constructor bool
+	// If the definition is a static field or method, which are declared at the
+	// package level, instead of on the class's struct
+	Static bool
+	// If the variable is a local that is captured by a lambda or an anonymous
+	// class, which Java only allows if the variable is effectively final
+	Captured bool
+	// If the variable is a single-element array that is only used to let a
+	// lambda modify a captured value, such as `final int[] counter = {0}`
+	CaptureBox bool
+	// If the variable is a list or a map that is never shared with any other
+	// code, so it can be a Go slice or map instead of one of stdjava's
+	// collections
+	NativeCollection bool
+	// Why a local list or map has to be one of stdjava's collections
+	CollectionEscape string
+
 	// If the object is a function, it has parameters
 	Parameters []*Definition
 	// Children of the declaration, if the declaration is a scope
 	Children []*Definition
+
+	// The range of the source, in bytes, that the definition is visible in
+	// For a scope, this is the entire block, and for a local variable, this
+	// starts at its declaration, and ends at the end of its enclosing scope
+	StartByte, EndByte uint32
 }
 
 // Rename changes the display name of a definition
@@ -61,6 +82,48 @@ func (d *Definition) FindVariable(name string) *Definition {
 	return nil
 }
 
+// FindVariableAt searches a definition's parameters, as well as all of its
+// nested scopes, for a variable that is visible at the given byte offset in
+// the source, and returns the innermost one, or nil if none was found
+func (d *Definition) FindVariableAt(name string, offset uint32) *Definition {
+	if param := d.ParameterByName(name); param != nil {
+		return param
+	}
+	return d.findVisibleChild(name, offset)
+}
+
+func (d *Definition) findVisibleChild(name string, offset uint32) *Definition {
+	var found *Definition
+	for _, child := range d.Children {
+		if offset < child.StartByte || offset >= child.EndByte {
+			continue
+		}
+		// Scopes have no name, so look through their children
+		if child.OriginalName == "" {
+			if def := child.findVisibleChild(name, offset); def != nil {
+				return def
+			}
+		} else if child.OriginalName == name {
+			found = child
+		}
+	}
+	return found
+}
+
+// Locals returns every local variable declared within a definition, including
+// the ones that are declared inside of any nested scopes
+func (d *Definition) Locals() []*Definition {
+	var locals []*Definition
+	for _, child := range d.Children {
+		if child.OriginalName == "" {
+			locals = append(locals, child.Locals()...)
+		} else {
+			locals = append(locals, child)
+		}
+	}
+	return locals
+}
+
 func (d Definition) IsEmpty() bool {
 	return d.OriginalName == "" && len(d.Children) == 0
 }
@@ -26,6 +26,40 @@ func (fs *FileScope) FindClass(name string) *Definition {
 	return nil
 }
 
+// FindClassScope searches through a file for the scope of a class with the given
+// original name, or nil if the class is not defined in the file
+func (fs *FileScope) FindClassScope(name string) *ClassScope {
+	return fs.BaseClass.FindClassScope(name)
+}
+
+// SuperclassScope returns the scope of the class that a class extends, or nil
+// if it doesn't extend a class that is declared in the file's package
+func (fs *FileScope) SuperclassScope(class *ClassScope) *ClassScope {
+	if class.Superclass == "" {
+		return nil
+	}
+	name, _ := SplitTypeArguments(class.Superclass)
+	return fs.findClassScope(name)
+}
+
+// Superclasses returns the names of the classes that a class in the file's
+// package extends, starting with its own superclass, and ending with the first
+// class that isn't declared in the package, such as one of the JDK's classes
+func (fs *FileScope) Superclasses(className string) []string {
+	var superclasses []string
+	// Classes can't extend themselves, but an invalid cycle stops when it repeats
+	seen := map[*ClassScope]bool{}
+	for class := fs.findClassScope(className); class != nil && !seen[class]; class = fs.SuperclassScope(class) {
+		seen[class] = true
+		if class.Superclass == "" {
+			break
+		}
+		name, _ := SplitTypeArguments(class.Superclass)
+		superclasses = append(superclasses, name)
+	}
+	return superclasses
+}
+
 // FindField searches through all of the classes in a file and determines if a
 // field exists
 func (cs *FileScope) FindField() Finder {
@@ -0,0 +1,189 @@
+package symbol
+
+import (
+	"regexp"
+	"strings"
+)
+
+// FunctionalSignature is the signature of the single method of a functional
+// interface, which is translated into a Go function type
+type FunctionalSignature struct {
+	// The name of the interface's method, such as `apply` for a `Function`
+	Method string
+	// The type parameters of the interface, which are empty once the interface
+	// has been given its type arguments
+	TypeParameters []string
+	// The Java types of the method's parameters
+	Parameters []string
+	// The Java type that the method returns, which is `void` if it returns nothing
+	Result string
+}
+
+// The functional interfaces in `java.util.function`, as well as other commonly
+// used functional interfaces
+var functionalInterfaces = map[string]FunctionalSignature{
+	"Runnable":             {Method: "run", Result: "void"},
+	"Callable":             {Method: "call", TypeParameters: []string{"V"}, Result: "V"},
+	"Comparator":           {Method: "compare", TypeParameters: []string{"T"}, Parameters: []string{"T", "T"}, Result: "int"},
+	"Supplier":             {Method: "get", TypeParameters: []string{"T"}, Result: "T"},
+	"Consumer":             {Method: "accept", TypeParameters: []string{"T"}, Parameters: []string{"T"}, Result: "void"},
+	"BiConsumer":           {Method: "accept", TypeParameters: []string{"T", "U"}, Parameters: []string{"T", "U"}, Result: "void"},
+	"Function":             {Method: "apply", TypeParameters: []string{"T", "R"}, Parameters: []string{"T"}, Result: "R"},
+	"BiFunction":           {Method: "apply", TypeParameters: []string{"T", "U", "R"}, Parameters: []string{"T", "U"}, Result: "R"},
+	"UnaryOperator":        {Method: "apply", TypeParameters: []string{"T"}, Parameters: []string{"T"}, Result: "T"},
+	"BinaryOperator":       {Method: "apply", TypeParameters: []string{"T"}, Parameters: []string{"T", "T"}, Result: "T"},
+	"Predicate":            {Method: "test", TypeParameters: []string{"T"}, Parameters: []string{"T"}, Result: "boolean"},
+	"BiPredicate":          {Method: "test", TypeParameters: []string{"T", "U"}, Parameters: []string{"T", "U"}, Result: "boolean"},
+	"BooleanSupplier":      {Method: "getAsBoolean", Result: "boolean"},
+	"IntSupplier":          {Method: "getAsInt", Result: "int"},
+	"IntConsumer":          {Method: "accept", Parameters: []string{"int"}, Result: "void"},
+	"IntFunction":          {Method: "apply", TypeParameters: []string{"R"}, Parameters: []string{"int"}, Result: "R"},
+	"IntPredicate":         {Method: "test", Parameters: []string{"int"}, Result: "boolean"},
+	"IntUnaryOperator":     {Method: "applyAsInt", Parameters: []string{"int"}, Result: "int"},
+	"IntBinaryOperator":    {Method: "applyAsInt", Parameters: []string{"int", "int"}, Result: "int"},
+	"ToIntFunction":        {Method: "applyAsInt", TypeParameters: []string{"T"}, Parameters: []string{"T"}, Result: "int"},
+	"ToLongFunction":       {Method: "applyAsLong", TypeParameters: []string{"T"}, Parameters: []string{"T"}, Result: "long"},
+	"ToDoubleFunction":     {Method: "applyAsDouble", TypeParameters: []string{"T"}, Parameters: []string{"T"}, Result: "double"},
+	"LongSupplier":         {Method: "getAsLong", Result: "long"},
+	"LongConsumer":         {Method: "accept", Parameters: []string{"long"}, Result: "void"},
+	"LongFunction":         {Method: "apply", TypeParameters: []string{"R"}, Parameters: []string{"long"}, Result: "R"},
+	"LongUnaryOperator":    {Method: "applyAsLong", Parameters: []string{"long"}, Result: "long"},
+	"DoubleSupplier":       {Method: "getAsDouble", Result: "double"},
+	"DoubleConsumer":       {Method: "accept", Parameters: []string{"double"}, Result: "void"},
+	"DoubleFunction":       {Method: "apply", TypeParameters: []string{"R"}, Parameters: []string{"double"}, Result: "R"},
+	"DoubleUnaryOperator":  {Method: "applyAsDouble", Parameters: []string{"double"}, Result: "double"},
+	"DoubleBinaryOperator": {Method: "applyAsDouble", Parameters: []string{"double", "double"}, Result: "double"},
+}
+
+// FunctionalSignature returns the signature of a functional interface's method
+func (cs *ClassScope) FunctionalSignature() FunctionalSignature {
+	method := cs.Methods[0]
+
+	signature := FunctionalSignature{
+		Method:         method.OriginalName,
+		TypeParameters: cs.TypeParameters,
+		Result:         method.OriginalType,
+	}
+	for _, param := range method.Parameters {
+		signature.Parameters = append(signature.Parameters, param.OriginalType)
+	}
+	return signature
+}
+
+// FunctionalSignatureOf returns the signature of the functional interface that
+// a Java type refers to, with the type's arguments substituted into it, or nil
+// if the type is not a functional interface
+func (fs *FileScope) FunctionalSignatureOf(javaType string) *FunctionalSignature {
+	if fs == nil {
+		return nil
+	}
+
+	className, arguments := SplitTypeArguments(strings.TrimSpace(javaType))
+
+	var signature FunctionalSignature
+	if class := fs.findClassScope(className); class != nil {
+		// Classes in the package may have the same names as the standard library's
+		if !class.Functional {
+			return nil
+		}
+		signature = class.FunctionalSignature()
+	} else if known, ok := functionalInterfaces[standardFunctionalName(className)]; ok {
+		signature = known
+	} else {
+		return nil
+	}
+
+	signature = signature.Instantiate(arguments)
+	return &signature
+}
+
+// functionalPackages are the packages of the functional interfaces from Java's
+// standard library, which may be referred to by their qualified names, such as
+// `java.util.function.IntSupplier`
+var functionalPackages = []string{"java.util.function", "java.util.concurrent", "java.util", "java.lang"}
+
+// standardFunctionalName returns the name of a functional interface from Java's
+// standard library without its package
+func standardFunctionalName(className string) string {
+	for _, pkg := range functionalPackages {
+		if name := strings.TrimPrefix(className, pkg+"."); name != className {
+			return name
+		}
+	}
+	return className
+}
+
+// namedFunctionalType returns the Go type that refers to one of the package's
+// functional interfaces by the name of the function type that it is declared
+// as, or an empty string if the Java type doesn't refer to one of them
+//
+// Generic interfaces that aren't given all of their type arguments can't be
+// named, and are referred to by their function types instead
+func (fs *FileScope) namedFunctionalType(javaType string, typeParameters []string) string {
+	className, arguments := SplitTypeArguments(strings.TrimSpace(javaType))
+	class := fs.findClassScope(className)
+	if class == nil || !class.Functional || len(arguments) != len(class.TypeParameters) {
+		return ""
+	}
+	if len(arguments) == 0 {
+		return class.Class.Name
+	}
+
+	goArguments := make([]string, len(arguments))
+	for ind, argument := range arguments {
+		goArguments[ind] = fs.goType(argument, typeParameters)
+	}
+	return class.Class.Name + "[" + strings.Join(goArguments, ", ") + "]"
+}
+
+// Instantiate substitutes the type arguments of a generic functional interface
+// into its signature, where any missing arguments are treated as `Object`s
+func (fs FunctionalSignature) Instantiate(arguments []string) FunctionalSignature {
+	if len(fs.TypeParameters) == 0 {
+		return fs
+	}
+
+	substitute := func(javaType string) string {
+		for ind, param := range fs.TypeParameters {
+			argument := "Object"
+			if ind < len(arguments) {
+				argument = arguments[ind]
+			}
+			javaType = regexp.MustCompile(`\b`+regexp.QuoteMeta(param)+`\b`).ReplaceAllLiteralString(javaType, argument)
+		}
+		return javaType
+	}
+
+	instantiated := FunctionalSignature{Method: fs.Method, Result: substitute(fs.Result)}
+	for _, param := range fs.Parameters {
+		instantiated.Parameters = append(instantiated.Parameters, substitute(param))
+	}
+	return instantiated
+}
+
+// ParameterTypes returns the Go types of each of the method's parameters
+func (fs FunctionalSignature) ParameterTypes(file *FileScope) []string {
+	types := make([]string, len(fs.Parameters))
+	for ind, param := range fs.Parameters {
+		types[ind] = file.goType(param, fs.TypeParameters)
+	}
+	return types
+}
+
+// ResultType returns the Go type that the method returns, or an empty string
+// if it does not return anything
+func (fs FunctionalSignature) ResultType(file *FileScope) string {
+	if fs.Result == "void" || fs.Result == "" {
+		return ""
+	}
+	return file.goType(fs.Result, fs.TypeParameters)
+}
+
+// goType returns the Go function type that the interface is translated as
+func (fs FunctionalSignature) goType(file *FileScope) string {
+	goType := "func(" + strings.Join(fs.ParameterTypes(file), ", ") + ")"
+	if result := fs.ResultType(file); result != "" {
+		goType += " " + result
+	}
+	return goType
+}
@@ -0,0 +1,264 @@
+package symbol
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/NickyBoy89/java2go/nodeutil"
+	sitter "github.com/smacker/go-tree-sitter"
+)
+
+// nativeMethod is a method of lists and maps that can be translated into an
+// operation on Go's slices and maps
+type nativeMethod struct {
+	// The number of arguments that the method takes
+	arguments uint32
+	// Whether the method's result can't be used, because Go's version of it is
+	// a statement
+	statement bool
+}
+
+var (
+	nativeListMethods = map[string]nativeMethod{
+		"add":     {arguments: 1, statement: true},
+		"set":     {arguments: 2, statement: true},
+		"remove":  {arguments: 1, statement: true},
+		"clear":   {statement: true},
+		"get":     {arguments: 1},
+		"size":    {},
+		"isEmpty": {},
+	}
+	nativeMapMethods = map[string]nativeMethod{
+		"put":         {arguments: 2, statement: true},
+		"remove":      {arguments: 1, statement: true},
+		"clear":       {statement: true},
+		"get":         {arguments: 1},
+		"containsKey": {arguments: 1},
+		"size":        {},
+		"isEmpty":     {},
+	}
+)
+
+// The types of the keys that Go's maps compare the same way as Java's `equals`
+var nativeKeyTypes = map[string]bool{
+	"String":    true,
+	"Integer":   true,
+	"Long":      true,
+	"Short":     true,
+	"Byte":      true,
+	"Character": true,
+	"Boolean":   true,
+}
+
+// analyzeCollections finds the local lists and maps of a method that can be
+// Go's slices and maps, because they are created within the method, and never
+// shared with any other code that could see the difference between them
+func analyzeCollections(method *Definition, body *sitter.Node, source []byte) {
+	analysis := collectionAnalysis{
+		method:     method,
+		source:     source,
+		candidates: make(map[*Definition]bool),
+	}
+	analysis.visit(body)
+
+	for def := range analysis.candidates {
+		def.NativeCollection = def.CollectionEscape == ""
+	}
+}
+
+type collectionAnalysis struct {
+	method *Definition
+	source []byte
+	// The local variables that are declared as lists or maps, mapped to whether
+	// they are lists
+	candidates map[*Definition]bool
+}
+
+func (an collectionAnalysis) visit(node *sitter.Node) {
+	switch node.Type() {
+	case "variable_declarator":
+		if node.Parent().Type() == "local_variable_declaration" {
+			an.visitDeclarator(node)
+		}
+	case "identifier":
+		if isVariableReference(node) {
+			an.visitIdentifier(node)
+		}
+		return
+	}
+
+	for _, child := range nodeutil.NamedChildrenOf(node) {
+		an.visit(child)
+	}
+}
+
+// nativeKind returns whether a Java type is a list or a map that could be a
+// Go slice or map, from the name of its class
+func nativeKind(javaType string) (isList, isMap bool) {
+	className, _ := SplitTypeArguments(javaType)
+	switch strings.TrimPrefix(className, "java.util.") {
+	case "List", "ArrayList":
+		return true, false
+	case "Map", "HashMap":
+		return false, true
+	}
+	return false, false
+}
+
+func (an collectionAnalysis) visitDeclarator(node *sitter.Node) {
+	name := node.ChildByFieldName("name")
+	def := an.method.FindVariableAt(name.Content(an.source), name.StartByte())
+	if def == nil {
+		return
+	}
+	isList, isMap := nativeKind(def.OriginalType)
+	if !isList && !isMap {
+		return
+	}
+	an.candidates[def] = isList
+
+	_, arguments := SplitTypeArguments(def.OriginalType)
+	switch {
+	case len(arguments) == 0:
+		def.CollectionEscape = "it has no type arguments"
+	case isMap && !nativeKeyTypes[arguments[0]]:
+		def.CollectionEscape = fmt.Sprintf("its keys are compared with the equals method of %s", arguments[0])
+	case !an.isCreation(node.ChildByFieldName("value"), isList):
+		def.CollectionEscape = "it is not initialized with a new collection"
+	}
+}
+
+// isCreation tests if a value creates a new, empty collection of the same kind
+// as a variable, such as `new ArrayList<>()`, or `new HashMap<>(16)`
+func (an collectionAnalysis) isCreation(value *sitter.Node, isList bool) bool {
+	if value == nil || value.Type() != "object_creation_expression" {
+		return false
+	}
+	className, _ := SplitTypeArguments(value.ChildByFieldName("type").Content(an.source))
+	switch strings.TrimPrefix(className, "java.util.") {
+	case "ArrayList":
+		if !isList {
+			return false
+		}
+	case "HashMap":
+		if isList {
+			return false
+		}
+	default:
+		return false
+	}
+
+	arguments := value.ChildByFieldName("arguments")
+	switch arguments.NamedChildCount() {
+	case 0:
+		return true
+	case 1:
+		// A collection can be given a capacity
+		return an.isIndex(arguments.NamedChild(0))
+	}
+	return false
+}
+
+// isIndex tests if an expression is always an int, so that it is an index,
+// instead of an element
+func (an collectionAnalysis) isIndex(node *sitter.Node) bool {
+	switch node.Type() {
+	case "decimal_integer_literal", "hex_integer_literal", "octal_integer_literal", "binary_integer_literal":
+		return true
+	case "identifier":
+		def := an.method.FindVariableAt(node.Content(an.source), node.StartByte())
+		if def == nil {
+			return false
+		}
+		switch def.OriginalType {
+		case "int", "short", "byte", "char":
+			return true
+		}
+	case "parenthesized_expression":
+		return an.isIndex(node.NamedChild(0))
+	case "binary_expression":
+		switch node.Child(1).Content(an.source) {
+		case "+", "-", "*", "/", "%":
+			return an.isIndex(node.Child(0)) && an.isIndex(node.Child(2))
+		}
+	}
+	return false
+}
+
+// visitIdentifier looks at one of the uses of a variable, and records why it
+// can't be a Go slice or map, if it is used in a way that needs one of
+// stdjava's collections
+func (an collectionAnalysis) visitIdentifier(node *sitter.Node) {
+	def := an.method.FindVariableAt(node.Content(an.source), node.StartByte())
+	isList, candidate := an.candidates[def]
+	if !candidate || def.CollectionEscape != "" {
+		return
+	}
+	def.CollectionEscape = an.escape(node, isList)
+}
+
+// escape returns why a use of a list or a map needs one of stdjava's
+// collections, or an empty string if it doesn't
+func (an collectionAnalysis) escape(node *sitter.Node, isList bool) string {
+	parent := node.Parent()
+	switch parent.Type() {
+	case "method_invocation":
+		if !parent.ChildByFieldName("object").Equal(node) {
+			return "it is passed to a method"
+		}
+		name := parent.ChildByFieldName("name").Content(an.source)
+		arguments := parent.ChildByFieldName("arguments")
+
+		methods := nativeMapMethods
+		if isList {
+			methods = nativeListMethods
+		}
+		method, supported := methods[name]
+		switch {
+		case name == "keySet" || name == "values":
+			// The keys and values of a map can only be iterated over
+			if !isList && arguments.NamedChildCount() == 0 && an.isIterated(parent) {
+				return ""
+			}
+			return fmt.Sprintf("the result of its %s method is used", name)
+		case !supported:
+			return fmt.Sprintf("its %s method is used", name)
+		case arguments.NamedChildCount() != method.arguments:
+			return fmt.Sprintf("its %s method is used with %d arguments", name, arguments.NamedChildCount())
+		case method.statement && parent.Parent().Type() != "expression_statement":
+			return fmt.Sprintf("the result of its %s method is used", name)
+		case isList && name == "remove" && !an.isIndex(arguments.NamedChild(0)):
+			return "an element may be removed from it by value"
+		}
+		return ""
+	case "enhanced_for_statement":
+		if isList && an.isIterated(node) {
+			return ""
+		}
+	case "assignment_expression":
+		// It can be replaced with another new collection
+		if parent.ChildByFieldName("left").Equal(node) {
+			if parent.Child(1).Content(an.source) == "=" && parent.Parent().Type() == "expression_statement" && an.isCreation(parent.ChildByFieldName("right"), isList) {
+				return ""
+			}
+			return "it is assigned something other than a new collection"
+		}
+		return "it is assigned to another variable"
+	case "variable_declarator":
+		return "it is assigned to another variable"
+	case "argument_list":
+		return "it is passed to a method"
+	case "return_statement":
+		return "it is returned"
+	case "method_reference":
+		return "one of its methods is referred to"
+	}
+	return "it is used as a value"
+}
+
+// isIterated tests if an expression is the value that an enhanced for
+// statement iterates over
+func (an collectionAnalysis) isIterated(node *sitter.Node) bool {
+	parent := node.Parent()
+	return parent.Type() == "enhanced_for_statement" && parent.NamedChild(int(parent.NamedChildCount())-2).Equal(node)
+}
@@ -0,0 +1,37 @@
+package symbol
+
+import "strings"
+
+// The optionals in `java.util`, which are implemented by `stdjava`, mapped to
+// their Go types
+var javaOptionals = map[string]string{
+	"Optional":       "stdjava.Optional",
+	"OptionalInt":    "stdjava.OptionalInt",
+	"OptionalLong":   "stdjava.OptionalLong",
+	"OptionalDouble": "stdjava.OptionalDouble",
+}
+
+// JavaOptional returns the name of the optional from `java.util` that a Java
+// type refers to, without its type arguments, or an empty string if it isn't
+// one of them
+func (fs *FileScope) JavaOptional(javaType string) string {
+	className, _ := SplitTypeArguments(strings.TrimSpace(javaType))
+	className = fs.StandardClass(className, "java.util")
+	if _, known := javaOptionals[className]; !known {
+		return ""
+	}
+	return className
+}
+
+// optionalGoType converts one of the optionals from `java.util` into its Go
+// type, where a raw `Optional` contains an `Object`
+func (fs *FileScope) optionalGoType(optional string, arguments []string, typeParameters []string) string {
+	if optional != "Optional" {
+		return javaOptionals[optional]
+	}
+	value := "any"
+	if len(arguments) > 0 {
+		value = fs.goType(arguments[0], typeParameters)
+	}
+	return javaOptionals[optional] + "[" + value + "]"
+}
@@ -33,7 +33,7 @@ func (pf *PackageFieldFinder) By(criteria func(d *Definition) bool) []*Definitio
 	results := []*Definition{}
 	for _, file := range pf.Files {
 		for _, field := range file.BaseClass.Fields {
-			if criteria(field) {
+			if field.Static && criteria(field) {
 				results = append(results, field)
 			}
 		}
@@ -53,6 +53,37 @@ func (ps *PackageFieldFinder) ByOriginalName(originalName string) []*Definition
 	})
 }
 
+func (ps *PackageScope) FindStaticMethod() Finder {
+	pm := PackageMethodFinder(*ps)
+	return &pm
+}
+
+type PackageMethodFinder PackageScope
+
+func (pm *PackageMethodFinder) By(criteria func(d *Definition) bool) []*Definition {
+	results := []*Definition{}
+	for _, file := range pm.Files {
+		for _, method := range file.BaseClass.Methods {
+			if method.Static && criteria(method) {
+				results = append(results, method)
+			}
+		}
+	}
+	return results
+}
+
+func (pm *PackageMethodFinder) ByName(name string) []*Definition {
+	return pm.By(func(d *Definition) bool {
+		return d.Name == name
+	})
+}
+
+func (pm *PackageMethodFinder) ByOriginalName(originalName string) []*Definition {
+	return pm.By(func(d *Definition) bool {
+		return d.OriginalName == originalName
+	})
+}
+
 func (ps *PackageScope) AddSymbolsFromFile(symbols *FileScope) {
 	ps.Files[symbols.BaseClass.Class.Name] = symbols
 }
@@ -61,14 +92,8 @@ func (ps *PackageScope) AddSymbolsFromFile(symbols *FileScope) {
 // the class may be the subclass of another class
 func (ps *PackageScope) FindClass(name string) *ClassScope {
 	for _, fileScope := range ps.Files {
-		if fileScope.BaseClass.Class.OriginalName == name {
-			return fileScope.BaseClass
-		}
-		for _, subclass := range fileScope.BaseClass.Subclasses {
-			class := subclass.FindClass(name)
-			if class != nil {
-				return fileScope.BaseClass
-			}
+		if class := fileScope.FindClassScope(name); class != nil {
+			return class
 		}
 	}
 	return nil
@@ -57,18 +57,34 @@ func parseClassScope(root *sitter.Node, source []byte) *ClassScope {
 		},
 	}
 
+	if typeParameters := root.ChildByFieldName("type_parameters"); typeParameters != nil {
+		for _, param := range nodeutil.NamedChildrenOf(typeParameters) {
+			scope.TypeParameters = append(scope.TypeParameters, param.NamedChild(0).Content(source))
+		}
+	}
+
+	if superclass := root.ChildByFieldName("superclass"); superclass != nil {
+		scope.Superclass = superclass.NamedChild(0).Content(source)
+	}
+
+	// Interfaces with a single abstract method are functional interfaces
+	var abstractMethods int
+
 	// Parse the body of the class
 
 	for _, node := range nodeutil.NamedChildrenOf(root.ChildByFieldName("body")) {
 
 		switch node.Type() {
 		case "field_declaration":
-			var public bool
+			var public, static bool
 			// Rename the type based on the public/static rules
 			if node.NamedChild(0).Type() == "modifiers" {
 				for _, modifier := range nodeutil.UnnamedChildrenOf(node.NamedChild(0)) {
-					if modifier.Type() == "public" {
+					switch modifier.Type() {
+					case "public":
 						public = true
+					case "static":
+						static = true
 					}
 				}
 			}
@@ -98,14 +114,18 @@ func parseClassScope(root *sitter.Node, source []byte) *ClassScope {
 				OriginalName: fieldName,
 				Type:         fieldType,
 				OriginalType: typeNode.Content(source),
+				Static:       static,
 			})
 		case "method_declaration", "constructor_declaration":
-			var public bool
+			var public, static bool
 			// Rename the type based on the public/static rules
 			if node.NamedChild(0).Type() == "modifiers" {
 				for _, modifier := range nodeutil.UnnamedChildrenOf(node.NamedChild(0)) {
-					if modifier.Type() == "public" {
+					switch modifier.Type() {
+					case "public":
 						public = true
+					case "static":
+						static = true
 					}
 				}
 			}
@@ -117,6 +137,8 @@ func parseClassScope(root *sitter.Node, source []byte) *ClassScope {
 				Name:         HandleExportStatus(public, name),
 				OriginalName: name,
 				Parameters:   []*Definition{},
+				// Constructors are always declared as package-level functions
+				Static: static || node.Type() == "constructor_declaration",
 			}
 
 			if node.Type() == "method_declaration" {
@@ -164,6 +186,10 @@ func parseClassScope(root *sitter.Node, source []byte) *ClassScope {
 				if !methodScope.IsEmpty() {
 					declaration.Children = append(declaration.Children, methodScope.Children...)
 				}
+				analyzeCaptures(declaration, node.ChildByFieldName("body"), source)
+				analyzeCollections(declaration, node.ChildByFieldName("body"), source)
+			} else if !static {
+				abstractMethods++
 			}
 
 			scope.Methods = append(scope.Methods, declaration)
@@ -175,26 +201,166 @@ func parseClassScope(root *sitter.Node, source []byte) *ClassScope {
 		}
 	}
 
+	// Default and static methods can't be represented by a function type
+	scope.Functional = root.Type() == "interface_declaration" && abstractMethods == 1 && len(scope.Methods) == 1
+
 	return scope
 }
 
+// parseScope parses a block of code, and returns a definition for the scope
+// that contains every local variable declared in it, as well as the scopes
+// that are nested within it
 func parseScope(root *sitter.Node, source []byte) *Definition {
-	def := &Definition{}
+	def := &Definition{
+		StartByte: root.StartByte(),
+		EndByte:   root.EndByte(),
+	}
+	parseScopeChildren(def, root, source)
+	return def
+}
+
+// parseScopeChildren adds every local declaration found in the children of
+// the given node to the scope
+func parseScopeChildren(scope *Definition, root *sitter.Node, source []byte) {
 	for _, node := range nodeutil.NamedChildrenOf(root) {
-		switch node.Type() {
-		case "local_variable_declaration":
-			/*
-				name := nodeToStr(ParseExpr(node.ChildByFieldName("declarator").ChildByFieldName("name"), source, Ctx{}))
-				def.Children = append(def.Children, &symbol.Definition{
-					OriginalName: name,
-					OriginalType: node.ChildByFieldName("type").Content(source),
-					Type:         nodeToStr(ParseExpr(node.ChildByFieldName("type"), source, Ctx{})),
-					Name:         name,
-				})
-			*/
-		case "for_statement", "enhanced_for_statement", "while_statement", "if_statement":
-			def.Children = append(def.Children, parseScope(node, source))
+		parseScopeNode(scope, node, source)
+	}
+}
+
+// parseScopeNode adds the local declarations in a single node to the scope
+func parseScopeNode(scope *Definition, node *sitter.Node, source []byte) {
+	switch node.Type() {
+	case "local_variable_declaration":
+		typeNode := node.ChildByFieldName("type")
+		for _, declarator := range nodeutil.NamedChildrenOfType(node, "variable_declarator") {
+			scope.Children = append(scope.Children, localDefinition(
+				declarator.ChildByFieldName("name").Content(source),
+				typeNode,
+				source,
+				declarator.StartByte(),
+				scope.EndByte,
+			))
+			// Initial values may contain lambdas, which declare their own scopes
+			if value := declarator.ChildByFieldName("value"); value != nil {
+				parseScopeNode(scope, value, source)
+			}
+		}
+	case "block", "for_statement", "switch_block", "try_with_resources_statement":
+		// Any variables declared in the initializer of a for loop, or the
+		// resources of a `try` statement are visible only within that statement
+		scope.Children = append(scope.Children, parseScope(node, source))
+	case "resource":
+		// A resource is either a declaration, or a reference to an existing variable
+		if node.ChildByFieldName("name") != nil {
+			scope.Children = append(scope.Children, localDefinition(
+				node.ChildByFieldName("name").Content(source),
+				node.ChildByFieldName("type"),
+				source,
+				node.StartByte(),
+				scope.EndByte,
+			))
 		}
+		parseScopeChildren(scope, node, source)
+	case "enhanced_for_statement":
+		loopScope := parseScope(node, source)
+		loopScope.Children = append([]*Definition{localDefinition(
+			node.ChildByFieldName("name").Content(source),
+			node.ChildByFieldName("type"),
+			source,
+			node.ChildByFieldName("name").StartByte(),
+			node.EndByte(),
+		)}, loopScope.Children...)
+		scope.Children = append(scope.Children, loopScope)
+	case "catch_clause":
+		catchScope := parseScope(node, source)
+		param := node.NamedChild(0)
+		// A catch can be for multiple types, such as `IOException | RuntimeException`
+		// and since there is no single type for these, the original type is kept
+		var catchTypes *sitter.Node
+		for _, child := range nodeutil.NamedChildrenOf(param) {
+			if child.Type() == "catch_type" {
+				catchTypes = child
+			}
+		}
+		var catchType *sitter.Node
+		if catchTypes.NamedChildCount() == 1 {
+			catchType = catchTypes.NamedChild(0)
+		}
+		paramDef := localDefinition(
+			param.ChildByFieldName("name").Content(source),
+			catchType,
+			source,
+			param.StartByte(),
+			node.EndByte(),
+		)
+		paramDef.OriginalType = catchTypes.Content(source)
+		catchScope.Children = append([]*Definition{paramDef}, catchScope.Children...)
+		scope.Children = append(scope.Children, catchScope)
+	case "lambda_expression":
+		lambdaScope := &Definition{
+			StartByte: node.StartByte(),
+			EndByte:   node.EndByte(),
+		}
+
+		params := node.ChildByFieldName("parameters")
+		switch params.Type() {
+		case "identifier":
+			// A single parameter, such as `n -> n + 1`
+			lambdaScope.Children = append(lambdaScope.Children, localDefinition(
+				params.Content(source), nil, source, node.StartByte(), node.EndByte(),
+			))
+		case "inferred_parameters":
+			for _, param := range nodeutil.NamedChildrenOf(params) {
+				lambdaScope.Children = append(lambdaScope.Children, localDefinition(
+					param.Content(source), nil, source, node.StartByte(), node.EndByte(),
+				))
+			}
+		case "formal_parameters":
+			for _, param := range nodeutil.NamedChildrenOf(params) {
+				var name string
+				var paramType *sitter.Node
+				if param.Type() == "spread_parameter" {
+					name = param.NamedChild(1).ChildByFieldName("name").Content(source)
+					paramType = param.NamedChild(0)
+				} else {
+					name = param.ChildByFieldName("name").Content(source)
+					paramType = param.ChildByFieldName("type")
+				}
+				lambdaScope.Children = append(lambdaScope.Children, localDefinition(
+					name, paramType, source, node.StartByte(), node.EndByte(),
+				))
+			}
+		}
+
+		// A lambda that returns another lambda, such as `x -> y -> x + y`, has the
+		// inner lambda as its body, which declares its own parameters
+		if body := node.ChildByFieldName("body"); body.Type() == "block" {
+			parseScopeChildren(lambdaScope, body, source)
+		} else {
+			parseScopeNode(lambdaScope, body, source)
+		}
+		scope.Children = append(scope.Children, lambdaScope)
+	case "class_body":
+		// Anonymous classes, as well as local classes, have their own scopes
+	default:
+		parseScopeChildren(scope, node, source)
+	}
+}
+
+// localDefinition creates the definition for a local variable, that is
+// visible within the given range of the source
+// If the variable's type is not known, such as with lambda parameters, the
+// type node may be nil
+func localDefinition(name string, typeNode *sitter.Node, source []byte, start, end uint32) *Definition {
+	def := &Definition{
+		Name:         name,
+		OriginalName: name,
+		StartByte:    start,
+		EndByte:      end,
+	}
+	if typeNode != nil {
+		def.OriginalType = typeNode.Content(source)
+		def.Type = nodeToStr(astutil.ParseType(typeNode, source))
 	}
 	return def
 }
@@ -0,0 +1,57 @@
+package symbol
+
+import "strings"
+
+// The streams in `java.util.stream`, which are implemented by the `stream`
+// package in `stdjava`, mapped to their Go types
+var javaStreams = map[string]string{
+	"Stream":    "*stream.Stream",
+	"IntStream": "stream.IntStream",
+}
+
+// StandardClass returns the name of a class from one of Java's packages, such
+// as `Arrays` from `java.util`, without its package, or an empty string if the
+// name refers to another class instead
+//
+// Classes in the file's package, and classes that are imported from other
+// packages, take priority over Java's classes, like they do in Java
+func (fs *FileScope) StandardClass(className, pkg string) string {
+	className = strings.TrimSpace(className)
+	if qualified := strings.TrimPrefix(className, pkg+"."); qualified != className {
+		return qualified
+	}
+	if fs != nil {
+		if fs.findClassScope(strings.Split(className, ".")[0]) != nil {
+			return ""
+		}
+		if path, imported := fs.Imports[className]; imported && path != pkg {
+			return ""
+		}
+	}
+	return className
+}
+
+// JavaStream returns the name of the stream from `java.util.stream` that a
+// Java type refers to, without its type arguments, or an empty string if it
+// isn't one of them
+func (fs *FileScope) JavaStream(javaType string) string {
+	className, _ := SplitTypeArguments(strings.TrimSpace(javaType))
+	className = fs.StandardClass(className, "java.util.stream")
+	if _, known := javaStreams[className]; !known {
+		return ""
+	}
+	return className
+}
+
+// streamGoType converts one of the streams from `java.util.stream` into its Go
+// type, where the elements of a raw `Stream` are `Object`s
+func (fs *FileScope) streamGoType(stream string, arguments []string, typeParameters []string) string {
+	if stream != "Stream" {
+		return javaStreams[stream]
+	}
+	element := "any"
+	if len(arguments) > 0 {
+		element = fs.goType(arguments[0], typeParameters)
+	}
+	return javaStreams[stream] + "[" + element + "]"
+}
@@ -1,11 +1,18 @@
 package symbol
 
 import (
+	"strings"
+
 	sitter "github.com/smacker/go-tree-sitter"
 )
 
-// Go reserved keywords that are not Java keywords, and create invalid code
-var reservedKeywords = []string{"chan", "defer", "fallthrough", "func", "go", "map", "range", "select", "struct", "type"}
+// Go reserved keywords, most of which are not Java keywords, and create invalid code
+var reservedKeywords = []string{
+	"break", "case", "chan", "const", "continue", "default", "defer", "else",
+	"fallthrough", "for", "func", "go", "goto", "if", "import", "interface",
+	"map", "package", "range", "return", "select", "struct", "switch", "type",
+	"var",
+}
 
 // IsReserved tests if a given identifier conflicts with a Go reserved keyword
 func IsReserved(name string) bool {
@@ -17,28 +24,85 @@ func IsReserved(name string) bool {
 	return false
 }
 
+// Go's predeclared identifiers, which are valid names in Java, but shadow Go's
+// built-in types and functions when they are declared as variables
+var predeclaredIdentifiers = []string{
+	"any", "append", "bool", "byte", "cap", "clear", "close", "comparable",
+	"complex", "complex64", "complex128", "copy", "delete", "error", "false",
+	"float32", "float64", "imag", "int", "int8", "int16", "int32", "int64",
+	"iota", "len", "make", "max", "min", "new", "nil", "panic", "print",
+	"println", "real", "recover", "rune", "string", "true", "uint", "uint8",
+	"uint16", "uint32", "uint64", "uintptr",
+}
+
+// ShadowsPredeclared tests if a given identifier would shadow one of Go's
+// predeclared identifiers, such as `len` or `string`
+func ShadowsPredeclared(name string) bool {
+	for _, predeclared := range predeclaredIdentifiers {
+		if predeclared == name {
+			return true
+		}
+	}
+	return false
+}
+
+// The packages that the generated code can import, which can be hidden by a
+// declaration of the same name
+// Formatted as map[PackageName: import/path]
+var importedPackages = map[string]string{
+	"fmt":     "fmt",
+	"math":    "math",
+	"os":      "os",
+	"stdjava": "github.com/NickyBoy89/java2go/stdjava",
+	"strconv": "strconv",
+	"stream":  "github.com/NickyBoy89/java2go/stdjava/stream",
+	"strings": "strings",
+}
+
+// ShadowsPackage tests if a given identifier would hide one of the packages that
+// the generated code imports
+func ShadowsPackage(name string) bool {
+	_, imported := importedPackages[name]
+	return imported
+}
+
+// PackagePath returns the import path for one of the packages that the
+// generated code can import, and whether that package is known
+func PackagePath(name string) (string, bool) {
+	path, imported := importedPackages[name]
+	return path, imported
+}
+
+// Collides tests if a given identifier can not be declared in a function or
+// package scope, because it is either a keyword, or it would hide one of Go's
+// predeclared identifiers or an imported package
+func Collides(name string) bool {
+	return IsReserved(name) || ShadowsPredeclared(name) || ShadowsPackage(name)
+}
+
 // TypeOfLiteral returns the corresponding type for a Java literal
 func TypeOfLiteral(node *sitter.Node, source []byte) string {
 	var originalType string
 
 	switch node.Type() {
-	case "decimal_integer_literal":
+	case "decimal_integer_literal", "hex_integer_literal", "octal_integer_literal", "binary_integer_literal":
 		switch node.Content(source)[len(node.Content(source))-1] {
-		case 'L':
+		case 'L', 'l':
 			originalType = "long"
 		default:
 			originalType = "int"
 		}
-	case "hex_integer_literal":
-		panic("here")
-	case "decimal_floating_point_literal":
+	case "decimal_floating_point_literal", "hex_floating_point_literal":
+		// Floating point literals are doubles, unless they are marked as a float
 		switch node.Content(source)[len(node.Content(source))-1] {
-		case 'D':
-			originalType = "double"
-		default:
+		case 'F', 'f':
 			originalType = "float"
+		default:
+			originalType = "double"
 		}
-	case "string_literal":
+	case "true", "false":
+		originalType = "boolean"
+	case "string_literal", "text_block":
 		originalType = "String"
 	case "character_literal":
 		originalType = "char"
@@ -53,17 +117,45 @@ func TypeOfLiteral(node *sitter.Node, source []byte) string {
 // Resolving a definition means that the type of the file is matched up with the type defined
 // in the local scope or otherwise
 func ResolveDefinition(definition *Definition, fileScope *FileScope) bool {
+	// Boxed types are pointers to their primitive values
+	if primitive := UnboxedType(definition.OriginalType); primitive != definition.OriginalType {
+		definition.Type = "*" + PrimitiveTypes[primitive]
+		return true
+	}
+
+	// Functional interfaces are translated into function types
+	if signature := fileScope.FunctionalSignatureOf(definition.OriginalType); signature != nil && !definition.Constructor {
+		definition.Type = fileScope.GoType(definition.OriginalType)
+		return true
+	}
+
+	// The collections and optionals from `java.util`, and the streams from
+	// `java.util.stream`, are types in `stdjava`
+	if fileScope.JavaCollection(definition.OriginalType) != "" || fileScope.JavaOptional(definition.OriginalType) != "" || fileScope.JavaStream(definition.OriginalType) != "" {
+		definition.Type = fileScope.GoType(definition.OriginalType)
+		return true
+	}
+
+	// Classes may be renamed, so look them up by the type that they originally had
+	typeName := BaseTypeName(definition.OriginalType)
+	// Constructors have no original type, and return the type that they construct
+	if definition.Constructor {
+		typeName = definition.Type
+	}
+
 	// Look in the class scope first
 	//if localClassDef := fileScope.FindClass().ByType(definition.Type); localClassDef != nil {
-	if localClassDef := fileScope.BaseClass.FindClass(definition.Type); localClassDef != nil {
+	if localClassDef := fileScope.BaseClass.FindClass(typeName); localClassDef != nil {
 		// Every type in the local scope is a reference type, so prefix it with a pointer
 		definition.Type = "*" + localClassDef.Name
 		return true
 
-	} else if globalDef, in := fileScope.Imports[definition.Type]; in { // Look through the imports
+	} else if globalDef, in := fileScope.Imports[typeName]; in { // Look through the imports
 		// Find what package the type is in
 		if packageDef := GlobalScope.FindPackage(globalDef); packageDef != nil {
-			definition.Type = packageDef.FindClass(definition.Type).FindClass(definition.Type).Type
+			if class := packageDef.FindClass(typeName); class != nil {
+				definition.Type = "*" + class.FindClass(typeName).Name
+			}
 		}
 		return true
 	}
@@ -72,6 +164,16 @@ func ResolveDefinition(definition *Definition, fileScope *FileScope) bool {
 	return false
 }
 
+// BaseTypeName removes any type arguments from a Java type, so that it can be
+// looked up as a class
+// Ex: List<String> -> List
+func BaseTypeName(javaType string) string {
+	if ind := strings.IndexByte(javaType, '<'); ind != -1 {
+		return javaType[:ind]
+	}
+	return javaType
+}
+
 // ResolveChildren recursively resolves a definition and all of its children
 // It returns true if all definitions were resolved correctly, and false otherwise
 func ResolveChildren(definition *Definition, fileScope *FileScope) bool {
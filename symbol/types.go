@@ -0,0 +1,170 @@
+package symbol
+
+import (
+	"strings"
+
+	"github.com/NickyBoy89/java2go/astutil"
+	"golang.org/x/exp/slices"
+)
+
+// PrimitiveTypes maps each of Java's primitive types to the Go types that they
+// are represented as
+var PrimitiveTypes = map[string]string{
+	"boolean": "bool",
+	"byte":    "int8",
+	"short":   "int16",
+	"char":    "rune",
+	"int":     "int32",
+	"long":    "int64",
+	"float":   "float32",
+	"double":  "float64",
+}
+
+// The primitive types that each of Java's boxed types contain
+var boxedTypes = map[string]string{
+	"Boolean":   "boolean",
+	"Byte":      "byte",
+	"Short":     "short",
+	"Character": "char",
+	"Integer":   "int",
+	"Long":      "long",
+	"Float":     "float",
+	"Double":    "double",
+}
+
+// UnboxedType returns the primitive type that a boxed type, such as `Integer`,
+// contains, or the type itself if it is not a boxed type
+func UnboxedType(javaType string) string {
+	if primitive, boxed := boxedTypes[javaType]; boxed {
+		return primitive
+	}
+	return javaType
+}
+
+// BoxedType returns the boxed type of a primitive type, such as `Integer` for
+// an `int`, or the type itself if it is not a primitive type
+func BoxedType(javaType string) string {
+	for boxed, primitive := range boxedTypes {
+		if primitive == javaType {
+			return boxed
+		}
+	}
+	return javaType
+}
+
+// SplitTypeArguments splits a Java type into the name of its class, and the
+// type arguments that it was given
+// Ex: Map<String, List<Integer>> -> Map, [String, List<Integer>]
+func SplitTypeArguments(javaType string) (string, []string) {
+	start := strings.IndexByte(javaType, '<')
+	end := strings.LastIndexByte(javaType, '>')
+	if start == -1 || end < start {
+		return javaType, nil
+	}
+
+	var arguments []string
+	var depth int
+	argumentStart := start + 1
+	for ind := start + 1; ind < end; ind++ {
+		switch javaType[ind] {
+		case '<':
+			depth++
+		case '>':
+			depth--
+		case ',':
+			if depth == 0 {
+				arguments = append(arguments, strings.TrimSpace(javaType[argumentStart:ind]))
+				argumentStart = ind + 1
+			}
+		}
+	}
+	if argument := strings.TrimSpace(javaType[argumentStart:end]); argument != "" {
+		arguments = append(arguments, argument)
+	}
+
+	return javaType[:start], arguments
+}
+
+// GoType converts the name of a Java type into the Go type that it is
+// represented as, including the classes that are defined in the file's package,
+// and functional interfaces, which become function types
+func (fs *FileScope) GoType(javaType string) string {
+	return fs.goType(javaType, nil)
+}
+
+// goType converts a Java type into a Go type, where any of the given type
+// parameters are left as they are
+func (fs *FileScope) goType(javaType string, typeParameters []string) string {
+	javaType = strings.TrimSpace(javaType)
+
+	switch {
+	case strings.HasSuffix(javaType, "[]"):
+		return "[]" + fs.goType(strings.TrimSuffix(javaType, "[]"), typeParameters)
+	case javaType == "?":
+		return "any"
+	case strings.HasPrefix(javaType, "? extends "):
+		return fs.goType(strings.TrimPrefix(javaType, "? extends "), typeParameters)
+	case strings.HasPrefix(javaType, "? super "):
+		return fs.goType(strings.TrimPrefix(javaType, "? super "), typeParameters)
+	case javaType == "String":
+		return "string"
+	case javaType == "Object":
+		return "any"
+	case javaType == "StringBuilder" || javaType == "StringBuffer":
+		return "*stdjava.StringBuilder"
+	case slices.Contains(typeParameters, javaType):
+		return javaType
+	}
+
+	if goType, primitive := PrimitiveTypes[javaType]; primitive {
+		return goType
+	}
+	// Boxed types are pointers to their primitive values, where `null` is nil,
+	// including when they are type arguments or the elements of arrays
+	if primitive, boxed := boxedTypes[javaType]; boxed {
+		return "*" + PrimitiveTypes[primitive]
+	}
+
+	if _, exception := astutil.JavaExceptions[javaType]; exception {
+		return "stdjava.Thrown"
+	}
+
+	if named := fs.namedFunctionalType(javaType, typeParameters); named != "" {
+		return named
+	}
+	if signature := fs.FunctionalSignatureOf(javaType); signature != nil {
+		return signature.goType(fs)
+	}
+
+	className, arguments := SplitTypeArguments(javaType)
+	if collection := fs.JavaCollection(className); collection != "" {
+		return fs.collectionGoType(collection, arguments, typeParameters)
+	}
+	if stream := fs.JavaStream(className); stream != "" {
+		return fs.streamGoType(stream, arguments, typeParameters)
+	}
+	if optional := fs.JavaOptional(className); optional != "" {
+		return fs.optionalGoType(optional, arguments, typeParameters)
+	}
+	if class := fs.findClassScope(className); class != nil {
+		return "*" + class.Class.Name
+	}
+
+	// Generic types are referred to directly, and other classes are references
+	if len(arguments) > 0 {
+		return className
+	}
+	return "*" + className
+}
+
+// findClassScope looks for a class in the file, and then in the rest of the
+// file's package
+func (fs *FileScope) findClassScope(name string) *ClassScope {
+	if class := fs.FindClassScope(name); class != nil {
+		return class
+	}
+	if pkg := GlobalScope.FindPackage(fs.Package); pkg != nil {
+		return pkg.FindClass(name)
+	}
+	return nil
+}
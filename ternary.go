@@ -0,0 +1,207 @@
+package main
+
+import (
+	"go/ast"
+	"go/token"
+
+	"github.com/NickyBoy89/java2go/symbol"
+	sitter "github.com/smacker/go-tree-sitter"
+)
+
+// Go has no conditional operator, so ternary expressions are lowered into
+// `if` statements, which only evaluate the branch that is chosen
+//
+// Each branch ends with a statement that uses the branch's value, such as
+// assigning it to a variable, or returning it
+
+// A ternarySink creates the statements that use the value of a branch
+type ternarySink func(value ast.Expr, valueNode *sitter.Node) []ast.Stmt
+
+// isTernary tests if an expression is a ternary expression, which may be
+// inside of parentheses
+func isTernary(node *sitter.Node) bool {
+	return node != nil && unwrapParentheses(node).Type() == "ternary_expression"
+}
+
+// unwrapParentheses returns the expression inside of any parentheses
+func unwrapParentheses(node *sitter.Node) *sitter.Node {
+	for node.Type() == "parenthesized_expression" {
+		node = node.NamedChild(0)
+	}
+	return node
+}
+
+// lowerTernary translates a ternary expression into the statements that
+// evaluate it, passing the value of each branch to the sink
+//
+// A chain of ternaries, such as `a ? x : b ? y : z`, becomes a switch
+// statement, unless one of its conditions has to hoist statements
+func lowerTernary(node *sitter.Node, source []byte, ctx Ctx, sink ternarySink) []ast.Stmt {
+	// Flatten the ternaries that are nested in the alternatives of the chain
+	var conditions, branches []*sitter.Node
+	for node = unwrapParentheses(node); node.Type() == "ternary_expression"; node = unwrapParentheses(node.ChildByFieldName("alternative")) {
+		conditions = append(conditions, node.ChildByFieldName("condition"))
+		branches = append(branches, node.ChildByFieldName("consequence"))
+	}
+	otherwise := node
+
+	conds := make([]ast.Expr, len(conditions))
+	condHoisted := make([][]ast.Stmt, len(conditions))
+	bodies := make([][]ast.Stmt, len(conditions))
+	chained := true
+	for ind, condition := range conditions {
+		conds[ind], condHoisted[ind] = parseCondition(condition, source, ctx)
+		bodies[ind] = ternaryBranch(branches[ind], source, ctx, sink)
+		if ind > 0 && len(condHoisted[ind]) > 0 {
+			chained = false
+		}
+	}
+	otherwiseBody := ternaryBranch(otherwise, source, ctx, sink)
+
+	// The first condition is always evaluated
+	stmts := condHoisted[0]
+
+	if chained && len(conds) > 1 {
+		clauses := make([]ast.Stmt, len(conds)+1)
+		for ind, cond := range conds {
+			clauses[ind] = &ast.CaseClause{List: []ast.Expr{cond}, Body: bodies[ind]}
+		}
+		clauses[len(conds)] = &ast.CaseClause{Body: otherwiseBody}
+		return append(stmts, &ast.SwitchStmt{Body: &ast.BlockStmt{List: clauses}})
+	}
+
+	// Otherwise, build the chain from the inside out
+	var alternative ast.Stmt = &ast.BlockStmt{List: otherwiseBody}
+	for ind := len(conds) - 1; ind >= 0; ind-- {
+		ifStmt := &ast.IfStmt{Cond: conds[ind], Body: &ast.BlockStmt{List: bodies[ind]}, Else: alternative}
+		alternative = ifStmt
+		if ind > 0 && len(condHoisted[ind]) > 0 {
+			alternative = &ast.BlockStmt{List: append(condHoisted[ind], ifStmt)}
+		}
+	}
+	return append(stmts, alternative)
+}
+
+// ternaryBranch translates a single branch of a ternary, which is only
+// evaluated if the branch is chosen
+func ternaryBranch(node *sitter.Node, source []byte, ctx Ctx, sink ternarySink) []ast.Stmt {
+	if isTernary(node) {
+		return lowerTernary(node, source, ctx, sink)
+	}
+	value, hoisted := parseCondition(node, source, ctx)
+	return append(hoisted, sink(value, node)...)
+}
+
+// assignTernary lowers a ternary into statements that assign its value to a
+// target, converted to the type of the target
+func assignTernary(node *sitter.Node, target ast.Expr, targetType string, source []byte, ctx Ctx) []ast.Stmt {
+	return lowerTernary(node, source, ctx, func(value ast.Expr, valueNode *sitter.Node) []ast.Stmt {
+		return []ast.Stmt{&ast.AssignStmt{
+			Lhs: []ast.Expr{target},
+			Tok: token.ASSIGN,
+			Rhs: []ast.Expr{AssignmentConversion(value, valueNode, source, ctx, targetType)},
+		}}
+	})
+}
+
+// returnTernary lowers a ternary into statements that return its value
+func returnTernary(node *sitter.Node, source []byte, ctx Ctx, resultType string) []ast.Stmt {
+	return lowerTernary(node, source, ctx, func(value ast.Expr, valueNode *sitter.Node) []ast.Stmt {
+		return ctx.returnStmts(AssignmentConversion(value, valueNode, source, ctx, resultType))
+	})
+}
+
+// ternaryType returns the Java type of a ternary with the given branches,
+// which follows Java's rules for numeric ternaries when both of the branches
+// are numbers, where an int constant that fits in the other branch's byte,
+// short, or char type is given that type, such as the `0` in `b ? 'a' : 0`
+func ternaryType(consequence, alternative *sitter.Node, source []byte, ctx Ctx) string {
+	first, second := ExpressionType(consequence, source, ctx), ExpressionType(alternative, source, ctx)
+	switch {
+	// A primitive value and `null` are boxed
+	case consequence.Type() == "null_literal" && isPrimitive(second):
+		return symbol.BoxedType(second)
+	case alternative.Type() == "null_literal" && isPrimitive(first):
+		return symbol.BoxedType(first)
+	case first == "" || first == "null":
+		return second
+	case second == "" || second == "null" || first == second:
+		return first
+	}
+
+	// A box and a primitive value, or two different boxes, are unboxed
+	unboxedFirst, unboxedSecond := symbol.UnboxedType(first), symbol.UnboxedType(second)
+	switch {
+	case !isNumeric(unboxedFirst) || !isNumeric(unboxedSecond):
+		return first
+	case unboxedFirst == unboxedSecond:
+		return unboxedFirst
+	case fitsConstant(alternative, source, unboxedFirst):
+		return unboxedFirst
+	case fitsConstant(consequence, source, unboxedSecond):
+		return unboxedSecond
+	case unboxedFirst == "byte" && unboxedSecond == "short", unboxedFirst == "short" && unboxedSecond == "byte":
+		return "short"
+	}
+	return binaryPromotion(unboxedFirst, unboxedSecond)
+}
+
+// fitsConstant tests if an expression is an int constant that can be
+// represented by one of the smaller integral types
+func fitsConstant(node *sitter.Node, source []byte, javaType string) bool {
+	value, ok := integerConstant(node, source)
+	if !ok || isLongLiteral(node, source) {
+		return false
+	}
+	switch javaType {
+	case "byte":
+		return value == int64(int8(value))
+	case "short":
+		return value == int64(int16(value))
+	case "char":
+		return value == int64(uint16(value))
+	}
+	return false
+}
+
+// ternaryExpression translates a ternary that is used as part of another
+// expression
+//
+// If the expression is in a statement, then the ternary's value is assigned
+// to a temporary variable beforehand. Otherwise, the ternary becomes a
+// function that returns its value, which is called immediately
+func ternaryExpression(node *sitter.Node, source []byte, ctx Ctx) ast.Expr {
+	javaType := ExpressionType(node, source, ctx)
+	goType := ctx.currentFile.GoType(javaType)
+	if goType == "" {
+		goType = "any"
+	}
+
+	if ctx.hoisted == nil {
+		if ctx.temporaries == nil {
+			ctx.temporaries = new(int)
+		}
+		return &ast.CallExpr{Fun: &ast.FuncLit{
+			Type: &ast.FuncType{
+				Params:  &ast.FieldList{},
+				Results: &ast.FieldList{List: []*ast.Field{&ast.Field{Type: &ast.Ident{Name: goType}}}},
+			},
+			Body: &ast.BlockStmt{List: returnTernary(node, source, ctx, javaType)},
+		}}
+	}
+
+	result := ctx.temporaryName()
+	ctx.hoist(&ast.DeclStmt{Decl: &ast.GenDecl{
+		Tok:   token.VAR,
+		Specs: []ast.Spec{&ast.ValueSpec{Names: []*ast.Ident{result}, Type: &ast.Ident{Name: goType}}},
+	}})
+	ctx.hoist(assignTernary(node, result, javaType, source, ctx)...)
+	return result
+}
+
+// splitLast hoists all of the statements except for the last one, which is
+// returned, so that a list of statements can be used as a single statement
+func (ctx Ctx) splitLast(stmts []ast.Stmt) ast.Stmt {
+	ctx.hoist(stmts[:len(stmts)-1]...)
+	return stmts[len(stmts)-1]
+}
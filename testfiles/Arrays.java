@@ -0,0 +1,52 @@
+class Arrays {
+  static int sum(int[] values) {
+    int total = 0;
+    for (int i = 0; i < values.length; i++) {
+      total += values[i];
+    }
+    return total;
+  }
+
+  static int count(Object[] values) {
+    return values.length;
+  }
+
+  public static void main(String[] args) {
+    int[] literal = new int[] {1, 2, 3};
+    long[] widened = {1, 2, literal[0]};
+    int[][] jagged = new int[][] {{1}, {2, 3}};
+    int[][] grid = {{1, 2}, {3, 4}};
+
+    int size = 4;
+    double[] zeroes = new double[size];
+    char[][] board = new char[3][size];
+    int[][][] cube = new int[2][3][4];
+    String[][] rows = new String[size][];
+    // Any number of dimensions can be given lengths
+    long[][][][][][] deep = new long[2][size][1][2][3][];
+    rows[0] = new String[] {"a", "b"};
+
+    int[] copy = literal.clone();
+    copy[0] = 10;
+    System.out.println(literal[0] + " " + copy[0] + " " + sum(copy));
+    System.out.println(jagged[1].length + grid.length + cube[1][2].length);
+    System.out.println(zeroes.length + board[2].length + widened[2]);
+    System.out.println(deep[1][3][0][1].length + " " + (deep[1][3][0][1][2] == null));
+
+    String[] words = {"x", "y"};
+    Object[] objects = words;
+    System.out.println(count(words) + count(objects) + rows[0].length);
+
+    // Both arrays share the same elements
+    objects[0] = "z";
+    words[1] += "w";
+    System.out.println(words[0] + objects[1]);
+    String[] back = (String[]) objects;
+    System.out.println(back[0] + back.length);
+    try {
+      objects[1] = 1;
+    } catch (ArrayStoreException e) {
+      System.out.println("stored: " + words[1]);
+    }
+  }
+}
@@ -0,0 +1,113 @@
+import java.util.ArrayList;
+import java.util.HashMap;
+import java.util.List;
+import java.util.Map;
+import java.util.function.Function;
+
+public class Boxed {
+    Integer count;
+
+    Boxed self() {
+        return this;
+    }
+
+    static Integer twice(Integer x) {
+        return x * 2;
+    }
+
+    static Integer find(List<Integer> values, int target) {
+        for (Integer value : values) {
+            if (value == target) {
+                return value;
+            }
+        }
+        return null;
+    }
+
+    static int sum(List<Integer> values) {
+        int total = 0;
+        for (Integer value : values) {
+            total += value;
+        }
+        return total;
+    }
+
+    public static void main(String[] args) {
+        // Only small values share the same box
+        Integer a = 127;
+        Integer b = 127;
+        Integer c = 128;
+        Integer d = 128;
+        System.out.println((a == b) + " " + (c == d) + " " + c.equals(d));
+
+        Integer missing = null;
+        System.out.println("missing: " + missing);
+        System.out.println(missing == null);
+
+        int doubled = twice(5);
+        Long big = 5L;
+        big += 10;
+        Double ratio = 2.5;
+        Boolean flag = true;
+        Character letter = 'x';
+        System.out.println(doubled + " " + big + " " + ratio * 2 + " " + !flag + " " + letter);
+
+        Integer counter = 0;
+        counter++;
+        ++counter;
+        counter += 3;
+        System.out.println("counter: " + counter);
+
+        // The box is only looked up once when it is replaced
+        Boxed holder = new Boxed();
+        holder.count = 1;
+        holder.self().count += 2;
+        holder.self().count++;
+        System.out.println("holder: " + holder.count);
+
+        Object object = a;
+        Integer back = (Integer) object;
+        System.out.println(back + 1);
+
+        List<Integer> list = new ArrayList<>();
+        list.add(a);
+        list.add(c);
+        Integer first = list.get(0);
+        System.out.println(first + " " + sum(list) + " " + find(list, 128) + " " + find(list, 5));
+
+        System.out.println(Integer.parseInt("42") + Integer.valueOf(3));
+        System.out.println(Integer.MAX_VALUE + 1);
+        System.out.println(Long.MIN_VALUE + " " + Double.MAX_VALUE);
+        System.out.println(Integer.compare(a, c) + " " + Integer.toString(255, 16) + " " + a.toString());
+        System.out.println(Double.parseDouble("1.5") + Long.parseLong("-7"));
+        System.out.println(Boolean.parseBoolean("TRUE") + " " + Character.compare('a', 'c'));
+        System.out.println(c.compareTo(a) + " " + ratio.intValue() + " " + a.hashCode());
+
+        Function<String, Integer> parse = Integer::parseInt;
+        System.out.println(parse.apply("12") + list.stream().reduce(0, Integer::sum));
+
+        // Boxed type arguments and array elements can hold null
+        Map<String, Integer> counts = new HashMap<>();
+        Integer none = counts.get("missing");
+        Integer old = counts.put("a", 1);
+        Integer replaced = counts.put("a", 2);
+        System.out.println(none + " " + (counts.get("missing") == null) + " " + old + " " + replaced + " " + (counts.get("a") + 1));
+        list.add(null);
+        System.out.println(list.size() + " " + list.get(2) + " " + (list.get(2) == null) + " " + list.contains(null));
+        Integer[] boxes = new Integer[2];
+        boxes[1] = 7;
+        System.out.println((boxes[0] == null) + " " + boxes[1] * 2 + " " + (boxes.length > 1 ? boxes[0] : a));
+
+        try {
+            Integer.parseInt("abc");
+        } catch (NumberFormatException e) {
+            System.out.println(e.getMessage());
+        }
+        try {
+            int unboxed = missing;
+            System.out.println(unboxed);
+        } catch (NullPointerException e) {
+            System.out.println("null unboxed");
+        }
+    }
+}
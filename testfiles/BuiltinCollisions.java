@@ -0,0 +1,54 @@
+/*
+ * Tests for names that are valid in Java, but conflict with Go's keywords,
+ * built-in functions and types, imported packages, or the method receiver
+ */
+public class BuiltinCollisions {
+  // Static fields are global variables, and would hide the built-in `len`
+  private static int len = 0;
+
+  // Instance fields are only accessed through the struct, so they are fine
+  private int cap;
+
+  // A struct can't have a field and a method with the same name
+  private int count;
+
+  // Static methods are package-level functions
+  private static int copy(int var) {
+    return var;
+  }
+
+  private int count() {
+    return count;
+  }
+
+  // `bs` is the receiver of this method, and `strings` is an imported package
+  public int sum(int bs, String strings) {
+    int error = copy(bs) + cap + len;
+    return error + count();
+  }
+
+  private static int total;
+
+  private static int helper(int value) {
+    return value + 1;
+  }
+
+  private static int strconv() {
+    return 0;
+  }
+
+  // Locals can't hide the static fields and methods, which are declared at the
+  // package level, including the ones that were renamed themselves
+  public static void add(int value) {
+    int total = value * 2;
+    BuiltinCollisions.total += total;
+    int helper = helper(value);
+    int strconv = strconv() + helper;
+    BuiltinCollisions.total += strconv;
+  }
+
+  public static void main(String[] args) {
+    BuiltinCollisions collisions = new BuiltinCollisions();
+    System.out.println(collisions.sum(1, "2"));
+  }
+}
@@ -0,0 +1,44 @@
+import java.util.List;
+import java.util.ArrayList;
+
+public class Captures {
+    private String name = "captures";
+
+    public static List<Runnable> printers(String[] words) {
+        List<Runnable> printers = new ArrayList<>();
+        // Each lambda sees the word from its own iteration
+        for (String word : words) {
+            printers.add(() -> System.out.println(word));
+        }
+        // Variables declared inside of the loop are already new each iteration
+        for (int i = 0; i < words.length; i++) {
+            int index = i;
+            printers.add(() -> System.out.println(index));
+        }
+        return printers;
+    }
+
+    public int count(String[] words) {
+        // A single-element array lets the lambda change the count
+        final int[] counter = {0};
+        long[] total = new long[1];
+        for (String word : words) {
+            Runnable increment = () -> {
+                counter[0]++;
+                total[0] += word.length();
+            };
+            increment.run();
+        }
+        System.out.println(total[0]);
+
+        // An array that is used as a whole can't be replaced
+        int[] values = {1};
+        Runnable printer = () -> System.out.println(values.length);
+        printer.run();
+
+        Runnable named = this.name::length;
+        named.run();
+
+        return counter[0];
+    }
+}
@@ -0,0 +1,29 @@
+/*
+ * Tests for character and string literals with Java's escape sequences, and
+ * for indexing into strings by their UTF-16 chars
+ */
+public class CharLiterals {
+  public static void main(String[] args) {
+    char letter = 'A';
+    char bell = '\7';
+    char quote = '\'';
+    char high = '\uD83D';
+    char next = (char) (letter + 1);
+    int code = letter - 'A';
+
+    String escaped = "tab\there\101\s\"quoted\"\0";
+    String emoji = "smile 😀";
+    String unicode = "café";
+
+    int length = emoji.length();
+    char first = emoji.charAt(0);
+    char half = emoji.charAt(6);
+    String joined = "char: " + first;
+
+    for (int i = 0; i < escaped.length(); i++) {
+      if (escaped.charAt(i) == 'A') {
+        code++;
+      }
+    }
+  }
+}
@@ -0,0 +1,84 @@
+import java.util.ArrayList;
+import java.util.ArrayDeque;
+import java.util.ConcurrentModificationException;
+import java.util.Deque;
+import java.util.HashMap;
+import java.util.HashSet;
+import java.util.Iterator;
+import java.util.LinkedList;
+import java.util.List;
+import java.util.Map;
+import java.util.Set;
+
+public class Collections {
+  public static List<String> names() {
+    List<String> names = new ArrayList<>();
+    names.add("carol");
+    names.add("alice");
+    names.add(1, "bob");
+    return names;
+  }
+
+  public static void main(String[] args) {
+    List<String> names = names();
+    names.sort((a, b) -> a.compareTo(b));
+    System.out.println(names);
+    System.out.println(names.get(0) + " " + names.size() + " " + names.indexOf("carol"));
+
+    // remove(int) removes by index, and remove(Object) by value
+    List<Integer> numbers = new ArrayList<>();
+    for (int i = 0; i < 5; i++) {
+      numbers.add(i * 10);
+    }
+    numbers.remove(1);
+    Integer thirty = 30;
+    numbers.remove(thirty);
+    System.out.println(numbers + " " + numbers.contains(40));
+
+    Map<String, Integer> counts = new HashMap<>();
+    String[] words = {"a", "b", "a", "c", "a", "b"};
+    for (int i = 0; i < words.length; i++) {
+      counts.merge(words[i], 1, (x, y) -> x + y);
+    }
+    System.out.println(counts + " " + counts.get("a") + " " + counts.getOrDefault("z", 0));
+
+    Set<String> seen = new HashSet<>(names);
+    System.out.println(seen.contains("bob") + " " + seen.add("bob"));
+
+    Deque<Integer> stack = new ArrayDeque<>();
+    stack.push(1);
+    stack.push(2);
+    System.out.println(stack.pop() + " " + stack.peek());
+
+    LinkedList<String> queue = new LinkedList<>();
+    queue.offer("first");
+    queue.addFirst("zeroth");
+    System.out.println(queue.poll() + " " + queue.getLast());
+
+    Iterator<String> it = names.iterator();
+    while (it.hasNext()) {
+      if (it.next().equals("bob")) {
+        it.remove();
+      }
+    }
+    System.out.println(names);
+
+    // Changing a list while iterating over it fails fast
+    try {
+      Iterator<Integer> failing = numbers.iterator();
+      while (failing.hasNext()) {
+        numbers.add(failing.next());
+      }
+    } catch (ConcurrentModificationException e) {
+      System.out.println("concurrent modification");
+    }
+
+    // Diamonds that a lambda returns take their types from its interface
+    Map<String, List<Integer>> groups = new HashMap<>();
+    groups.computeIfAbsent("a", k -> new ArrayList<>()).add(1);
+    groups.computeIfAbsent("a", k -> {
+      return new ArrayList<>();
+    }).add(2);
+    System.out.println(groups);
+  }
+}
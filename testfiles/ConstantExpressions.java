@@ -0,0 +1,27 @@
+/*
+ * Tests for constant expressions, which are evaluated with Java's arithmetic,
+ * where doubles are rounded after every operation, and ints wrap around when
+ * they overflow
+ */
+public class ConstantExpressions {
+  public static void main(String[] args) {
+    System.out.println(0.1 + 0.2 == 0.3);
+    System.out.println("a" + (0.1 + 0.2));
+    float third = 1.0f / 3;
+    System.out.println(third);
+    System.out.println(1.0 / 0);
+
+    int ov = 2147483647 * 2;
+    System.out.println(ov);
+    System.out.println(0x7fff_ffff + 1);
+    long wide = 2147483647 * 2L;
+    System.out.println(wide);
+    long overflow = 9223372036854775807L + 1;
+    System.out.println(overflow);
+    int inverted = ~0 * 3;
+    System.out.println(inverted);
+    // Constants that don't overflow are left as they are
+    int seconds = 60 * 60 * 24;
+    System.out.println(seconds);
+  }
+}
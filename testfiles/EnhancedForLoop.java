@@ -1,12 +1,73 @@
+import java.util.ArrayList;
+import java.util.HashMap;
+import java.util.Iterator;
+import java.util.List;
+import java.util.Map;
+
 /*
  * Tests for correct behavior around enhanced for loops
  */
 class EnhancedForLoop {
+  // A class that can be iterated over, since it implements Iterable
+  static class Countdown implements Iterable<Integer> {
+    private int start;
+
+    public Countdown(int start) {
+      this.start = start;
+    }
+
+    public Iterator<Integer> iterator() {
+      List<Integer> values = new ArrayList<>();
+      for (int i = start; i > 0; i--) {
+        values.add(i);
+      }
+      return values.iterator();
+    }
+  }
+
   public static void main(String[] args) {
     String[] words = {"this", "should", "be", "iterated", "over"};
 
     for (String word : words) {
       System.out.println(word);
     }
+
+    // The elements are widened to the type of the variable
+    int[] numbers = {1, 2, 3};
+    long total = 0;
+    for (long number : numbers) {
+      total += number * 1000000000;
+    }
+    System.out.println(total);
+
+    for (char c : "hi".toCharArray()) {
+      System.out.println(c);
+    }
+
+    List<Integer> list = new ArrayList<>();
+    list.add(4);
+    list.add(5);
+    int sum = 0;
+    for (int element : list) {
+      sum += element;
+    }
+    System.out.println(sum);
+
+    Map<String, Integer> ages = new HashMap<>();
+    ages.put("alice", 30);
+    ages.put("bob", 25);
+    for (Map.Entry<String, Integer> entry : ages.entrySet()) {
+      System.out.println(entry.getKey() + " " + entry.getValue());
+    }
+    for (String name : ages.keySet()) {
+      System.out.println(name);
+    }
+    for (var age : ages.values()) {
+      System.out.println(age);
+    }
+
+    for (int count : new Countdown(3)) {
+      System.out.println(count);
+    }
   }
 }
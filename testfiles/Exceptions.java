@@ -0,0 +1,153 @@
+class Exceptions {
+  static class Node {
+    int value;
+  }
+
+  static class InvalidValueException extends IllegalStateException {
+    int code;
+
+    InvalidValueException(String message, int code) {
+      super(message);
+      this.code = code;
+    }
+  }
+
+  static class NegativeValueException extends InvalidValueException {
+    NegativeValueException(int value) {
+      super("negative value " + value, 2);
+    }
+  }
+
+  static int divide(int a, int b) {
+    try {
+      return a / b;
+    } catch (ArithmeticException e) {
+      System.out.println("Caught: " + e.getMessage());
+      return 0;
+    } finally {
+      System.out.println("Divided " + a + " by " + b);
+    }
+  }
+
+  static int element(int[] values, int index) {
+    try {
+      return values[index];
+    } catch (ArrayIndexOutOfBoundsException | NullPointerException e) {
+      return -1;
+    }
+  }
+
+  static int valueOf(Node node) {
+    try {
+      return node.value;
+    } catch (RuntimeException e) {
+      System.out.println(e.toString());
+    }
+    return -1;
+  }
+
+  static void validate(int value) {
+    if (value < 0) {
+      throw new IllegalArgumentException("negative value " + value);
+    }
+  }
+
+  static void check(int value) {
+    if (value < 0) {
+      throw new NegativeValueException(value);
+    }
+    if (value > 9) {
+      throw new InvalidValueException("large value " + value, 1);
+    }
+  }
+
+  static void log(Exception e) {
+    System.out.println("Logged: " + e.getMessage());
+  }
+
+  static RuntimeException make(String message) {
+    return new IllegalStateException(message);
+  }
+
+  static int firstValid(int[] values) {
+    int found = -1;
+    for (int value : values) {
+      try {
+        if (value < 0) {
+          continue;
+        }
+        check(value);
+        found = value;
+        break;
+      } catch (InvalidValueException e) {
+        log(e);
+      }
+    }
+    return found;
+  }
+
+  static int search(int[][] grid, int target) {
+    outer:
+    for (int[] row : grid) {
+      for (int value : row) {
+        try {
+          if (value == target) {
+            return value;
+          }
+          if (value < 0) {
+            continue outer;
+          }
+          if (value > 100) {
+            break outer;
+          }
+        } finally {
+          System.out.print(value + " ");
+        }
+      }
+    }
+    return -1;
+  }
+
+  public static void main(String[] args) {
+    System.out.println(divide(6, 3) + divide(1, 0));
+    System.out.println(element(new int[] {1, 2}, 5));
+    System.out.println(valueOf(null));
+
+    try {
+      validate(-1);
+    } catch (IllegalStateException e) {
+      System.out.println("Wrong exception");
+    } catch (Exception e) {
+      System.out.println(e.getMessage());
+    }
+
+    try {
+      check(-2);
+    } catch (RuntimeException e) {
+      System.out.println(e.getMessage());
+    }
+
+    for (int value : new int[] {-3, 10}) {
+      try {
+        check(value);
+      } catch (InvalidValueException e) {
+        System.out.println(e.getMessage() + " " + e.code);
+      }
+    }
+
+    System.out.println(firstValid(new int[] {-1, 12, 3, 4}));
+    System.out.println(search(new int[][] {{1, -2, 5}, {7, 200, 9}}, 9));
+    System.out.println(search(new int[][] {{1, -2, 5}, {7, 200, 9}}, 7));
+    try {
+      throw make("made");
+    } catch (RuntimeException e) {
+      log(e);
+    }
+
+    try {
+      throw new UnsupportedOperationException();
+    } catch (UnsupportedOperationException ignored) {
+      System.out.println("Unsupported");
+    }
+  }
+}
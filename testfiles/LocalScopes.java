@@ -0,0 +1,60 @@
+import java.util.function.IntFunction;
+import java.util.function.IntUnaryOperator;
+
+/*
+ * Tests for the handling of local variables, and the scopes that they are
+ * declared in. Some of these variables are named the same as Go's built-in
+ * functions and types, and must be renamed everywhere that they are used
+ */
+public class LocalScopes {
+  public static int sum(int[] values) {
+    // `len` is a built-in function in Go
+    int len = 0;
+    for (int i = 0; i < values.length; i++) {
+      // `string` is a built-in type in Go
+      int string = values[i];
+      len += string;
+    }
+
+    // Variables declared in sibling blocks can have different types
+    {
+      String copy = "copy";
+      System.out.println(copy);
+    }
+    {
+      int copy = 1;
+      len += copy;
+    }
+
+    for (int value : values) {
+      len += value;
+    }
+
+    // Every variable of a declaration is declared, not only the first
+    int low, high;
+    int first = values[0], last = values[values.length - 1];
+    low = first;
+    high = last;
+    len += high - low;
+
+    for (int i = 0, j = values.length - 1; i < j; i++) {
+      len += values[j] - values[i];
+    }
+
+    return len;
+  }
+
+  public static void main(String[] args) {
+    int[] values = {1, 2, 3};
+    // Lambda parameters are locals as well
+    Runnable printer = () -> {
+      int map = sum(values);
+      System.out.println(map);
+    };
+    printer.run();
+
+    // The parameters of a lambda that is returned by another lambda are renamed too
+    IntFunction<IntUnaryOperator> adder = x -> len -> x + len;
+    System.out.println(adder.apply(1).applyAsInt(2));
+  }
+}
@@ -0,0 +1,50 @@
+import java.util.ArrayList;
+import java.util.List;
+
+public class MathMethods {
+    static double distance(double x1, double y1, double x2, double y2) {
+        return Math.sqrt(Math.pow(x2 - x1, 2) + Math.pow(y2 - y1, 2));
+    }
+
+    public static void main(String[] args) {
+        int a = -7;
+        long big = 10_000_000_000L;
+        float f = 2.5f;
+        double d = -2.5;
+        short s = 3;
+
+        // Each overload is chosen from the types of the arguments
+        System.out.println(Math.max(a, 4) + " " + Math.min(big, a) + " " + Math.max(f, 3) + " " + Math.min(d, 0));
+        System.out.println(Math.abs(a) + " " + Math.abs(Integer.MIN_VALUE) + " " + Math.abs(d) + " " + Math.abs(s));
+        System.out.println(Math.floorDiv(a, 2) + " " + Math.floorMod(a, 3) + " " + Math.floorMod(big, 7) + " " + (a % 3));
+        System.out.println(Math.round(d) + " " + Math.round(f) + " " + Math.round(0.49999999999999994) + " " + Math.round(Double.NaN));
+
+        long rounded = Math.round(d * 100);
+        int roundedFloat = Math.round(f);
+        System.out.println(rounded + roundedFloat);
+
+        System.out.println(distance(0, 0, 3, 4) + " " + Math.hypot(3, 4) + " " + Math.cbrt(27));
+        System.out.println(Math.floor(d) + " " + Math.ceil(d) + " " + Math.rint(d) + " " + Math.signum(d) + " " + Math.signum(f));
+        System.out.println(Math.toDegrees(Math.PI) + " " + StrictMath.sin(0) + " " + Math.E);
+
+        double area = Math.PI * 2 * 2;
+        System.out.println((int) area);
+        double random = Math.random();
+        System.out.println(random >= 0 && random < 1);
+
+        Integer boxed = 12;
+        System.out.println(Math.max(boxed, 5) + " " + Integer.max(3, 9) + " " + Long.min(4, big));
+
+        List<Integer> values = new ArrayList<>();
+        values.add(4);
+        values.add(-9);
+        values.add(2);
+        System.out.println(values.stream().map(Math::abs).reduce(0, Math::max));
+
+        try {
+            Math.floorMod(a, 0);
+        } catch (ArithmeticException e) {
+            System.out.println(e.getMessage());
+        }
+    }
+}
@@ -0,0 +1,62 @@
+import java.util.function.*;
+
+public class MethodReferences {
+    private int total;
+
+    public MethodReferences(int total) {
+        this.total = total;
+    }
+
+    public MethodReferences() {
+        this(0);
+    }
+
+    public static int twice(int value) {
+        return value * 2;
+    }
+
+    public static long widen(int value) {
+        return value;
+    }
+
+    public int getTotal() {
+        return total;
+    }
+
+    public void add(int amount) {
+        total += amount;
+    }
+
+    public boolean exceeds(long limit) {
+        return total > limit;
+    }
+
+    public static void main(String[] args) {
+        // Static references
+        IntUnaryOperator doubler = MethodReferences::twice;
+        Function<Integer, Long> widener = MethodReferences::widen;
+
+        // Constructor references, which pick the matching overload
+        Supplier<MethodReferences> empty = MethodReferences::new;
+        IntFunction<MethodReferences> withTotal = MethodReferences::new;
+        IntFunction<int[]> makeArray = int[]::new;
+
+        MethodReferences counter = withTotal.apply(doubler.applyAsInt(3));
+
+        // Bound instance references
+        IntConsumer adder = counter::add;
+        Predicate<Long> over = counter::exceeds;
+        adder.accept(5);
+
+        // Unbound instance references
+        ToIntFunction<MethodReferences> getter = MethodReferences::getTotal;
+        ToIntFunction<String> length = String::length;
+
+        int[] values = makeArray.apply(getter.applyAsInt(counter));
+        System.out.println(length.applyAsInt("hello") + values.length);
+        System.out.println(over.test(widener.apply(10)));
+
+        Runnable printer = empty.get()::getTotal;
+        printer.run();
+    }
+}
@@ -0,0 +1,79 @@
+import java.util.ArrayList;
+import java.util.HashMap;
+import java.util.List;
+import java.util.Map;
+
+public class NativeCollections {
+  public static int wordCount(String[] words) {
+    Map<String, Integer> counts = new HashMap<>();
+    for (String word : words) {
+      if (counts.containsKey(word)) {
+        counts.put(word, counts.get(word) + 1);
+      } else {
+        counts.put(word, 1);
+      }
+    }
+    counts.remove("the");
+    return counts.size();
+  }
+
+  public static long sumOfSquares(int count) {
+    List<Long> squares = new ArrayList<>(count);
+    for (int i = 0; i < count; i++) {
+      squares.add((long) i * i);
+    }
+    squares.remove(0);
+    squares.set(0, 100L);
+
+    long total = 0;
+    for (long square : squares) {
+      total += square;
+    }
+    for (int i = 0; i < squares.size(); i++) {
+      total += squares.get(i);
+    }
+    return total;
+  }
+
+  public static int keyLengths() {
+    Map<String, Integer> lengths = new HashMap<>();
+    lengths.put("one", 1);
+    lengths.put("three", 3);
+
+    int total = 0;
+    for (String key : lengths.keySet()) {
+      total += key.length();
+    }
+    for (int value : lengths.values()) {
+      total += value;
+    }
+    lengths.clear();
+    return total + (lengths.isEmpty() ? 0 : 1);
+  }
+
+  // These lists are shared with other code, so they are stdjava's collections
+  public static List<String> shared() {
+    List<String> returned = new ArrayList<>();
+    returned.add("returned");
+
+    List<String> printed = new ArrayList<>();
+    printed.add("printed");
+    System.out.println(printed);
+
+    List<Integer> byValue = new ArrayList<>();
+    Integer element = 5;
+    byValue.add(element);
+    byValue.remove(element);
+
+    List<String> result = new ArrayList<>();
+    boolean added = result.add("result");
+    return returned;
+  }
+
+  public static void main(String[] args) {
+    System.out.println(wordCount(new String[] {"the", "cat", "the", "hat"}));
+    System.out.println(sumOfSquares(4));
+    System.out.println(keyLengths());
+    System.out.println(shared());
+  }
+}
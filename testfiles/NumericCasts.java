@@ -0,0 +1,42 @@
+/*
+ * Tests for casts between primitive types, which follow Java's rules for
+ * truncation, saturation, and wraparound, as well as casts of references
+ */
+public class NumericCasts {
+  public static void main(String[] args) {
+    double d = 3.7;
+    int i = (int) d;
+    long l = 3000000000L;
+    int wrapped = (int) l;
+    char c = (char) i;
+    byte b = (byte) 200;
+    short s = (short) (i * 1000);
+    byte fromDouble = (byte) d;
+    float f = (float) d;
+    double back = (double) i;
+    Object o = "text";
+    String str = (String) o;
+    int unknown = (int) Math.floor(d);
+
+    // Constants are converted the same way as any other value
+    float infinite = (float) 1e40;
+    int notANumber = (int) (0.0 / 0.0);
+    long saturated = (long) -1e30;
+    char fromFloat = (char) 65.9f;
+  }
+
+  // Casts to the classes that are declared in this file use their Go names
+  static int xOf(Object value) {
+    return ((Point) value).x;
+  }
+
+  static class Point {
+    int x;
+    int y;
+
+    Point(int x, int y) {
+      this.x = x;
+      this.y = y;
+    }
+  }
+}
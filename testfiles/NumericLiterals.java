@@ -0,0 +1,38 @@
+/*
+ * Tests for numeric literals in every base, with underscores and suffixes,
+ * as well as the literals at the edges of each type's range
+ */
+public class NumericLiterals {
+  public static void main(String[] args) {
+    int hex = 0xCAFE;
+    int allBits = 0xFFFFFFFF;
+    int signBit = 0x80000000;
+    long longBits = 0xFFFFFFFFL;
+    long allLongBits = 0xFFFFFFFFFFFFFFFFL;
+    int octal = 0777;
+    int binary = 0b1010_1010;
+    int million = 1_000_000;
+    long lower = 10l;
+    int minInt = -2147483648;
+    long minLong = -9223372036854775808L;
+    long maxLong = 9223372036854775807L;
+    float f = 1.5f;
+    float whole = 2F;
+    double d = 2.5d;
+    double exponent = 1e-3;
+    double half = .5;
+    double hexFloat = 0x1.8p1;
+    float hexFloatF = 0x1p3f;
+    byte narrowed = (byte) 0xFF;
+    long widened = (long) 0xFFFFFFFF;
+    // Negating the smallest value of a type overflows back into itself
+    int negatedSignBit = -0x80000000;
+    long negatedLongSignBit = -0x8000000000000000L;
+    int negatedAllBits = -0xFFFFFFFF;
+    long widenedNegation = (long) -0x80000000;
+    // Negative zero is a different value from zero
+    double negativeZero = -0.0;
+    float negativeZeroF = -0.0f;
+    System.out.println(1 / negativeZero + " " + 1 / negativeZeroF + " " + (0.0 * -1) + " " + (float) -0.0);
+  }
+}
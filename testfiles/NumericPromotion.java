@@ -0,0 +1,41 @@
+/*
+ * Tests for binary numeric promotion, where the operands of arithmetic are
+ * converted to a common type, and for compound assignments that implicitly
+ * narrow their results
+ */
+public class NumericPromotion {
+  static long widen(long value) {
+    return value * 2;
+  }
+
+  static double average(int total, int count) {
+    return total / count;
+  }
+
+  public static void main(String[] args) {
+    int i = 7;
+    long l = i;
+    long sum = i + l;
+    double d = i * 0.5;
+    float f = 1.5f;
+    double mixed = f + d;
+    byte b = 10;
+    short s = 20;
+    int product = b * s;
+    char c = 'a';
+    int next = c + 1;
+    int negated = -b;
+    boolean same = b == 200;
+
+    b += 1;
+    b *= i;
+    c += 2;
+    s -= b;
+    i += 1.5;
+    l += i;
+    l <<= b;
+    int shifted = b << 2;
+    long wide = widen(i);
+    long big = 1L << i;
+  }
+}
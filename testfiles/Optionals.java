@@ -0,0 +1,72 @@
+import java.util.ArrayList;
+import java.util.List;
+import java.util.NoSuchElementException;
+import java.util.Optional;
+import java.util.OptionalInt;
+import java.util.OptionalDouble;
+import java.util.stream.IntStream;
+
+class Optionals {
+  static Optional<String> find(List<String> words, String prefix) {
+    for (String word : words) {
+      if (word.startsWith(prefix)) {
+        return Optional.of(word);
+      }
+    }
+    return Optional.empty();
+  }
+
+  static OptionalInt firstEven(int[] numbers) {
+    for (int number : numbers) {
+      if (number % 2 == 0) {
+        return OptionalInt.of(number);
+      }
+    }
+    return OptionalInt.empty();
+  }
+
+  public static void main(String[] args) {
+    List<String> words = new ArrayList<>();
+    words.add("apple");
+    words.add("banana");
+    words.add("cherry");
+
+    Optional<String> found = find(words, "b");
+    System.out.println(found.isPresent() + " " + found.get());
+    System.out.println(find(words, "z").isEmpty());
+    System.out.println(find(words, "z").orElse("none"));
+    System.out.println(find(words, "c").orElseGet(() -> "unused"));
+    System.out.println(found.map(String::length).orElse(0));
+    System.out.println(found.map(word -> word.toUpperCase()).filter(word -> word.startsWith("B")));
+    System.out.println(found.flatMap(word -> find(words, word.substring(0, 1))));
+    found.ifPresent(word -> System.out.println("Found " + word));
+    find(words, "z").ifPresentOrElse(word -> System.out.println(word), () -> System.out.println("Missing"));
+
+    Optional<StringBuilder> nothing = Optional.ofNullable(null);
+    System.out.println(nothing);
+    System.out.println(Optional.of(42).equals(Optional.of(42)));
+
+    try {
+      nothing.orElseThrow(() -> new IllegalStateException("nothing here"));
+    } catch (IllegalStateException e) {
+      System.out.println(e.getMessage());
+    }
+    try {
+      nothing.get();
+    } catch (NoSuchElementException e) {
+      System.out.println(e.getMessage());
+    }
+
+    OptionalInt even = firstEven(new int[] {3, 5, 8});
+    System.out.println(even.getAsInt() + " " + firstEven(new int[] {1}).orElse(-1));
+    System.out.println(even);
+
+    System.out.println(words.stream().filter(word -> word.contains("e")).findFirst().get());
+    System.out.println(words.stream().min((a, b) -> a.length() - b.length()).get());
+    System.out.println(words.stream().max((a, b) -> a.length() - b.length()).get());
+    System.out.println(words.stream().reduce((a, b) -> a + b).orElse(""));
+    System.out.println(IntStream.range(0, 0).max().isPresent());
+    OptionalDouble average = IntStream.rangeClosed(1, 4).average();
+    System.out.println(average.getAsDouble());
+  }
+}
@@ -0,0 +1,33 @@
+public class Shifts {
+    public static void main(String[] args) {
+        int value = -9;
+        long wide = -9L;
+        byte small = -16;
+        int distance = 33;
+
+        // Unsigned shifts fill in the top bits with zeroes for the value's width
+        int unsigned = value >>> 2;
+        long unsignedWide = wide >>> 2;
+        int promoted = small >>> 4;
+
+        // Only the lowest bits of the distance are used
+        int masked = value << distance;
+        long maskedWide = wide >> distance;
+        int negative = value >> -1;
+
+        // Constants are shifted with the width of an int
+        int minimum = 1 << 31;
+        int wrapped = 1 << 33;
+        long large = 1L << 40;
+        int variable = 1 << distance;
+
+        value >>>= 28;
+        wide >>>= distance;
+        small >>>= 2;
+        value <<= distance;
+        small >>= distance;
+
+        System.out.println(unsigned + " " + unsignedWide + " " + promoted + " " + masked + " " + maskedWide + " " + negative);
+        System.out.println(minimum + " " + wrapped + " " + large + " " + variable + " " + value + " " + wide + " " + small);
+    }
+}
@@ -0,0 +1,62 @@
+public class SideEffects {
+    private int[] values = new int[8];
+    private int position;
+
+    public static int sum(int a, int b) {
+        return a + b;
+    }
+
+    public int next() {
+        return position++;
+    }
+
+    public void push(int value) {
+        values[position++] = value;
+    }
+
+    public int order(int x) {
+        // Arguments are evaluated from left to right
+        int first = sum(x, x++);
+        int second = sum(++x, x++);
+        int third = sum(x = 10, x += 5);
+        // The current value is read before the increment
+        x += x++;
+        return first + second + third + x;
+    }
+
+    public boolean conditions(int[] data, int i) {
+        // The increment only happens if the first condition is true
+        if (i < data.length && data[i++] > 0) {
+            return true;
+        } else if (data[--i] < 0) {
+            return false;
+        }
+        int a, b;
+        a = b = i;
+        return a == b || i++ > 0;
+    }
+
+    public int count(int[] data) {
+        int lines = 0;
+        int line;
+        while ((line = next()) < data.length) {
+            if (data[line] == 0) {
+                continue;
+            }
+            lines++;
+        }
+        for (int i = 0; (line = next()) < 16; i++) {
+            lines += i;
+        }
+        do {
+            lines--;
+        } while (--lines > 0);
+        return lines;
+    }
+
+    public void compound() {
+        // The target is only evaluated once, even when it is used twice
+        values[next()] += 1.5;
+        values[next()] >>>= 2;
+    }
+}
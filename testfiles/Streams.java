@@ -0,0 +1,107 @@
+import java.util.ArrayList;
+import java.util.Arrays;
+import java.util.List;
+import java.util.Map;
+import java.util.Set;
+import java.util.stream.Collectors;
+import java.util.stream.IntStream;
+import java.util.stream.Stream;
+
+public class Streams {
+  static class Person {
+    String name;
+    int age;
+
+    Person(String name, int age) {
+      this.name = name;
+      this.age = age;
+    }
+
+    String getName() {
+      return name;
+    }
+
+    int getAge() {
+      return age;
+    }
+  }
+
+  public static List<String> longWords(List<String> words) {
+    return words.stream().filter(w -> w.length() > 3).map(w -> w.toUpperCase()).collect(Collectors.toList());
+  }
+
+  public static void main(String[] args) {
+    List<String> words = new ArrayList<>();
+    words.add("stream");
+    words.add("map");
+    words.add("filter");
+    words.add("map");
+    words.add("collect");
+
+    System.out.println(longWords(words));
+
+    // Each element goes through the whole pipeline before the next one
+    List<Integer> lengths = words.stream()
+        .peek(w -> System.out.println("saw " + w))
+        .map(String::length)
+        .limit(2)
+        .collect(Collectors.toList());
+    System.out.println(lengths);
+
+    Set<String> unique = words.stream().collect(Collectors.toSet());
+    System.out.println(unique.size());
+
+    String joined = words.stream().distinct().sorted().collect(Collectors.joining(", ", "[", "]"));
+    System.out.println(joined);
+
+    Map<Integer, List<String>> byLength = words.stream().collect(Collectors.groupingBy(w -> w.length()));
+    System.out.println(byLength.get(3));
+
+    Map<Integer, Long> counts = words.stream().collect(Collectors.groupingBy(String::length, Collectors.counting()));
+    System.out.println(counts.get(3) + " " + counts.get(6));
+
+    long count = words.stream().filter(w -> w.startsWith("m")).count();
+    System.out.println(count);
+
+    int total = words.stream().mapToInt(w -> w.length()).sum();
+    System.out.println(total);
+
+    int[] squares = IntStream.rangeClosed(1, 5).map(i -> i * i).toArray();
+    System.out.println(Arrays.stream(squares).filter(i -> i % 2 == 1).sum());
+
+    List<Person> people = new ArrayList<>();
+    people.add(new Person("Ada", 36));
+    people.add(new Person("Alan", 41));
+    Map<String, Integer> ages = people.stream().collect(Collectors.toMap(Person::getName, p -> p.getAge()));
+    System.out.println(ages.get("Alan"));
+    System.out.println(people.stream().anyMatch(p -> p.getAge() > 40) + " " + people.stream().allMatch(p -> p.getAge() > 40));
+
+    String[] letters = {"c", "a", "b"};
+    String sorted = Arrays.stream(letters).sorted((a, b) -> b.compareTo(a)).reduce("", (a, b) -> a + b);
+    System.out.println(sorted);
+
+    List<String> pairs = Stream.of("a", "b")
+        .flatMap(s -> Stream.of(s + "1", s + "2"))
+        .collect(Collectors.toList());
+    System.out.println(pairs);
+
+    int[] numbers = {3, -1, 4, -1, 5};
+    int positive = Arrays.stream(numbers).filter(n -> n > 0).map(n -> n * 10).sum();
+    System.out.println(positive);
+
+    words.stream().filter(w -> w.length() == 3).forEach(w -> {
+      String shout = w.toUpperCase() + "!";
+      System.out.println(shout);
+    });
+
+    IntStream.range(0, 3).mapToObj(i -> "#" + i).forEach(s -> System.out.println(s));
+
+    Stream<String> once = words.stream();
+    once.count();
+    try {
+      once.count();
+    } catch (IllegalStateException e) {
+      System.out.println("stream reused");
+    }
+  }
+}
@@ -0,0 +1,48 @@
+class StringBuilders {
+  private StringBuilder log;
+
+  StringBuilders() {
+    log = new StringBuilder();
+  }
+
+  void record(String event) {
+    log.append(event).append(';');
+  }
+
+  static String joined(int[] values) {
+    StringBuilder sb = new StringBuilder(values.length * 2);
+    for (int value : values) {
+      if (sb.length() > 0) {
+        sb.append(", ");
+      }
+      sb.append(value);
+    }
+    return sb.toString();
+  }
+
+  public static void main(String[] args) {
+    StringBuilder sb = new StringBuilder("count: ");
+    sb.append(3).append(' ').append(true).append(' ').append(2.5).append(' ').append(10L);
+    System.out.println(sb);
+
+    StringBuffer buffer = new StringBuffer();
+    buffer.append("abc").insert(0, 42).insert(2, '-').reverse();
+    System.out.println(buffer.toString() + " " + buffer.length());
+
+    StringBuilder edited = new StringBuilder("hello world");
+    edited.setCharAt(0, 'H');
+    edited.deleteCharAt(5).replace(5, 10, "There").delete(0, 1);
+    System.out.println(edited + " " + edited.charAt(0) + " " + edited.indexOf("The"));
+    edited.setLength(4);
+    System.out.println(edited.toString() + edited.isEmpty());
+
+    String word = "level";
+    String reversed = new StringBuilder(word).reverse().toString();
+    System.out.println(word.equals(reversed));
+
+    StringBuilders builders = new StringBuilders();
+    builders.record("start");
+    builders.record("stop");
+    System.out.println(builders.log + joined(new int[] {1, 2, 3}));
+  }
+}
@@ -0,0 +1,33 @@
+/*
+ * Tests for string concatenation, where values of every type are converted
+ * into strings the same way that Java formats them
+ */
+public class StringConcatenation {
+  int count;
+
+  public String toString() {
+    return "StringConcatenation(" + count + ")";
+  }
+
+  public static void main(String[] args) {
+    int n = 5;
+    long big = 3000000000L;
+    char c = 'x';
+    boolean flag = true;
+    double d = 1e10;
+    float f = 0.1f;
+    StringConcatenation object = new StringConcatenation();
+    StringConcatenation missing = null;
+
+    String message = "count: " + n;
+    String letters = "char " + c + ", flag " + flag;
+    String numbers = d + " and " + f + " and " + big;
+    String arithmetic = 1 + 2 + "3" + 4 + 5;
+    String objects = "object " + object + ", missing " + missing;
+    String nothing = "null: " + null;
+
+    message += n;
+    message += c;
+    System.out.println("The message is " + message + object.toString());
+  }
+}
@@ -0,0 +1,51 @@
+import java.util.ArrayList;
+import java.util.List;
+
+class Strings {
+  static String describe(String name, int count) {
+    return String.format("%s has %d items%n", name, count);
+  }
+
+  public static void main(String[] args) {
+    String text = "  Hello, World  ";
+    String trimmed = text.trim();
+    System.out.println(trimmed.length() + " " + trimmed.isEmpty());
+    System.out.println(trimmed.substring(7) + trimmed.substring(0, 5));
+    System.out.println(trimmed.indexOf("o") + trimmed.indexOf('o', 5) + trimmed.lastIndexOf('o'));
+    System.out.println(trimmed.toUpperCase() + trimmed.toLowerCase());
+
+    if (trimmed.startsWith("Hello") && !trimmed.endsWith("!") && trimmed.contains(", ")) {
+      System.out.println(trimmed.replace('l', 'L').replace("World", "There"));
+    }
+
+    String[] parts = "a,b,,c,,".split(",");
+    System.out.println(parts.length + String.join("|", parts));
+    List<String> words = new ArrayList<>();
+    words.add("x");
+    words.add("y");
+    System.out.println(String.join(", ", words));
+    for (String word : "one  two three".split("\\s+", 2)) {
+      System.out.println(word);
+    }
+
+    String other = "hello, world";
+    System.out.println(trimmed.equals(other) + " " + trimmed.equalsIgnoreCase(other));
+    System.out.println(trimmed.compareTo(other) + other.replaceAll("(o)", "[$1]"));
+    System.out.println(other.matches("[a-z, ]+") + other.concat("!").repeat(2));
+
+    char initial = trimmed.charAt(0);
+    System.out.println(String.valueOf(initial) + String.valueOf(3.5) + String.valueOf(true));
+    System.out.print(describe("list", 3));
+    System.out.println(String.format("%c|%5.2f|%-6s|%x|%h", initial, 3.14159, "ab", -1, "hi"));
+
+    // The halves of a surrogate pair are joined back together
+    String emoji = "x😀";
+    String joined = "" + emoji.charAt(1) + emoji.charAt(2);
+    String copied = "";
+    for (int i = 0; i < emoji.length(); i++) {
+      copied += emoji.charAt(i);
+    }
+    System.out.println(joined.equals("😀") + " " + copied.equals(emoji) + " " + joined.length());
+    System.out.println(other.hashCode() + " " + emoji.hashCode());
+  }
+}
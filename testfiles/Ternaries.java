@@ -0,0 +1,68 @@
+import java.util.function.IntUnaryOperator;
+
+public class Ternaries {
+    private static String label = Math.random() > 0.5 ? "high" : "low";
+
+    private Ternaries next;
+    private int value;
+
+    public Ternaries() {
+        value = 7;
+    }
+
+    public int valueOf(Ternaries other) {
+        // Only the chosen branch is evaluated
+        return other != null ? other.value : 0;
+    }
+
+    public String describe(int n) {
+        String size = n < 10 ? "small" : n < 100 ? "medium" : n < 1000 ? "large" : "huge";
+        long widened;
+        widened = n > 0 ? n : -1L;
+        System.out.println("Size: " + (n % 2 == 0 ? "even" : "odd") + widened);
+        return size;
+    }
+
+    public int count(int[] data, int i) {
+        int total = data.length > 0 ? data[i++] : i;
+        IntUnaryOperator sign = x -> x > 0 ? 1 : x < 0 ? -1 : 0;
+        // The condition of a later branch only runs if it is reached
+        return total + (i > 0 ? sign.applyAsInt(i) : (i = 5) > 4 ? 1 : 2);
+    }
+
+    public int unknownTarget(boolean flag) {
+        // The interface isn't known, but the lambda still returns its value
+        java.util.function.IntSupplier choice = () -> flag ? 1 : 2;
+        return choice.getAsInt();
+    }
+
+    public static void chars(int i, char c) {
+        // An int constant that fits in a char is a char, the same as the other branch
+        System.out.println(i > 0 ? 'a' : 0);
+        System.out.println(false ? 1 : c);
+        System.out.println(i > 0 ? 'a' : i);
+        Integer boxed = i > 0 ? i : null;
+        System.out.println(boxed);
+    }
+
+    public static int steps() {
+        int k = 0;
+        int total = 0;
+        for (int j = 0; j < 3; j++, k = k == 0 ? 1 : 5) {
+            total += k;
+        }
+        for (int j = 0; j < 3; k = k > 3 ? 0 : k + 2) {
+            total += k;
+            j++;
+        }
+        return total;
+    }
+
+    public static void main(String[] args) {
+        Ternaries ternaries = new Ternaries();
+        System.out.println(ternaries.describe(42) + " " + ternaries.count(new int[] {3}, 0) + " " + ternaries.unknownTarget(true));
+        chars(1, 'x');
+        chars(0, 'x');
+        System.out.println(steps());
+    }
+}
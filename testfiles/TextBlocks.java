@@ -0,0 +1,40 @@
+/*
+ * Tests for text blocks, which have their indentation and trailing spaces
+ * stripped, and their escape sequences processed
+ */
+public class TextBlocks {
+  public static void main(String[] args) {
+    String query = """
+        SELECT id, name
+          FROM users
+         WHERE id = ?
+        """;
+
+    String json = """
+        {
+          "name": "java2go",
+          "tags": ["go", "java"]
+        }""";
+
+    String escaped = """
+        trailing\s
+        joined \
+        together
+        tab\tand "quotes" and \"""
+        """;
+
+    String backquoted = """
+        uses `backquotes`
+        """;
+
+    String indentedClose = """
+          indented
+        """;
+
+    System.out.println(query + json + escaped + backquoted + indentedClose);
+
+    // Ordinary literals after a text block are left as they are
+    String after = "after \"text blocks\"";
+    System.out.println(after + ' ' + '"');
+  }
+}
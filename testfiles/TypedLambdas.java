@@ -0,0 +1,53 @@
+import java.util.function.BiFunction;
+import java.util.function.Function;
+import java.util.function.Predicate;
+import java.util.function.Supplier;
+import java.util.Comparator;
+
+/*
+ * Tests for lambdas that get their types from the functional interface that
+ * they implement
+ */
+public class TypedLambdas {
+  @FunctionalInterface
+  interface Transformer<T, R> {
+    R transform(T value);
+  }
+
+  interface Operation {
+    int apply(int left, int right);
+  }
+
+  static int combine(Operation operation, int left, int right) {
+    return operation.apply(left, right);
+  }
+
+  static Predicate<String> nonEmpty() {
+    return s -> s.length() > 0;
+  }
+
+  public static void main(String[] args) {
+    Function<Integer, Integer> square = x -> x * x;
+    BiFunction<Integer, Long, Long> add = (a, b) -> a + b;
+    Supplier<String> greeting = () -> "hello";
+    Comparator<String> byLength = (a, b) -> a.length() - b.length();
+    Transformer<String, Integer> length = s -> s.length();
+    Runnable print = () -> System.out.println("running");
+    Function<Integer, Integer> block = (Integer x) -> {
+      int doubled = x * 2;
+      return doubled;
+    };
+
+    int squared = square.apply(4);
+    long sum = add.apply(1, 2L);
+    int total = combine((left, right) -> left + right, 1, 2);
+    boolean empty = nonEmpty().test("");
+    int compared = byLength.compare("a", "bb");
+    print.run();
+    Runnable cast = (Runnable) () -> System.out.println("cast");
+
+    // Locals of the functional interfaces in this file have their named types
+    Operation multiply = (left, right) -> left * right;
+    System.out.println(combine(multiply, 3, 4) + length.transform("four"));
+  }
+}
@@ -34,5 +34,11 @@ public class VariableAssignments {
     int var4;
 
     System.out.println(var1 = var2 = var3 = var4 = 10);
+
+    // A variable that is declared as a supertype of its value can later be
+    // assigned anything else of that type
+    Object o = "str";
+    o = Integer.valueOf(5);
+    System.out.println(o);
   }
 }
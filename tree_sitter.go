@@ -46,8 +46,21 @@ type Ctx struct {
 
 	// Used when generating arrays, because in Java, these are defined as
 	// arrType[] varName = {item, item, item}, and no class name data is defined
-	// Can either be of type `*ast.Ident` or `*ast.StarExpr`
-	lastType ast.Expr
+	// This is the original Java type of the variable that is being declared
+	lastType string
+
+	// The original Java type that the current method or lambda returns
+	returnType string
+	// How a `return` leaves the closure of a `try` statement, which is nil
+	// outside of a `try` statement
+	exit *tryExit
+
+	// Statements that have to run before the statement that is being parsed,
+	// such as any assignments or increments within its expressions
+	hoisted *[]ast.Stmt
+	// The number of temporary variables that have been declared in the current
+	// method, so that each of them has a different name
+	temporaries *int
 }
 
 // Clone performs a shallow copy on a `Ctx`, returning a new Ctx with its pointers
@@ -59,6 +72,10 @@ func (c Ctx) Clone() Ctx {
 		currentClass: c.currentClass,
 		localScope:   c.localScope,
 		lastType:     c.lastType,
+		returnType:   c.returnType,
+		exit:         c.exit,
+		hoisted:      c.hoisted,
+		temporaries:  c.temporaries,
 	}
 }
 
@@ -91,6 +108,12 @@ func ParseNode(node *sitter.Node, source []byte, ctx Ctx) interface{} {
 				program.Imports = append(program.Imports, ParseNode(c, source, ctx).(*ast.ImportSpec))
 			}
 		}
+
+		// Import any packages that the generated code refers to
+		if imports := GenImports(program); imports != nil {
+			program.Decls = append([]ast.Decl{imports}, program.Decls...)
+		}
+
 		return program
 	case "field_declaration":
 		var public bool
@@ -104,7 +127,7 @@ func ParseNode(node *sitter.Node, source []byte, ctx Ctx) interface{} {
 		}
 
 		fieldType := ParseExpr(node.ChildByFieldName("type"), source, ctx)
-		fieldName := ParseExpr(node.ChildByFieldName("declarator").ChildByFieldName("name"), source, ctx).(*ast.Ident)
+		fieldName := &ast.Ident{Name: node.ChildByFieldName("declarator").ChildByFieldName("name").Content(source)}
 		fieldName.Name = symbol.HandleExportStatus(public, fieldName.Name)
 
 		// If the field is assigned to a value (ex: int field = 1)
@@ -199,8 +222,7 @@ func ParseNode(node *sitter.Node, source []byte, ctx Ctx) interface{} {
 		stmts := []ast.Stmt{ParseStmt(node.NamedChild(0), source, ctx)}
 		return append(stmts, ParseStmt(node.NamedChild(1), source, ctx).(*ast.BlockStmt).List...)
 	case "try_statement":
-		// We ignore try statements
-		return ParseStmt(node.NamedChild(0), source, ctx).(*ast.BlockStmt).List
+		return TryStatement(node, source, ctx)
 	case "synchronized_statement":
 		// A synchronized statement contains the variable to be synchronized, as
 		// well as the block
@@ -229,7 +251,9 @@ func ParseNode(node *sitter.Node, source []byte, ctx Ctx) interface{} {
 		return params
 	case "formal_parameter":
 		if ctx.localScope != nil {
-			paramDef := ctx.localScope.ParameterByName(node.ChildByFieldName("name").Content(source))
+			// Lambda parameters are stored as locals of the enclosing method
+			nameNode := node.ChildByFieldName("name")
+			paramDef := ctx.localScope.FindVariableAt(nameNode.Content(source), nameNode.StartByte())
 			if paramDef == nil {
 				paramDef = &symbol.Definition{
 					Name: node.ChildByFieldName("name").Content(source),
@@ -269,7 +293,7 @@ func ParseNode(node *sitter.Node, source []byte, ctx Ctx) interface{} {
 			})
 		}
 		return params
-	case "comment": // Ignore comments
+	case "comment", "line_comment", "block_comment": // Ignore comments
 		return nil
 	}
 	panic(fmt.Sprintf("Unknown node type: %v", node.Type()))
@@ -0,0 +1,241 @@
+package main
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/NickyBoy89/java2go/nodeutil"
+	"github.com/NickyBoy89/java2go/symbol"
+	sitter "github.com/smacker/go-tree-sitter"
+)
+
+// TypeInformation contains the Go types of every named symbol in a source
+// file, such as methods, their parameters, and any local variables
+type TypeInformation struct {
+	// Maps the name of each symbol to its type
+	types map[string]string
+}
+
+// ExtractTypeInformation generates the symbol table for a given file, and
+// collects the types of all the symbols declared in it
+func ExtractTypeInformation(root *sitter.Node, source []byte) (TypeInformation, error) {
+	if root.HasError() {
+		return TypeInformation{}, errors.New("source file contains parse errors")
+	}
+
+	info := TypeInformation{types: make(map[string]string)}
+	info.addClass(symbol.ParseSymbols(root, source).BaseClass)
+	return info, nil
+}
+
+func (info TypeInformation) addClass(class *symbol.ClassScope) {
+	for _, field := range class.Fields {
+		info.types[field.OriginalName] = field.Type
+	}
+
+	for _, method := range class.Methods {
+		// Constructors return the type that they construct, and are not named
+		if !method.Constructor {
+			info.types[method.OriginalName] = method.Type
+		}
+		for _, param := range method.Parameters {
+			info.types[param.OriginalName] = param.Type
+		}
+		for _, local := range method.Locals() {
+			info.types[local.OriginalName] = local.Type
+		}
+	}
+
+	for _, subclass := range class.Subclasses {
+		info.addClass(subclass)
+	}
+}
+
+// ExpressionType returns the original Java type of an expression, or an empty
+// string if the type of the expression could not be determined
+func ExpressionType(node *sitter.Node, source []byte, ctx Ctx) string {
+	switch node.Type() {
+	case "parenthesized_expression":
+		return ExpressionType(node.NamedChild(0), source, ctx)
+	case "this":
+		if ctx.currentClass != nil {
+			return ctx.currentClass.Class.OriginalName
+		}
+	case "identifier":
+		if def := findVariable(node, source, ctx); def != nil {
+			return def.OriginalType
+		}
+	case "field_access":
+		if node.ChildByFieldName("field").Content(source) == "length" && isArrayType(ExpressionType(node.ChildByFieldName("object"), source, ctx)) {
+			return "int"
+		}
+		if constant := wrapperFieldType(node, source, ctx); constant != "" {
+			return constant
+		}
+		if constant := mathFieldType(node, source, ctx); constant != "" {
+			return constant
+		}
+		if class := classOfExpression(node.ChildByFieldName("object"), source, ctx); class != nil {
+			if def := class.FindFieldByName(node.ChildByFieldName("field").Content(source)); def != nil {
+				return def.OriginalType
+			}
+		}
+	case "method_invocation":
+		if signature := functionalCall(node, source, ctx); signature != nil {
+			return signature.Result
+		}
+		if isExceptionMethod(node, source, ctx) {
+			return exceptionMethodTypes[node.ChildByFieldName("name").Content(source)]
+		}
+		if isArrayClone(node, source, ctx) {
+			return ExpressionType(node.ChildByFieldName("object"), source, ctx)
+		}
+		if isStringMethod(node, source, ctx) {
+			return stringMethodTypes[node.ChildByFieldName("name").Content(source)]
+		}
+		if isStringBuilderMethod(node, source, ctx) {
+			return stringBuilderMethodType(node.ChildByFieldName("name").Content(source), ExpressionType(node.ChildByFieldName("object"), source, ctx))
+		}
+		if isStringStaticMethod(node, source, ctx) {
+			return stringStaticTypes[node.ChildByFieldName("name").Content(source)]
+		}
+		if result := collectionMethodType(node, source, ctx); result != "" {
+			return result
+		}
+		if result := streamMethodType(node, source, ctx); result != "" {
+			return result
+		}
+		if result := optionalMethodType(node, source, ctx); result != "" {
+			return result
+		}
+		if result := wrapperMethodType(node, source, ctx); result != "" {
+			return result
+		}
+		if result := mathMethodType(node, source, ctx); result != "" {
+			return result
+		}
+		if def := resolveMethod(node, source, ctx); def != nil {
+			return def.OriginalType
+		}
+	case "object_creation_expression":
+		return createdType(node, source, ctx)
+	case "cast_expression":
+		return node.ChildByFieldName("type").Content(source)
+	case "array_access":
+		arrayType := ExpressionType(node.ChildByFieldName("array"), source, ctx)
+		if strings.HasSuffix(arrayType, "[]") {
+			return strings.TrimSuffix(arrayType, "[]")
+		}
+	case "binary_expression":
+		left := ExpressionType(node.Child(0), source, ctx)
+		right := ExpressionType(node.Child(2), source, ctx)
+
+		switch node.Child(1).Content(source) {
+		case "==", "!=", "<", ">", "<=", ">=", "&&", "||":
+			return "boolean"
+		case "<<", ">>", ">>>":
+			// The type of a shift only depends on the value being shifted
+			return unaryPromotion(left)
+		case "+":
+			// Adding anything to a string concatenates the two together
+			if left == "String" || right == "String" {
+				return "String"
+			}
+		case "&", "|", "^":
+			if left == "boolean" && right == "boolean" {
+				return "boolean"
+			}
+		}
+
+		return binaryPromotion(left, right)
+	case "unary_expression":
+		if node.Child(0).Content(source) == "!" {
+			return "boolean"
+		}
+		return unaryPromotion(ExpressionType(node.Child(1), source, ctx))
+	case "update_expression":
+		return ExpressionType(node.NamedChild(0), source, ctx)
+	case "assignment_expression":
+		return ExpressionType(node.Child(0), source, ctx)
+	case "ternary_expression":
+		return ternaryType(node.ChildByFieldName("consequence"), node.ChildByFieldName("alternative"), source, ctx)
+	case "instanceof_expression":
+		return "boolean"
+	case "array_creation_expression":
+		arrayType := node.ChildByFieldName("type").Content(source)
+		for _, child := range nodeutil.NamedChildrenOf(node) {
+			switch child.Type() {
+			case "dimensions_expr":
+				arrayType += "[]"
+			case "dimensions":
+				arrayType += strings.Repeat("[]", strings.Count(child.Content(source), "["))
+			}
+		}
+		return arrayType
+	default:
+		return symbol.TypeOfLiteral(node, source)
+	}
+	return ""
+}
+
+// findVariable looks up the definition for an identifier that refers to a
+// variable, first as a local variable or parameter, then as a field of the
+// current class
+func findVariable(node *sitter.Node, source []byte, ctx Ctx) *symbol.Definition {
+	if ctx.localScope != nil {
+		if def := ctx.localScope.FindVariableAt(node.Content(source), node.StartByte()); def != nil {
+			return def
+		}
+	}
+	if ctx.currentClass != nil {
+		return ctx.currentClass.FindFieldByName(node.Content(source))
+	}
+	return nil
+}
+
+// findClassScope looks up the scope of a class by its original Java type, in
+// the current file first, and then in the current package
+func findClassScope(javaType string, ctx Ctx) *symbol.ClassScope {
+	if ctx.currentFile == nil {
+		return nil
+	}
+	name := symbol.BaseTypeName(javaType)
+	if class := ctx.currentFile.FindClassScope(name); class != nil {
+		return class
+	}
+	if pkg := symbol.GlobalScope.FindPackage(ctx.currentFile.Package); pkg != nil {
+		return pkg.FindClass(name)
+	}
+	return nil
+}
+
+// classOfExpression returns the scope of the class that an expression evaluates
+// to, or that the expression names directly, such as the `Math` in `Math.max`
+func classOfExpression(node *sitter.Node, source []byte, ctx Ctx) *symbol.ClassScope {
+	if node.Type() == "identifier" && findVariable(node, source, ctx) == nil {
+		return findClassScope(node.Content(source), ctx)
+	}
+	return findClassScope(ExpressionType(node, source, ctx), ctx)
+}
+
+// resolveMethod finds the definition of the method that is called by a method
+// invocation, or nil if the method is not defined in any known class
+func resolveMethod(node *sitter.Node, source []byte, ctx Ctx) *symbol.Definition {
+	class := ctx.currentClass
+	if object := node.ChildByFieldName("object"); object != nil {
+		class = classOfExpression(object, source, ctx)
+	}
+	if class == nil {
+		return nil
+	}
+	return class.FindMethodByArguments(node.ChildByFieldName("name").Content(source), argumentTypes(node.ChildByFieldName("arguments"), source, ctx))
+}
+
+// argumentTypes returns the original types of every argument in an argument list
+func argumentTypes(node *sitter.Node, source []byte, ctx Ctx) []string {
+	types := make([]string, node.NamedChildCount())
+	for ind, argument := range nodeutil.NamedChildrenOf(node) {
+		types[ind] = ExpressionType(argument, source, ctx)
+	}
+	return types
+}
@@ -0,0 +1,336 @@
+package main
+
+import (
+	"go/ast"
+	"go/token"
+	"strings"
+
+	"github.com/NickyBoy89/java2go/nodeutil"
+	"github.com/NickyBoy89/java2go/symbol"
+	sitter "github.com/smacker/go-tree-sitter"
+)
+
+// The classes of the boxed types, such as `Integer`, have static methods that
+// work with their primitive values, and their boxes have methods of their own,
+// which are translated into Go's operations on the primitive values
+
+// The Java types that the translated static methods of the boxed types' classes
+// return, where `P` is the class's primitive type, and `B` is the class itself
+//
+// The methods that parse strings are named after the primitive type, such as
+// `parseInt`, and are looked up as `parse`
+var wrapperStaticTypes = map[string]string{
+	"parse":      "P",
+	"valueOf":    "B",
+	"compare":    "int",
+	"toString":   "String",
+	"sum":        "P",
+	"max":        "P",
+	"min":        "P",
+	"isNaN":      "boolean",
+	"isInfinite": "boolean",
+}
+
+// The Java types that the translated methods of boxes return
+var wrapperMethodTypes = map[string]string{
+	"byteValue":    "byte",
+	"shortValue":   "short",
+	"intValue":     "int",
+	"longValue":    "long",
+	"floatValue":   "float",
+	"doubleValue":  "double",
+	"booleanValue": "boolean",
+	"charValue":    "char",
+	"toString":     "String",
+	"equals":       "boolean",
+	"hashCode":     "int",
+	"compareTo":    "int",
+	"isNaN":        "boolean",
+	"isInfinite":   "boolean",
+}
+
+// The functions and variables from `stdjava` that the limits of each of the
+// boxed types are translated into, such as `Integer.MAX_VALUE`
+var wrapperLimits = map[string]map[string]string{
+	"byte":   {"MIN_VALUE": "MinByte", "MAX_VALUE": "MaxByte"},
+	"short":  {"MIN_VALUE": "MinShort", "MAX_VALUE": "MaxShort"},
+	"int":    {"MIN_VALUE": "MinInt", "MAX_VALUE": "MaxInt"},
+	"long":   {"MIN_VALUE": "MinLong", "MAX_VALUE": "MaxLong"},
+	"float":  {"MIN_VALUE": "MinFloat", "MAX_VALUE": "MaxFloat"},
+	"double": {"MIN_VALUE": "MinDouble", "MAX_VALUE": "MaxDouble"},
+}
+
+// wrapperClass returns the name of the boxed type whose class an expression
+// names, such as the `Integer` in `Integer.parseInt`, or an empty string if it
+// doesn't name one of them
+func wrapperClass(node *sitter.Node, source []byte, ctx Ctx) string {
+	if node == nil || ctx.currentFile == nil {
+		return ""
+	}
+	className := ctx.currentFile.StandardClass(node.Content(source), "java.lang")
+	if !isBoxedType(className) || !isStandardReference(node, className, "java.lang", source, ctx) {
+		return ""
+	}
+	return className
+}
+
+// parseMethod returns the name of the static method that parses a primitive
+// type from a string, such as `parseInt`
+func parseMethod(primitive string) string {
+	return "parse" + strings.ToUpper(primitive[:1]) + primitive[1:]
+}
+
+// wrapperStaticType returns the Java type that a static method of one of the
+// boxed types' classes returns, or an empty string if it isn't translated
+func wrapperStaticType(className, name string) string {
+	primitive := symbol.UnboxedType(className)
+	if name == parseMethod(primitive) {
+		name = "parse"
+	}
+	switch result := wrapperStaticTypes[name]; result {
+	case "P":
+		return primitive
+	case "B":
+		return className
+	default:
+		return result
+	}
+}
+
+// wrapperMethodType returns the Java type that a method invocation returns, if
+// it either calls a static method of one of the boxed types' classes, or calls
+// a method of a box, or an empty string if it does neither
+func wrapperMethodType(node *sitter.Node, source []byte, ctx Ctx) string {
+	object := node.ChildByFieldName("object")
+	if object == nil {
+		return ""
+	}
+	name := node.ChildByFieldName("name").Content(source)
+	if className := wrapperClass(object, source, ctx); className != "" {
+		return wrapperStaticType(className, name)
+	}
+	if isBoxedType(ExpressionType(object, source, ctx)) {
+		return wrapperMethodTypes[name]
+	}
+	return ""
+}
+
+// WrapperMethod translates a method invocation that either calls a static
+// method of one of the boxed types' classes, such as `Integer.parseInt`, or
+// calls a method of a box, such as `value.intValue()`
+//
+// Returns nil if the method invocation does neither
+func WrapperMethod(node *sitter.Node, source []byte, ctx Ctx) ast.Expr {
+	object := node.ChildByFieldName("object")
+	if wrapperMethodType(node, source, ctx) == "" {
+		return nil
+	}
+	name := node.ChildByFieldName("name").Content(source)
+	argumentNodes := nodeutil.NamedChildrenOf(node.ChildByFieldName("arguments"))
+
+	ev := ctx.evaluation()
+	var receiver *ast.Expr
+	className := wrapperClass(object, source, ctx)
+	if className == "" {
+		receiver = ev.parse(object, source, identity)
+		className = ExpressionType(object, source, ctx)
+	}
+	arguments := parseArguments(node.ChildByFieldName("arguments"), source, ev)
+
+	// Boxes are unboxed into the primitive values that the methods take, except
+	// for `equals`, which takes any object
+	argumentTypes := make([]string, len(arguments))
+	for ind, argument := range argumentNodes {
+		argumentTypes[ind] = ExpressionType(argument, source, ctx)
+		switch {
+		case receiver != nil && name == "equals":
+			arguments[ind] = ObjectConversion(arguments[ind], argument, source, ctx)
+		case holdsBox(argument, source, ctx):
+			arguments[ind], argumentTypes[ind] = callStdjava("Unbox", arguments[ind]), symbol.UnboxedType(argumentTypes[ind])
+		}
+	}
+
+	if receiver == nil {
+		call, _ := wrapperStaticCall(className, name, arguments, argumentTypes)
+		return call
+	}
+	value := *receiver
+	if holdsBox(object, source, ctx) {
+		value = callStdjava("Unbox", value)
+	}
+	call, _ := wrapperInstanceCall(className, name, value, arguments, argumentTypes)
+	return call
+}
+
+// wrapperStaticCall translates a call to a static method of one of the boxed
+// types' classes, with arguments of the given Java types, and returns the call
+// along with the Java type that it returns
+func wrapperStaticCall(className, name string, arguments []ast.Expr, argumentTypes []string) (ast.Expr, string) {
+	primitive := symbol.UnboxedType(className)
+	goType := &ast.Ident{Name: primitiveTypes[primitive]}
+	result := wrapperStaticType(className, name)
+
+	// The arguments are converted into the class's primitive type
+	converted := func(ind int) ast.Expr {
+		return convertValue(arguments[ind], argumentTypes[ind], primitive)
+	}
+
+	switch {
+	case name == parseMethod(primitive), name == "valueOf" && symbol.UnboxedType(argumentTypes[0]) == "String":
+		parsed := parseValue(primitive, arguments)
+		if name == "valueOf" {
+			return &ast.CallExpr{Fun: &ast.IndexExpr{X: stdjavaType("Box"), Index: goType}, Args: []ast.Expr{parsed}}, result
+		}
+		return parsed, result
+	case name == "valueOf":
+		return &ast.CallExpr{Fun: &ast.IndexExpr{X: stdjavaType("Box"), Index: goType}, Args: []ast.Expr{converted(0)}}, result
+	case name == "compare":
+		switch primitive {
+		case "boolean":
+			return callStdjava("Compare", converted(0), converted(1)), result
+		case "byte", "short", "char":
+			// The smaller types are compared by subtracting them as ints
+			return &ast.BinaryExpr{
+				X:  ConvertPrimitive(converted(0), primitive, "int"),
+				Op: token.SUB,
+				Y:  ConvertPrimitive(converted(1), primitive, "int"),
+			}, result
+		}
+		return callStdjava("CompareNumbers", converted(0), converted(1)), result
+	case name == "toString" && len(arguments) == 2:
+		// A number in another radix
+		value := convertValue(arguments[0], argumentTypes[0], "long")
+		radix := callFunc("int", arguments[1])
+		return &ast.CallExpr{Fun: &ast.SelectorExpr{X: &ast.Ident{Name: "strconv"}, Sel: &ast.Ident{Name: "FormatInt"}}, Args: []ast.Expr{value, radix}}, result
+	case name == "toString":
+		return StringConversion(converted(0), primitive), result
+	case name == "sum":
+		return &ast.BinaryExpr{X: converted(0), Op: token.ADD, Y: converted(1)}, result
+	case name == "max", name == "min":
+		// The same as `Math.max` and `Math.min` for the class's primitive type
+		call, _ := mathCall(name, []ast.Expr{converted(0), converted(1)}, []string{primitive, primitive})
+		return call, result
+	case name == "isNaN", name == "isInfinite":
+		return floatingTest(name, convertValue(arguments[0], argumentTypes[0], "double")), result
+	}
+	return nil, ""
+}
+
+// wrapperInstanceCall translates a call to a method of a box, whose value has
+// already been unboxed, and returns the call along with the Java type that it
+// returns
+func wrapperInstanceCall(className, name string, value ast.Expr, arguments []ast.Expr, argumentTypes []string) (ast.Expr, string) {
+	primitive := symbol.UnboxedType(className)
+	result := wrapperMethodTypes[name]
+
+	switch name {
+	case "toString":
+		return StringConversion(value, primitive), result
+	case "equals":
+		return callStdjava("Equals", value, arguments[0]), result
+	case "hashCode":
+		return callStdjava("HashOf", value), result
+	case "compareTo":
+		return wrapperStaticCall(className, "compare", []ast.Expr{value, arguments[0]}, []string{primitive, argumentTypes[0]})
+	case "isNaN", "isInfinite":
+		return floatingTest(name, ConvertPrimitive(value, primitive, "double")), result
+	}
+	// The methods such as `intValue` convert the value into another primitive type
+	if primitiveTypes[primitive] == primitiveTypes[result] {
+		return value, result
+	}
+	return ConvertPrimitive(value, primitive, result), result
+}
+
+// parseValue translates one of the methods that parse a string into a
+// primitive value, such as `Integer.parseInt`, which may also be given a radix
+func parseValue(primitive string, arguments []ast.Expr) ast.Expr {
+	switch primitive {
+	case "boolean":
+		// Any string other than "true", ignoring case, is false
+		return callStrings("EqualFold", arguments[0], &ast.BasicLit{Kind: token.STRING, Value: `"true"`})
+	case "float":
+		return callStdjava("ParseFloat", arguments[0])
+	case "double":
+		return callStdjava("ParseDouble", arguments[0])
+	}
+
+	radix := ast.Expr(&ast.BasicLit{Kind: token.INT, Value: "10"})
+	if len(arguments) > 1 {
+		radix = arguments[1]
+	}
+	return &ast.CallExpr{
+		Fun:  &ast.IndexExpr{X: stdjavaType("ParseInteger"), Index: &ast.Ident{Name: primitiveTypes[primitive]}},
+		Args: []ast.Expr{arguments[0], radix},
+	}
+}
+
+// floatingTest translates `isNaN` or `isInfinite`, which test a double
+func floatingTest(name string, value ast.Expr) ast.Expr {
+	if name == "isNaN" {
+		return &ast.CallExpr{Fun: &ast.SelectorExpr{X: &ast.Ident{Name: "math"}, Sel: &ast.Ident{Name: "IsNaN"}}, Args: []ast.Expr{value}}
+	}
+	return &ast.CallExpr{
+		Fun:  &ast.SelectorExpr{X: &ast.Ident{Name: "math"}, Sel: &ast.Ident{Name: "IsInf"}},
+		Args: []ast.Expr{value, &ast.BasicLit{Kind: token.INT, Value: "0"}},
+	}
+}
+
+// wrapperFieldType returns the Java type of a field access that refers to one
+// of the constants of the boxed types' classes, such as `Integer.MAX_VALUE`, or
+// an empty string if it doesn't refer to one of them
+func wrapperFieldType(node *sitter.Node, source []byte, ctx Ctx) string {
+	className := wrapperClass(node.ChildByFieldName("object"), source, ctx)
+	if className == "" {
+		return ""
+	}
+	primitive := symbol.UnboxedType(className)
+	switch field := node.ChildByFieldName("field").Content(source); {
+	case field == "MIN_VALUE" || field == "MAX_VALUE":
+		if primitive == "char" || wrapperLimits[primitive] != nil {
+			return primitive
+		}
+	case isFloating(primitive) && (field == "NaN" || field == "POSITIVE_INFINITY" || field == "NEGATIVE_INFINITY"):
+		return primitive
+	}
+	return ""
+}
+
+// WrapperField translates a field access of one of the constants of the boxed
+// types' classes, such as `Integer.MAX_VALUE` or `Double.NaN`
+//
+// Returns nil if the field access doesn't refer to one of them
+func WrapperField(node *sitter.Node, source []byte, ctx Ctx) ast.Expr {
+	primitive := wrapperFieldType(node, source, ctx)
+	if primitive == "" {
+		return nil
+	}
+
+	callMath := func(name string, args ...ast.Expr) ast.Expr {
+		return &ast.CallExpr{Fun: &ast.SelectorExpr{X: &ast.Ident{Name: "math"}, Sel: &ast.Ident{Name: name}}, Args: args}
+	}
+	var value ast.Expr
+	switch field := node.ChildByFieldName("field").Content(source); field {
+	case "NaN":
+		value = callMath("NaN")
+	case "POSITIVE_INFINITY":
+		value = callMath("Inf", &ast.BasicLit{Kind: token.INT, Value: "1"})
+	case "NEGATIVE_INFINITY":
+		value = callMath("Inf", &ast.BasicLit{Kind: token.INT, Value: "-1"})
+	default:
+		if primitive == "char" {
+			// Chars may be represented as runes, so their limits are converted
+			limit := "0"
+			if field == "MAX_VALUE" {
+				limit = "0xFFFF"
+			}
+			return callFunc(primitiveTypes["char"], &ast.BasicLit{Kind: token.INT, Value: limit})
+		}
+		return stdjavaType(wrapperLimits[primitive][field])
+	}
+	// Floats are converted from the doubles that `math` returns
+	if primitive == "float" {
+		return callFunc(primitiveTypes["float"], value)
+	}
+	return value
+}